@@ -24,6 +24,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/ServiceWeaver/weaver/internal/tool/callgraph"
 	"github.com/ServiceWeaver/weaver/internal/tool/generate"
 	"github.com/ServiceWeaver/weaver/internal/tool/multi"
 	"github.com/ServiceWeaver/weaver/internal/tool/single"
@@ -34,6 +35,8 @@ import (
 const usage = `USAGE
 
   weaver generate                 // weaver code generator
+  weaver check-compat <old> <new> // check wire compatibility
+  weaver callgraph <binary>       // print the component graph
   weaver single    <command> ...  // for single process deployments
   weaver multi     <command> ...  // for multiprocess deployments
   weaver ssh       <command> ...  // for multimachine deployments
@@ -44,9 +47,10 @@ DESCRIPTION
 
   Use the "weaver" command to deploy and manage Weaver applications.
 
-  The "weaver generate", "weaver single", "weaver multi", and "weaver ssh"
-  subcommands are baked in, but all other subcommands of the form
-  "weaver <deployer>" dispatch to a binary called "weaver-<deployer>".
+  The "weaver generate", "weaver check-compat", "weaver callgraph",
+  "weaver single", "weaver multi", and "weaver ssh" subcommands are baked
+  in, but all other subcommands of the form "weaver <deployer>" dispatch
+  to a binary called "weaver-<deployer>".
   "weaver gke status", for example, dispatches to "weaver-gke status".
 `
 
@@ -72,8 +76,44 @@ func main() {
 		generateFlags.Usage = func() {
 			fmt.Fprintln(os.Stderr, generate.Usage)
 		}
+		fakes := generateFlags.Bool("fakes", false, "If true, generate fake component implementations for use with weavertest.Fake")
+		schema := generateFlags.String("schema", "", "If non-empty, write the schema of the generated components to this file")
 		generateFlags.Parse(flag.Args()[1:]) //nolint:errcheck // does os.Exit on error
-		if err := generate.Generate(".", flag.Args()[1:], generate.Options{}); err != nil {
+		opts := generate.Options{Fakes: *fakes, Schema: *schema}
+		if err := generate.Generate(".", generateFlags.Args(), opts); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+
+	case "check-compat":
+		checkFlags := flag.NewFlagSet("check-compat", flag.ExitOnError)
+		checkFlags.Usage = func() {
+			fmt.Fprintln(os.Stderr, generate.CheckCompatUsage)
+		}
+		checkFlags.Parse(flag.Args()[1:]) //nolint:errcheck // does os.Exit on error
+		if checkFlags.NArg() != 2 {
+			checkFlags.Usage()
+			os.Exit(1)
+		}
+		if err := generate.CheckCompatFiles(checkFlags.Arg(0), checkFlags.Arg(1)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+
+	case "callgraph":
+		callgraphFlags := flag.NewFlagSet("callgraph", flag.ExitOnError)
+		callgraphFlags.Usage = func() {
+			fmt.Fprintln(os.Stderr, callgraph.Usage)
+		}
+		format := callgraphFlags.String("format", "dot", `Output format ("dot" or "json")`)
+		callgraphFlags.Parse(flag.Args()[1:]) //nolint:errcheck // does os.Exit on error
+		if callgraphFlags.NArg() != 1 {
+			callgraphFlags.Usage()
+			os.Exit(1)
+		}
+		if err := callgraph.Run(os.Stdout, os.Stderr, callgraphFlags.Arg(0), *format); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
@@ -94,6 +134,12 @@ func main() {
 		case n == 2 && command == "generate":
 			// weaver help generate
 			fmt.Fprintln(os.Stdout, generate.Usage)
+		case n == 2 && command == "check-compat":
+			// weaver help check-compat
+			fmt.Fprintln(os.Stdout, generate.CheckCompatUsage)
+		case n == 2 && command == "callgraph":
+			// weaver help callgraph
+			fmt.Fprintln(os.Stdout, callgraph.Usage)
 		case n == 2 && internals[command] != nil:
 			// weaver help <command>
 			fmt.Fprintln(os.Stdout, tool.MainHelp("weaver "+command, internals[command]))
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/ServiceWeaver/weaver/internal/register"
+	"github.com/ServiceWeaver/weaver/runtime"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/slog"
@@ -85,16 +86,14 @@ type component struct {
 	implInit sync.Once      // used to initialize impl, logger
 	implErr  error          // non-nil if impl creation fails
 	impl     *componentImpl // only ever non-nil if this component is local
+	fake     any            // if not nil, used instead of a real implementation
 	logger   *slog.Logger   // read-only after implInit.Do()
 	tracer   trace.Tracer   // read-only after implInit.Do()
 
-	// TODO(mwhittaker): We have one client for every component. Every client
-	// independently maintains network connections to every weavelet hosting
-	// the component. Thus, there may be many redundant network connections to
-	// the same weavelet. Given n weavelets hosting m components, there's at
-	// worst n^2m connections rather than a more optimal n^2 (a single
-	// connection between every pair of weavelets). We should rewrite things to
-	// avoid the redundancy.
+	// We have one client for every component, with its own resolver and
+	// balancer. The clients of all components share the weavelet's pool of
+	// network connections (see transport), so there is a single network
+	// connection between every pair of weavelets.
 	clientInit sync.Once // used to initialize client
 	client     *client   // only evern non-nil if this component is remote or routed
 
@@ -104,6 +103,7 @@ type component struct {
 
 	local register.WriteOnce[bool] // routed locally?
 	load  *loadCollector           // non-nil for routed components
+	calls *runtime.CallConfig      // if not nil, configures calls to the component
 }
 
 var _ Instance = &componentImpl{}
@@ -292,6 +292,29 @@ type ListenerOptions struct {
 // correctness. Only use routing to increase performance in the common case.
 type WithRouter[T any] struct{}
 
+// Retriable is a marker type that indicates that a component method is safe to
+// retry, e.g., because it is read-only or idempotent. To mark a method as
+// retriable, declare a blank variable of type Retriable whose value is the
+// method expression. For example:
+//
+//	type Cache interface {
+//		Get(ctx context.Context, key string) (string, error)
+//		Put(ctx context.Context, key, value string) error
+//	}
+//
+//	var _ weaver.Retriable = Cache.Get
+//	var _ weaver.Retriable = Cache.Put
+//
+// "weaver generate" records the retriable methods of every component. If a
+// call to a retriable method fails because of a network error or because the
+// component is unreachable, the call is automatically retried with
+// exponential backoff, until it succeeds, the caller's context is done, or the
+// retry budget is exhausted. Calls that fail for any other reason, including
+// calls that return an application error, are never retried.
+//
+// Methods that are not marked as retriable are never retried automatically.
+type Retriable interface{}
+
 // AutoMarshal is a type that can be embedded within a struct to indicate that
 // "weaver generate" should generate serialization methods for the struct.
 //
@@ -17,43 +17,48 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/examples/chat/ImageScaler",
-		Iface: reflect.TypeOf((*ImageScaler)(nil)).Elem(),
-		Impl:  reflect.TypeOf(scaler{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return imageScaler_local_stub{impl: impl.(ImageScaler), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/examples/chat/ImageScaler",
+		Iface:   reflect.TypeOf((*ImageScaler)(nil)).Elem(),
+		Impl:    reflect.TypeOf(scaler{}),
+		RefData: "⟦beaa9e35:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/chat/ImageScaler:Scale⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return imageScaler_local_stub{impl: impl.(ImageScaler), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return imageScaler_client_stub{stub: stub, scaleMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/ImageScaler", Method: "Scale"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return imageScaler_client_stub{stub: stub, interceptor: interceptor, scaleMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/ImageScaler", Method: "Scale"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return imageScaler_server_stub{impl: impl.(ImageScaler), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return imageScaler_server_stub{impl: impl.(ImageScaler), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/examples/chat/LocalCache",
-		Iface: reflect.TypeOf((*LocalCache)(nil)).Elem(),
-		Impl:  reflect.TypeOf(localCache{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return localCache_local_stub{impl: impl.(LocalCache), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/examples/chat/LocalCache",
+		Iface:   reflect.TypeOf((*LocalCache)(nil)).Elem(),
+		Impl:    reflect.TypeOf(localCache{}),
+		RefData: "⟦67d0f543:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/chat/LocalCache:Get,Put⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return localCache_local_stub{impl: impl.(LocalCache), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return localCache_client_stub{stub: stub, getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/LocalCache", Method: "Get"}), putMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/LocalCache", Method: "Put"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return localCache_client_stub{stub: stub, interceptor: interceptor, getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/LocalCache", Method: "Get"}), putMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/LocalCache", Method: "Put"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return localCache_server_stub{impl: impl.(LocalCache), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return localCache_server_stub{impl: impl.(LocalCache), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/Main",
-		Iface: reflect.TypeOf((*weaver.Main)(nil)).Elem(),
-		Impl:  reflect.TypeOf(server{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return main_local_stub{impl: impl.(weaver.Main), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/Main",
+		Iface:   reflect.TypeOf((*weaver.Main)(nil)).Elem(),
+		Impl:    reflect.TypeOf(server{}),
+		RefData: "⟦b99f3fa0:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/Main:⟧\n⟦7e1a0aa0:wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→github.com/ServiceWeaver/weaver/examples/chat/SQLStore⟧\n⟦ae108c0d:wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→github.com/ServiceWeaver/weaver/examples/chat/ImageScaler⟧\n⟦c86a1d44:wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→github.com/ServiceWeaver/weaver/examples/chat/LocalCache⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return main_local_stub{impl: impl.(weaver.Main), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any { return main_client_stub{stub: stub} },
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return main_server_stub{impl: impl.(weaver.Main), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return main_client_stub{stub: stub, interceptor: interceptor}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return main_server_stub{impl: impl.(weaver.Main), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
@@ -61,26 +66,49 @@ func init() {
 		Iface:    reflect.TypeOf((*SQLStore)(nil)).Elem(),
 		Impl:     reflect.TypeOf(sqlStore{}),
 		ConfigFn: func(i any) any { return i.(*sqlStore).WithConfig.Config() },
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return sQLStore_local_stub{impl: impl.(SQLStore), tracer: tracer}
+		RefData:  "⟦a7b5f725:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/chat/SQLStore:CreatePost,CreateThread,GetFeed,GetImage⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return sQLStore_local_stub{impl: impl.(SQLStore), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return sQLStore_client_stub{stub: stub, createPostMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/SQLStore", Method: "CreatePost"}), createThreadMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/SQLStore", Method: "CreateThread"}), getFeedMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/SQLStore", Method: "GetFeed"}), getImageMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/SQLStore", Method: "GetImage"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return sQLStore_client_stub{stub: stub, interceptor: interceptor, createPostMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/SQLStore", Method: "CreatePost"}), createThreadMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/SQLStore", Method: "CreateThread"}), getFeedMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/SQLStore", Method: "GetFeed"}), getImageMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/chat/SQLStore", Method: "GetImage"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return sQLStore_server_stub{impl: impl.(SQLStore), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return sQLStore_server_stub{impl: impl.(SQLStore), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦55aa348d:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/Main\",\"methods\":[]},{\"name\":\"github.com/ServiceWeaver/weaver/examples/chat/ImageScaler\",\"methods\":[{\"name\":\"Scale\",\"args\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"byte\"}},{\"kind\":\"int\"},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"byte\"}}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/examples/chat/LocalCache\",\"methods\":[{\"name\":\"Get\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"string\"}]},{\"name\":\"Put\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"}],\"results\":[]}]},{\"name\":\"github.com/ServiceWeaver/weaver/examples/chat/SQLStore\",\"methods\":[{\"name\":\"CreatePost\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"binary\",\"name\":\"time.Time\"},{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.ThreadID\"},{\"kind\":\"string\"}],\"results\":[]},{\"name\":\"CreateThread\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"binary\",\"name\":\"time.Time\"},{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}},{\"kind\":\"string\"},{\"kind\":\"slice\",\"elem\":{\"kind\":\"byte\"}}],\"results\":[{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.ThreadID\"}]},{\"name\":\"GetFeed\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.Thread\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.ThreadID\"}},{\"name\":\"Posts\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.Post\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.PostID\"}},{\"name\":\"Creator\",\"type\":{\"kind\":\"string\"}},{\"name\":\"When\",\"type\":{\"kind\":\"binary\",\"name\":\"time.Time\"}},{\"name\":\"Text\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ImageID\",\"type\":{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.ImageID\"}}]}}}]}}]},{\"name\":\"GetImage\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.ImageID\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"byte\"}}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.Post\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.PostID\"}},{\"name\":\"Creator\",\"type\":{\"kind\":\"string\"}},{\"name\":\"When\",\"type\":{\"kind\":\"binary\",\"name\":\"time.Time\"}},{\"name\":\"Text\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ImageID\",\"type\":{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.ImageID\"}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.Thread\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.ThreadID\"}},{\"name\":\"Posts\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.Post\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.PostID\"}},{\"name\":\"Creator\",\"type\":{\"kind\":\"string\"}},{\"name\":\"When\",\"type\":{\"kind\":\"binary\",\"name\":\"time.Time\"}},{\"name\":\"Text\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ImageID\",\"type\":{\"kind\":\"int64\",\"name\":\"github.com/ServiceWeaver/weaver/examples/chat.ImageID\"}}]}}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type imageScaler_local_stub struct {
-	impl   ImageScaler
-	tracer trace.Tracer
+	impl        ImageScaler
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s imageScaler_local_stub) Scale(ctx context.Context, a0 []byte, a1 int, a2 int) (r0 []byte, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		var r0 []byte
+		err := intercept(ctx, "Scale", func(ctx context.Context) (err error) {
+			r0, err = s.Scale(ctx, a0, a1, a2)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -98,11 +126,27 @@ func (s imageScaler_local_stub) Scale(ctx context.Context, a0 []byte, a1 int, a2
 }
 
 type localCache_local_stub struct {
-	impl   LocalCache
-	tracer trace.Tracer
+	impl        LocalCache
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s localCache_local_stub) Get(ctx context.Context, a0 string) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 string
+		err := intercept(ctx, "Get", func(ctx context.Context) (err error) {
+			r0, err = s.Get(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -120,6 +164,21 @@ func (s localCache_local_stub) Get(ctx context.Context, a0 string) (r0 string, e
 }
 
 func (s localCache_local_stub) Put(ctx context.Context, a0 string, a1 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "Put", func(ctx context.Context) (err error) {
+			err = s.Put(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -137,16 +196,35 @@ func (s localCache_local_stub) Put(ctx context.Context, a0 string, a1 string) (e
 }
 
 type main_local_stub struct {
-	impl   weaver.Main
-	tracer trace.Tracer
+	impl        weaver.Main
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 type sQLStore_local_stub struct {
-	impl   SQLStore
-	tracer trace.Tracer
+	impl        SQLStore
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s sQLStore_local_stub) CreatePost(ctx context.Context, a0 string, a1 time.Time, a2 ThreadID, a3 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		a3 := a3
+		err := intercept(ctx, "CreatePost", func(ctx context.Context) (err error) {
+			err = s.CreatePost(ctx, a0, a1, a2, a3)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -164,6 +242,25 @@ func (s sQLStore_local_stub) CreatePost(ctx context.Context, a0 string, a1 time.
 }
 
 func (s sQLStore_local_stub) CreateThread(ctx context.Context, a0 string, a1 time.Time, a2 []string, a3 string, a4 []byte) (r0 ThreadID, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		a3 := a3
+		a4 := a4
+		var r0 ThreadID
+		err := intercept(ctx, "CreateThread", func(ctx context.Context) (err error) {
+			r0, err = s.CreateThread(ctx, a0, a1, a2, a3, a4)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -181,6 +278,21 @@ func (s sQLStore_local_stub) CreateThread(ctx context.Context, a0 string, a1 tim
 }
 
 func (s sQLStore_local_stub) GetFeed(ctx context.Context, a0 string) (r0 []Thread, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []Thread
+		err := intercept(ctx, "GetFeed", func(ctx context.Context) (err error) {
+			r0, err = s.GetFeed(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -198,6 +310,22 @@ func (s sQLStore_local_stub) GetFeed(ctx context.Context, a0 string) (r0 []Threa
 }
 
 func (s sQLStore_local_stub) GetImage(ctx context.Context, a0 string, a1 ImageID) (r0 []byte, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 []byte
+		err := intercept(ctx, "GetImage", func(ctx context.Context) (err error) {
+			r0, err = s.GetImage(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -218,10 +346,28 @@ func (s sQLStore_local_stub) GetImage(ctx context.Context, a0 string, a1 ImageID
 
 type imageScaler_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	scaleMetrics *codegen.MethodMetrics
 }
 
 func (s imageScaler_client_stub) Scale(ctx context.Context, a0 []byte, a1 int, a2 int) (r0 []byte, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		var r0 []byte
+		err := intercept(ctx, "Scale", func(ctx context.Context) (err error) {
+			r0, err = s.Scale(ctx, a0, a1, a2)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.scaleMetrics.Count.Add(1)
@@ -256,8 +402,8 @@ func (s imageScaler_client_stub) Scale(ctx context.Context, a0 []byte, a1 int, a
 	size += (4 + (len(a0) * 1))
 	size += 8
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	serviceweaver_enc_slice_byte_87461245(enc, a0)
@@ -276,19 +422,35 @@ func (s imageScaler_client_stub) Scale(ctx context.Context, a0 []byte, a1 int, a
 	s.scaleMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_byte_87461245(dec)
 	err = dec.Error()
 	return
 }
 
 type localCache_client_stub struct {
-	stub       codegen.Stub
-	getMetrics *codegen.MethodMetrics
-	putMetrics *codegen.MethodMetrics
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
+	getMetrics  *codegen.MethodMetrics
+	putMetrics  *codegen.MethodMetrics
 }
 
 func (s localCache_client_stub) Get(ctx context.Context, a0 string) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 string
+		err := intercept(ctx, "Get", func(ctx context.Context) (err error) {
+			r0, err = s.Get(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getMetrics.Count.Add(1)
@@ -321,8 +483,8 @@ func (s localCache_client_stub) Get(ctx context.Context, a0 string) (r0 string,
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -339,13 +501,28 @@ func (s localCache_client_stub) Get(ctx context.Context, a0 string) (r0 string,
 	s.getMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
 }
 
 func (s localCache_client_stub) Put(ctx context.Context, a0 string, a1 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "Put", func(ctx context.Context) (err error) {
+			err = s.Put(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.putMetrics.Count.Add(1)
@@ -379,8 +556,8 @@ func (s localCache_client_stub) Put(ctx context.Context, a0 string, a1 string) (
 	size := 0
 	size += (4 + len(a0))
 	size += (4 + len(a1))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -398,17 +575,19 @@ func (s localCache_client_stub) Put(ctx context.Context, a0 string, a1 string) (
 	s.putMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 type main_client_stub struct {
-	stub codegen.Stub
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
 }
 
 type sQLStore_client_stub struct {
 	stub                codegen.Stub
+	interceptor         codegen.Interceptor
 	createPostMetrics   *codegen.MethodMetrics
 	createThreadMetrics *codegen.MethodMetrics
 	getFeedMetrics      *codegen.MethodMetrics
@@ -416,6 +595,23 @@ type sQLStore_client_stub struct {
 }
 
 func (s sQLStore_client_stub) CreatePost(ctx context.Context, a0 string, a1 time.Time, a2 ThreadID, a3 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		a3 := a3
+		err := intercept(ctx, "CreatePost", func(ctx context.Context) (err error) {
+			err = s.CreatePost(ctx, a0, a1, a2, a3)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.createPostMetrics.Count.Add(1)
@@ -446,7 +642,8 @@ func (s sQLStore_client_stub) CreatePost(ctx context.Context, a0 string, a1 time
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	enc.String(a0)
 	enc.EncodeBinaryMarshaler(&a1)
 	enc.Int64((int64)(a2))
@@ -464,12 +661,31 @@ func (s sQLStore_client_stub) CreatePost(ctx context.Context, a0 string, a1 time
 	s.createPostMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 func (s sQLStore_client_stub) CreateThread(ctx context.Context, a0 string, a1 time.Time, a2 []string, a3 string, a4 []byte) (r0 ThreadID, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		a3 := a3
+		a4 := a4
+		var r0 ThreadID
+		err := intercept(ctx, "CreateThread", func(ctx context.Context) (err error) {
+			r0, err = s.CreateThread(ctx, a0, a1, a2, a3, a4)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.createThreadMetrics.Count.Add(1)
@@ -500,7 +716,8 @@ func (s sQLStore_client_stub) CreateThread(ctx context.Context, a0 string, a1 ti
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	enc.String(a0)
 	enc.EncodeBinaryMarshaler(&a1)
 	serviceweaver_enc_slice_string_4af10117(enc, a2)
@@ -519,13 +736,28 @@ func (s sQLStore_client_stub) CreateThread(ctx context.Context, a0 string, a1 ti
 	s.createThreadMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	*(*int64)(&r0) = dec.Int64()
 	err = dec.Error()
 	return
 }
 
 func (s sQLStore_client_stub) GetFeed(ctx context.Context, a0 string) (r0 []Thread, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []Thread
+		err := intercept(ctx, "GetFeed", func(ctx context.Context) (err error) {
+			r0, err = s.GetFeed(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getFeedMetrics.Count.Add(1)
@@ -558,8 +790,8 @@ func (s sQLStore_client_stub) GetFeed(ctx context.Context, a0 string) (r0 []Thre
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -576,13 +808,29 @@ func (s sQLStore_client_stub) GetFeed(ctx context.Context, a0 string) (r0 []Thre
 	s.getFeedMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_Thread_511e1469(dec)
 	err = dec.Error()
 	return
 }
 
 func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageID) (r0 []byte, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 []byte
+		err := intercept(ctx, "GetImage", func(ctx context.Context) (err error) {
+			r0, err = s.GetImage(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getImageMetrics.Count.Add(1)
@@ -616,8 +864,8 @@ func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageI
 	size := 0
 	size += (4 + len(a0))
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -635,7 +883,7 @@ func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageI
 	s.getImageMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_byte_87461245(dec)
 	err = dec.Error()
 	return
@@ -644,8 +892,9 @@ func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageI
 // Server stub implementations.
 
 type imageScaler_server_stub struct {
-	impl    ImageScaler
-	addLoad func(key uint64, load float64)
+	impl        ImageScaler
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -667,7 +916,7 @@ func (s imageScaler_server_stub) scale(ctx context.Context, args []byte) (res []
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 []byte
 	a0 = serviceweaver_dec_slice_byte_87461245(dec)
 	var a1 int
@@ -678,7 +927,23 @@ func (s imageScaler_server_stub) scale(ctx context.Context, args []byte) (res []
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Scale(ctx, a0, a1, a2)
+	var r0 []byte
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Scale(ctx, a0, a1, a2)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		var ir0 []byte
+		appErr = s.interceptor(ctx, "Scale", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Scale(ctx, a0, a1, a2)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -688,8 +953,9 @@ func (s imageScaler_server_stub) scale(ctx context.Context, args []byte) (res []
 }
 
 type localCache_server_stub struct {
-	impl    LocalCache
-	addLoad func(key uint64, load float64)
+	impl        LocalCache
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -713,14 +979,28 @@ func (s localCache_server_stub) get(ctx context.Context, args []byte) (res []byt
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Get(ctx, a0)
+	var r0 string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Get(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 string
+		appErr = s.interceptor(ctx, "Get", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Get(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -738,7 +1018,7 @@ func (s localCache_server_stub) put(ctx context.Context, args []byte) (res []byt
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 string
@@ -747,7 +1027,19 @@ func (s localCache_server_stub) put(ctx context.Context, args []byte) (res []byt
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.Put(ctx, a0, a1)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.Put(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		appErr = s.interceptor(ctx, "Put", func(ctx context.Context) (err error) {
+			err = s.impl.Put(ctx, a0, a1)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -756,8 +1048,9 @@ func (s localCache_server_stub) put(ctx context.Context, args []byte) (res []byt
 }
 
 type main_server_stub struct {
-	impl    weaver.Main
-	addLoad func(key uint64, load float64)
+	impl        weaver.Main
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -769,8 +1062,9 @@ func (s main_server_stub) GetStubFn(method string) func(ctx context.Context, arg
 }
 
 type sQLStore_server_stub struct {
-	impl    SQLStore
-	addLoad func(key uint64, load float64)
+	impl        SQLStore
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -798,7 +1092,7 @@ func (s sQLStore_server_stub) createPost(ctx context.Context, args []byte) (res
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 time.Time
@@ -811,7 +1105,21 @@ func (s sQLStore_server_stub) createPost(ctx context.Context, args []byte) (res
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.CreatePost(ctx, a0, a1, a2, a3)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.CreatePost(ctx, a0, a1, a2, a3)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		a3 := a3
+		appErr = s.interceptor(ctx, "CreatePost", func(ctx context.Context) (err error) {
+			err = s.impl.CreatePost(ctx, a0, a1, a2, a3)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -828,7 +1136,7 @@ func (s sQLStore_server_stub) createThread(ctx context.Context, args []byte) (re
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 time.Time
@@ -843,7 +1151,25 @@ func (s sQLStore_server_stub) createThread(ctx context.Context, args []byte) (re
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.CreateThread(ctx, a0, a1, a2, a3, a4)
+	var r0 ThreadID
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.CreateThread(ctx, a0, a1, a2, a3, a4)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		a2 := a2
+		a3 := a3
+		a4 := a4
+		var ir0 ThreadID
+		appErr = s.interceptor(ctx, "CreateThread", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.CreateThread(ctx, a0, a1, a2, a3, a4)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -861,14 +1187,28 @@ func (s sQLStore_server_stub) getFeed(ctx context.Context, args []byte) (res []b
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetFeed(ctx, a0)
+	var r0 []Thread
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.GetFeed(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 []Thread
+		appErr = s.interceptor(ctx, "GetFeed", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.GetFeed(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -886,7 +1226,7 @@ func (s sQLStore_server_stub) getImage(ctx context.Context, args []byte) (res []
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 ImageID
@@ -895,7 +1235,22 @@ func (s sQLStore_server_stub) getImage(ctx context.Context, args []byte) (res []
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetImage(ctx, a0, a1)
+	var r0 []byte
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.GetImage(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 []byte
+		appErr = s.interceptor(ctx, "GetImage", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.GetImage(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -971,6 +1326,11 @@ func serviceweaver_dec_slice_Post_29a9ee83(dec *codegen.Decoder) []Post {
 	return res
 }
 
+func init() {
+	codegen.RegisterType[Post]()
+	codegen.RegisterType[Thread]()
+}
+
 // Encoding/decoding implementations.
 
 func serviceweaver_enc_slice_byte_87461245(enc *codegen.Encoder, arg []byte) {
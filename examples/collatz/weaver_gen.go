@@ -16,51 +16,80 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/collatz/Even",
-		Iface:       reflect.TypeOf((*Even)(nil)).Elem(),
-		Impl:        reflect.TypeOf(even{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return even_local_stub{impl: impl.(Even), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return even_client_stub{stub: stub, doMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/collatz/Even", Method: "Do"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/collatz/Even",
+		Iface:   reflect.TypeOf((*Even)(nil)).Elem(),
+		Impl:    reflect.TypeOf(even{}),
+		RefData: "⟦221bb6fb:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/collatz/Even:Do⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return even_local_stub{impl: impl.(Even), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return even_server_stub{impl: impl.(Even), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return even_client_stub{stub: stub, interceptor: interceptor, doMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/collatz/Even", Method: "Do"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return even_server_stub{impl: impl.(Even), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/Main",
-		Iface: reflect.TypeOf((*weaver.Main)(nil)).Elem(),
-		Impl:  reflect.TypeOf(server{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return main_local_stub{impl: impl.(weaver.Main), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/Main",
+		Iface:   reflect.TypeOf((*weaver.Main)(nil)).Elem(),
+		Impl:    reflect.TypeOf(server{}),
+		RefData: "⟦b99f3fa0:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/Main:⟧\n⟦f95ad2dd:wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→github.com/ServiceWeaver/weaver/examples/collatz/Odd⟧\n⟦987c175b:wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→github.com/ServiceWeaver/weaver/examples/collatz/Even⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return main_local_stub{impl: impl.(weaver.Main), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return main_client_stub{stub: stub, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any { return main_client_stub{stub: stub} },
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return main_server_stub{impl: impl.(weaver.Main), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return main_server_stub{impl: impl.(weaver.Main), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/collatz/Odd",
-		Iface:       reflect.TypeOf((*Odd)(nil)).Elem(),
-		Impl:        reflect.TypeOf(odd{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return odd_local_stub{impl: impl.(Odd), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return odd_client_stub{stub: stub, doMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/collatz/Odd", Method: "Do"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/collatz/Odd",
+		Iface:   reflect.TypeOf((*Odd)(nil)).Elem(),
+		Impl:    reflect.TypeOf(odd{}),
+		RefData: "⟦43d438c2:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/collatz/Odd:Do⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return odd_local_stub{impl: impl.(Odd), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return odd_client_stub{stub: stub, interceptor: interceptor, doMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/collatz/Odd", Method: "Do"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return odd_server_stub{impl: impl.(Odd), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return odd_server_stub{impl: impl.(Odd), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦71b76d85:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/Main\",\"methods\":[]},{\"name\":\"github.com/ServiceWeaver/weaver/examples/collatz/Even\",\"methods\":[{\"name\":\"Do\",\"args\":[{\"kind\":\"int\"}],\"results\":[{\"kind\":\"int\"}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/examples/collatz/Odd\",\"methods\":[{\"name\":\"Do\",\"args\":[{\"kind\":\"int\"}],\"results\":[{\"kind\":\"int\"}]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type even_local_stub struct {
-	impl   Even
-	tracer trace.Tracer
+	impl        Even
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s even_local_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 int
+		err := intercept(ctx, "Do", func(ctx context.Context) (err error) {
+			r0, err = s.Do(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -78,16 +107,33 @@ func (s even_local_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 }
 
 type main_local_stub struct {
-	impl   weaver.Main
-	tracer trace.Tracer
+	impl        weaver.Main
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 type odd_local_stub struct {
-	impl   Odd
-	tracer trace.Tracer
+	impl        Odd
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s odd_local_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 int
+		err := intercept(ctx, "Do", func(ctx context.Context) (err error) {
+			r0, err = s.Do(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -107,11 +153,27 @@ func (s odd_local_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 // Client stub implementations.
 
 type even_client_stub struct {
-	stub      codegen.Stub
-	doMetrics *codegen.MethodMetrics
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
+	doMetrics   *codegen.MethodMetrics
 }
 
 func (s even_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 int
+		err := intercept(ctx, "Do", func(ctx context.Context) (err error) {
+			r0, err = s.Do(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.doMetrics.Count.Add(1)
@@ -144,8 +206,8 @@ func (s even_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.Int(a0)
@@ -162,22 +224,39 @@ func (s even_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	s.doMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
 }
 
 type main_client_stub struct {
-	stub codegen.Stub
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
 }
 
 type odd_client_stub struct {
-	stub      codegen.Stub
-	doMetrics *codegen.MethodMetrics
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
+	doMetrics   *codegen.MethodMetrics
 }
 
 func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 int
+		err := intercept(ctx, "Do", func(ctx context.Context) (err error) {
+			r0, err = s.Do(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.doMetrics.Count.Add(1)
@@ -210,8 +289,8 @@ func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.Int(a0)
@@ -228,7 +307,7 @@ func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	s.doMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -237,8 +316,9 @@ func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 // Server stub implementations.
 
 type even_server_stub struct {
-	impl    Even
-	addLoad func(key uint64, load float64)
+	impl        Even
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -260,14 +340,28 @@ func (s even_server_stub) do(ctx context.Context, args []byte) (res []byte, err
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 int
 	a0 = dec.Int()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Do(ctx, a0)
+	var r0 int
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Do(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 int
+		appErr = s.interceptor(ctx, "Do", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Do(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -277,8 +371,9 @@ func (s even_server_stub) do(ctx context.Context, args []byte) (res []byte, err
 }
 
 type main_server_stub struct {
-	impl    weaver.Main
-	addLoad func(key uint64, load float64)
+	impl        weaver.Main
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -290,8 +385,9 @@ func (s main_server_stub) GetStubFn(method string) func(ctx context.Context, arg
 }
 
 type odd_server_stub struct {
-	impl    Odd
-	addLoad func(key uint64, load float64)
+	impl        Odd
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -313,14 +409,28 @@ func (s odd_server_stub) do(ctx context.Context, args []byte) (res []byte, err e
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 int
 	a0 = dec.Int()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Do(ctx, a0)
+	var r0 int
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Do(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 int
+		appErr = s.interceptor(ctx, "Do", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Do(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
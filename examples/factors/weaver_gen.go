@@ -16,42 +16,66 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:   "github.com/ServiceWeaver/weaver/examples/factors/Factorer",
-		Iface:  reflect.TypeOf((*Factorer)(nil)).Elem(),
-		Impl:   reflect.TypeOf(factorer{}),
-		Routed: true,
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return factorer_local_stub{impl: impl.(Factorer), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/examples/factors/Factorer",
+		Iface:   reflect.TypeOf((*Factorer)(nil)).Elem(),
+		Impl:    reflect.TypeOf(factorer{}),
+		Routed:  true,
+		RefData: "⟦e69a8ee9:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/factors/Factorer:Factors⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return factorer_local_stub{impl: impl.(Factorer), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return factorer_client_stub{stub: stub, factorsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/factors/Factorer", Method: "Factors"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return factorer_client_stub{stub: stub, interceptor: interceptor, factorsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/factors/Factorer", Method: "Factors"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return factorer_server_stub{impl: impl.(Factorer), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return factorer_server_stub{impl: impl.(Factorer), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/Main",
-		Iface: reflect.TypeOf((*weaver.Main)(nil)).Elem(),
-		Impl:  reflect.TypeOf(server{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return main_local_stub{impl: impl.(weaver.Main), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/Main",
+		Iface:   reflect.TypeOf((*weaver.Main)(nil)).Elem(),
+		Impl:    reflect.TypeOf(server{}),
+		RefData: "⟦b99f3fa0:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/Main:⟧\n⟦4724da9b:wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→github.com/ServiceWeaver/weaver/examples/factors/Factorer⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return main_local_stub{impl: impl.(weaver.Main), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any { return main_client_stub{stub: stub} },
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return main_server_stub{impl: impl.(weaver.Main), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return main_client_stub{stub: stub, interceptor: interceptor}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return main_server_stub{impl: impl.(weaver.Main), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦7e11bbe1:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/Main\",\"methods\":[]},{\"name\":\"github.com/ServiceWeaver/weaver/examples/factors/Factorer\",\"methods\":[{\"name\":\"Factors\",\"args\":[{\"kind\":\"int\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"int\"}}]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type factorer_local_stub struct {
-	impl   Factorer
-	tracer trace.Tracer
+	impl        Factorer
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s factorer_local_stub) Factors(ctx context.Context, a0 int) (r0 []int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []int
+		err := intercept(ctx, "Factors", func(ctx context.Context) (err error) {
+			r0, err = s.Factors(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -69,18 +93,35 @@ func (s factorer_local_stub) Factors(ctx context.Context, a0 int) (r0 []int, err
 }
 
 type main_local_stub struct {
-	impl   weaver.Main
-	tracer trace.Tracer
+	impl        weaver.Main
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 // Client stub implementations.
 
 type factorer_client_stub struct {
 	stub           codegen.Stub
+	interceptor    codegen.Interceptor
 	factorsMetrics *codegen.MethodMetrics
 }
 
 func (s factorer_client_stub) Factors(ctx context.Context, a0 int) (r0 []int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []int
+		err := intercept(ctx, "Factors", func(ctx context.Context) (err error) {
+			r0, err = s.Factors(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.factorsMetrics.Count.Add(1)
@@ -113,8 +154,8 @@ func (s factorer_client_stub) Factors(ctx context.Context, a0 int) (r0 []int, er
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.Int(a0)
@@ -134,21 +175,23 @@ func (s factorer_client_stub) Factors(ctx context.Context, a0 int) (r0 []int, er
 	s.factorsMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_int_7c8c8866(dec)
 	err = dec.Error()
 	return
 }
 
 type main_client_stub struct {
-	stub codegen.Stub
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
 }
 
 // Server stub implementations.
 
 type factorer_server_stub struct {
-	impl    Factorer
-	addLoad func(key uint64, load float64)
+	impl        Factorer
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -170,7 +213,7 @@ func (s factorer_server_stub) factors(ctx context.Context, args []byte) (res []b
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 int
 	a0 = dec.Int()
 	var r router
@@ -179,7 +222,21 @@ func (s factorer_server_stub) factors(ctx context.Context, args []byte) (res []b
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Factors(ctx, a0)
+	var r0 []int
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Factors(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 []int
+		appErr = s.interceptor(ctx, "Factors", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Factors(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -189,8 +246,9 @@ func (s factorer_server_stub) factors(ctx context.Context, args []byte) (res []b
 }
 
 type main_server_stub struct {
-	impl    weaver.Main
-	addLoad func(key uint64, load float64)
+	impl        weaver.Main
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -16,46 +16,71 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/Main",
-		Iface: reflect.TypeOf((*weaver.Main)(nil)).Elem(),
-		Impl:  reflect.TypeOf(app{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return main_local_stub{impl: impl.(weaver.Main), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/Main",
+		Iface:   reflect.TypeOf((*weaver.Main)(nil)).Elem(),
+		Impl:    reflect.TypeOf(app{}),
+		RefData: "⟦b99f3fa0:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/Main:⟧\n⟦8d621687:wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→github.com/ServiceWeaver/weaver/examples/hello/Reverser⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return main_local_stub{impl: impl.(weaver.Main), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any { return main_client_stub{stub: stub} },
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return main_server_stub{impl: impl.(weaver.Main), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return main_client_stub{stub: stub, interceptor: interceptor}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return main_server_stub{impl: impl.(weaver.Main), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/examples/hello/Reverser",
-		Iface: reflect.TypeOf((*Reverser)(nil)).Elem(),
-		Impl:  reflect.TypeOf(reverser{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return reverser_local_stub{impl: impl.(Reverser), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/examples/hello/Reverser",
+		Iface:   reflect.TypeOf((*Reverser)(nil)).Elem(),
+		Impl:    reflect.TypeOf(reverser{}),
+		RefData: "⟦382918ec:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/hello/Reverser:Reverse⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return reverser_local_stub{impl: impl.(Reverser), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return reverser_client_stub{stub: stub, reverseMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/hello/Reverser", Method: "Reverse"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return reverser_client_stub{stub: stub, interceptor: interceptor, reverseMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/hello/Reverser", Method: "Reverse"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return reverser_server_stub{impl: impl.(Reverser), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return reverser_server_stub{impl: impl.(Reverser), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦f9a0b305:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/Main\",\"methods\":[]},{\"name\":\"github.com/ServiceWeaver/weaver/examples/hello/Reverser\",\"methods\":[{\"name\":\"Reverse\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"string\"}]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type main_local_stub struct {
-	impl   weaver.Main
-	tracer trace.Tracer
+	impl        weaver.Main
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 type reverser_local_stub struct {
-	impl   Reverser
-	tracer trace.Tracer
+	impl        Reverser
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s reverser_local_stub) Reverse(ctx context.Context, a0 string) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 string
+		err := intercept(ctx, "Reverse", func(ctx context.Context) (err error) {
+			r0, err = s.Reverse(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -75,15 +100,32 @@ func (s reverser_local_stub) Reverse(ctx context.Context, a0 string) (r0 string,
 // Client stub implementations.
 
 type main_client_stub struct {
-	stub codegen.Stub
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
 }
 
 type reverser_client_stub struct {
 	stub           codegen.Stub
+	interceptor    codegen.Interceptor
 	reverseMetrics *codegen.MethodMetrics
 }
 
 func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 string
+		err := intercept(ctx, "Reverse", func(ctx context.Context) (err error) {
+			r0, err = s.Reverse(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.reverseMetrics.Count.Add(1)
@@ -116,8 +158,8 @@ func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -134,7 +176,7 @@ func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string
 	s.reverseMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -143,8 +185,9 @@ func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string
 // Server stub implementations.
 
 type main_server_stub struct {
-	impl    weaver.Main
-	addLoad func(key uint64, load float64)
+	impl        weaver.Main
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -156,8 +199,9 @@ func (s main_server_stub) GetStubFn(method string) func(ctx context.Context, arg
 }
 
 type reverser_server_stub struct {
-	impl    Reverser
-	addLoad func(key uint64, load float64)
+	impl        Reverser
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -179,14 +223,28 @@ func (s reverser_server_stub) reverse(ctx context.Context, args []byte) (res []b
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Reverse(ctx, a0)
+	var r0 string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Reverse(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 string
+		appErr = s.interceptor(ctx, "Reverse", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Reverse(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
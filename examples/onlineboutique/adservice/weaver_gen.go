@@ -17,27 +17,50 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/adservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, getAdsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/adservice/T", Method: "GetAds"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/adservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦5032942a:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/adservice/T:GetAds⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, getAdsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/adservice/T", Method: "GetAds"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦7e4f0c3f:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/adservice/T\",\"methods\":[{\"name\":\"GetAds\",\"args\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/adservice.Ad\",\"fields\":[{\"name\":\"RedirectURL\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Text\",\"type\":{\"kind\":\"string\"}}]}}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/adservice.Ad\",\"fields\":[{\"name\":\"RedirectURL\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Text\",\"type\":{\"kind\":\"string\"}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) GetAds(ctx context.Context, a0 []string) (r0 []Ad, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []Ad
+		err := intercept(ctx, "GetAds", func(ctx context.Context) (err error) {
+			r0, err = s.GetAds(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -58,10 +81,26 @@ func (s t_local_stub) GetAds(ctx context.Context, a0 []string) (r0 []Ad, err err
 
 type t_client_stub struct {
 	stub          codegen.Stub
+	interceptor   codegen.Interceptor
 	getAdsMetrics *codegen.MethodMetrics
 }
 
 func (s t_client_stub) GetAds(ctx context.Context, a0 []string) (r0 []Ad, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []Ad
+		err := intercept(ctx, "GetAds", func(ctx context.Context) (err error) {
+			r0, err = s.GetAds(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getAdsMetrics.Count.Add(1)
@@ -92,7 +131,8 @@ func (s t_client_stub) GetAds(ctx context.Context, a0 []string) (r0 []Ad, err er
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	serviceweaver_enc_slice_string_4af10117(enc, a0)
 	var shardKey uint64
 
@@ -107,7 +147,7 @@ func (s t_client_stub) GetAds(ctx context.Context, a0 []string) (r0 []Ad, err er
 	s.getAdsMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_Ad_86ae3655(dec)
 	err = dec.Error()
 	return
@@ -116,8 +156,9 @@ func (s t_client_stub) GetAds(ctx context.Context, a0 []string) (r0 []Ad, err er
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -139,14 +180,28 @@ func (s t_server_stub) getAds(ctx context.Context, args []byte) (res []byte, err
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 []string
 	a0 = serviceweaver_dec_slice_string_4af10117(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetAds(ctx, a0)
+	var r0 []Ad
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.GetAds(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 []Ad
+		appErr = s.interceptor(ctx, "GetAds", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.GetAds(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -175,6 +230,10 @@ func (x *Ad) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.Text = dec.String()
 }
 
+func init() {
+	codegen.RegisterType[Ad]()
+}
+
 // Encoding/decoding implementations.
 
 func serviceweaver_enc_slice_string_4af10117(enc *codegen.Encoder, arg []string) {
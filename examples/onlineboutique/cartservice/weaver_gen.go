@@ -17,42 +17,66 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, addItemMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T", Method: "AddItem"}), emptyCartMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T", Method: "EmptyCart"}), getCartMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T", Method: "GetCart"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦c79caf8e:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T:AddItem,EmptyCart,GetCart⟧\n⟦e78910e9:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T→github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, addItemMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T", Method: "AddItem"}), emptyCartMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T", Method: "EmptyCart"}), getCartMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T", Method: "GetCart"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:   "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache",
-		Iface:  reflect.TypeOf((*cartCache)(nil)).Elem(),
-		Impl:   reflect.TypeOf(cartCacheImpl{}),
-		Routed: true,
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return cartCache_local_stub{impl: impl.(cartCache), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache",
+		Iface:   reflect.TypeOf((*cartCache)(nil)).Elem(),
+		Impl:    reflect.TypeOf(cartCacheImpl{}),
+		Routed:  true,
+		RefData: "⟦4488e0a8:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache:Add,Get,Remove⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return cartCache_local_stub{impl: impl.(cartCache), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return cartCache_client_stub{stub: stub, addMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache", Method: "Add"}), getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache", Method: "Get"}), removeMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache", Method: "Remove"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return cartCache_client_stub{stub: stub, interceptor: interceptor, addMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache", Method: "Add"}), getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache", Method: "Get"}), removeMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache", Method: "Remove"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return cartCache_server_stub{impl: impl.(cartCache), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return cartCache_server_stub{impl: impl.(cartCache), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦13e45db2:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T\",\"methods\":[{\"name\":\"AddItem\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\",\"fields\":[{\"name\":\"ProductID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Quantity\",\"type\":{\"kind\":\"int32\"}}]}],\"results\":[]},{\"name\":\"EmptyCart\",\"args\":[{\"kind\":\"string\"}],\"results\":[]},{\"name\":\"GetCart\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\",\"fields\":[{\"name\":\"ProductID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Quantity\",\"type\":{\"kind\":\"int32\"}}]}}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/cartCache\",\"methods\":[{\"name\":\"Add\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\",\"fields\":[{\"name\":\"ProductID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Quantity\",\"type\":{\"kind\":\"int32\"}}]}}],\"results\":[]},{\"name\":\"Get\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\",\"fields\":[{\"name\":\"ProductID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Quantity\",\"type\":{\"kind\":\"int32\"}}]}}]},{\"name\":\"Remove\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"bool\"}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\",\"fields\":[{\"name\":\"ProductID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Quantity\",\"type\":{\"kind\":\"int32\"}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) AddItem(ctx context.Context, a0 string, a1 CartItem) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "AddItem", func(ctx context.Context) (err error) {
+			err = s.AddItem(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -70,6 +94,20 @@ func (s t_local_stub) AddItem(ctx context.Context, a0 string, a1 CartItem) (err
 }
 
 func (s t_local_stub) EmptyCart(ctx context.Context, a0 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		err := intercept(ctx, "EmptyCart", func(ctx context.Context) (err error) {
+			err = s.EmptyCart(ctx, a0)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -87,6 +125,21 @@ func (s t_local_stub) EmptyCart(ctx context.Context, a0 string) (err error) {
 }
 
 func (s t_local_stub) GetCart(ctx context.Context, a0 string) (r0 []CartItem, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []CartItem
+		err := intercept(ctx, "GetCart", func(ctx context.Context) (err error) {
+			r0, err = s.GetCart(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -104,11 +157,27 @@ func (s t_local_stub) GetCart(ctx context.Context, a0 string) (r0 []CartItem, er
 }
 
 type cartCache_local_stub struct {
-	impl   cartCache
-	tracer trace.Tracer
+	impl        cartCache
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s cartCache_local_stub) Add(ctx context.Context, a0 string, a1 []CartItem) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "Add", func(ctx context.Context) (err error) {
+			err = s.Add(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -126,6 +195,21 @@ func (s cartCache_local_stub) Add(ctx context.Context, a0 string, a1 []CartItem)
 }
 
 func (s cartCache_local_stub) Get(ctx context.Context, a0 string) (r0 []CartItem, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []CartItem
+		err := intercept(ctx, "Get", func(ctx context.Context) (err error) {
+			r0, err = s.Get(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -143,6 +227,21 @@ func (s cartCache_local_stub) Get(ctx context.Context, a0 string) (r0 []CartItem
 }
 
 func (s cartCache_local_stub) Remove(ctx context.Context, a0 string) (r0 bool, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 bool
+		err := intercept(ctx, "Remove", func(ctx context.Context) (err error) {
+			r0, err = s.Remove(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -163,12 +262,28 @@ func (s cartCache_local_stub) Remove(ctx context.Context, a0 string) (r0 bool, e
 
 type t_client_stub struct {
 	stub             codegen.Stub
+	interceptor      codegen.Interceptor
 	addItemMetrics   *codegen.MethodMetrics
 	emptyCartMetrics *codegen.MethodMetrics
 	getCartMetrics   *codegen.MethodMetrics
 }
 
 func (s t_client_stub) AddItem(ctx context.Context, a0 string, a1 CartItem) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "AddItem", func(ctx context.Context) (err error) {
+			err = s.AddItem(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.addItemMetrics.Count.Add(1)
@@ -202,8 +317,8 @@ func (s t_client_stub) AddItem(ctx context.Context, a0 string, a1 CartItem) (err
 	size := 0
 	size += (4 + len(a0))
 	size += serviceweaver_size_CartItem_e3591e56(&a1)
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -221,12 +336,26 @@ func (s t_client_stub) AddItem(ctx context.Context, a0 string, a1 CartItem) (err
 	s.addItemMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 func (s t_client_stub) EmptyCart(ctx context.Context, a0 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		err := intercept(ctx, "EmptyCart", func(ctx context.Context) (err error) {
+			err = s.EmptyCart(ctx, a0)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.emptyCartMetrics.Count.Add(1)
@@ -259,8 +388,8 @@ func (s t_client_stub) EmptyCart(ctx context.Context, a0 string) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -277,12 +406,27 @@ func (s t_client_stub) EmptyCart(ctx context.Context, a0 string) (err error) {
 	s.emptyCartMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 func (s t_client_stub) GetCart(ctx context.Context, a0 string) (r0 []CartItem, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []CartItem
+		err := intercept(ctx, "GetCart", func(ctx context.Context) (err error) {
+			r0, err = s.GetCart(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getCartMetrics.Count.Add(1)
@@ -315,8 +459,8 @@ func (s t_client_stub) GetCart(ctx context.Context, a0 string) (r0 []CartItem, e
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -333,7 +477,7 @@ func (s t_client_stub) GetCart(ctx context.Context, a0 string) (r0 []CartItem, e
 	s.getCartMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_CartItem_7a7ff11c(dec)
 	err = dec.Error()
 	return
@@ -341,12 +485,28 @@ func (s t_client_stub) GetCart(ctx context.Context, a0 string) (r0 []CartItem, e
 
 type cartCache_client_stub struct {
 	stub          codegen.Stub
+	interceptor   codegen.Interceptor
 	addMetrics    *codegen.MethodMetrics
 	getMetrics    *codegen.MethodMetrics
 	removeMetrics *codegen.MethodMetrics
 }
 
 func (s cartCache_client_stub) Add(ctx context.Context, a0 string, a1 []CartItem) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "Add", func(ctx context.Context) (err error) {
+			err = s.Add(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.addMetrics.Count.Add(1)
@@ -377,7 +537,8 @@ func (s cartCache_client_stub) Add(ctx context.Context, a0 string, a1 []CartItem
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	enc.String(a0)
 	serviceweaver_enc_slice_CartItem_7a7ff11c(enc, a1)
 
@@ -396,12 +557,27 @@ func (s cartCache_client_stub) Add(ctx context.Context, a0 string, a1 []CartItem
 	s.addMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 func (s cartCache_client_stub) Get(ctx context.Context, a0 string) (r0 []CartItem, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []CartItem
+		err := intercept(ctx, "Get", func(ctx context.Context) (err error) {
+			r0, err = s.Get(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getMetrics.Count.Add(1)
@@ -434,8 +610,8 @@ func (s cartCache_client_stub) Get(ctx context.Context, a0 string) (r0 []CartIte
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -455,13 +631,28 @@ func (s cartCache_client_stub) Get(ctx context.Context, a0 string) (r0 []CartIte
 	s.getMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_CartItem_7a7ff11c(dec)
 	err = dec.Error()
 	return
 }
 
 func (s cartCache_client_stub) Remove(ctx context.Context, a0 string) (r0 bool, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 bool
+		err := intercept(ctx, "Remove", func(ctx context.Context) (err error) {
+			r0, err = s.Remove(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.removeMetrics.Count.Add(1)
@@ -494,8 +685,8 @@ func (s cartCache_client_stub) Remove(ctx context.Context, a0 string) (r0 bool,
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -515,7 +706,7 @@ func (s cartCache_client_stub) Remove(ctx context.Context, a0 string) (r0 bool,
 	s.removeMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.Bool()
 	err = dec.Error()
 	return
@@ -524,8 +715,9 @@ func (s cartCache_client_stub) Remove(ctx context.Context, a0 string) (r0 bool,
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -551,7 +743,7 @@ func (s t_server_stub) addItem(ctx context.Context, args []byte) (res []byte, er
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 CartItem
@@ -560,7 +752,19 @@ func (s t_server_stub) addItem(ctx context.Context, args []byte) (res []byte, er
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.AddItem(ctx, a0, a1)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.AddItem(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		appErr = s.interceptor(ctx, "AddItem", func(ctx context.Context) (err error) {
+			err = s.impl.AddItem(ctx, a0, a1)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -577,14 +781,25 @@ func (s t_server_stub) emptyCart(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.EmptyCart(ctx, a0)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.EmptyCart(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		appErr = s.interceptor(ctx, "EmptyCart", func(ctx context.Context) (err error) {
+			err = s.impl.EmptyCart(ctx, a0)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -601,14 +816,28 @@ func (s t_server_stub) getCart(ctx context.Context, args []byte) (res []byte, er
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetCart(ctx, a0)
+	var r0 []CartItem
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.GetCart(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 []CartItem
+		appErr = s.interceptor(ctx, "GetCart", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.GetCart(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -618,8 +847,9 @@ func (s t_server_stub) getCart(ctx context.Context, args []byte) (res []byte, er
 }
 
 type cartCache_server_stub struct {
-	impl    cartCache
-	addLoad func(key uint64, load float64)
+	impl        cartCache
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -645,7 +875,7 @@ func (s cartCache_server_stub) add(ctx context.Context, args []byte) (res []byte
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 []CartItem
@@ -656,7 +886,19 @@ func (s cartCache_server_stub) add(ctx context.Context, args []byte) (res []byte
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.Add(ctx, a0, a1)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.Add(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		appErr = s.interceptor(ctx, "Add", func(ctx context.Context) (err error) {
+			err = s.impl.Add(ctx, a0, a1)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -673,7 +915,7 @@ func (s cartCache_server_stub) get(ctx context.Context, args []byte) (res []byte
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var r cartCacheRouter
@@ -682,7 +924,21 @@ func (s cartCache_server_stub) get(ctx context.Context, args []byte) (res []byte
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Get(ctx, a0)
+	var r0 []CartItem
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Get(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 []CartItem
+		appErr = s.interceptor(ctx, "Get", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Get(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -700,7 +956,7 @@ func (s cartCache_server_stub) remove(ctx context.Context, args []byte) (res []b
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var r cartCacheRouter
@@ -709,7 +965,21 @@ func (s cartCache_server_stub) remove(ctx context.Context, args []byte) (res []b
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Remove(ctx, a0)
+	var r0 bool
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Remove(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 bool
+		appErr = s.interceptor(ctx, "Remove", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Remove(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -738,6 +1008,10 @@ func (x *CartItem) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.Quantity = dec.Int32()
 }
 
+func init() {
+	codegen.RegisterType[CartItem]()
+}
+
 // Router methods.
 
 // _hashCartCache returns a 64 bit hash of the provided value.
@@ -18,27 +18,50 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, placeOrderMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T", Method: "PlaceOrder"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦d842c2fa:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T:PlaceOrder⟧\n⟦4c9a54a7:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T→github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T⟧\n⟦74479326:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T→github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice/T⟧\n⟦7395fba7:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T→github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T⟧\n⟦ae088216:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T→github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T⟧\n⟦43860cf2:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T→github.com/ServiceWeaver/weaver/examples/onlineboutique/emailservice/T⟧\n⟦54f6b59f:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T→github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice/T⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, placeOrderMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T", Method: "PlaceOrder"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦f7ce0b45:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice/T\",\"methods\":[{\"name\":\"PlaceOrder\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice.PlaceOrderRequest\",\"fields\":[{\"name\":\"UserID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"UserCurrency\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Address\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice.Address\"}},{\"name\":\"Email\",\"type\":{\"kind\":\"string\"}},{\"name\":\"CreditCard\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice.CreditCardInfo\"}}]}],\"results\":[{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types.Order\"}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/checkoutservice.PlaceOrderRequest\",\"fields\":[{\"name\":\"UserID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"UserCurrency\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Address\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice.Address\"}},{\"name\":\"Email\",\"type\":{\"kind\":\"string\"}},{\"name\":\"CreditCard\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice.CreditCardInfo\"}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) PlaceOrder(ctx context.Context, a0 PlaceOrderRequest) (r0 types.Order, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 types.Order
+		err := intercept(ctx, "PlaceOrder", func(ctx context.Context) (err error) {
+			r0, err = s.PlaceOrder(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -59,10 +82,26 @@ func (s t_local_stub) PlaceOrder(ctx context.Context, a0 PlaceOrderRequest) (r0
 
 type t_client_stub struct {
 	stub              codegen.Stub
+	interceptor       codegen.Interceptor
 	placeOrderMetrics *codegen.MethodMetrics
 }
 
 func (s t_client_stub) PlaceOrder(ctx context.Context, a0 PlaceOrderRequest) (r0 types.Order, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 types.Order
+		err := intercept(ctx, "PlaceOrder", func(ctx context.Context) (err error) {
+			r0, err = s.PlaceOrder(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.placeOrderMetrics.Count.Add(1)
@@ -93,7 +132,8 @@ func (s t_client_stub) PlaceOrder(ctx context.Context, a0 PlaceOrderRequest) (r0
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	var shardKey uint64
 
@@ -108,7 +148,7 @@ func (s t_client_stub) PlaceOrder(ctx context.Context, a0 PlaceOrderRequest) (r0
 	s.placeOrderMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -117,8 +157,9 @@ func (s t_client_stub) PlaceOrder(ctx context.Context, a0 PlaceOrderRequest) (r0
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -140,14 +181,28 @@ func (s t_server_stub) placeOrder(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 PlaceOrderRequest
 	(&a0).WeaverUnmarshal(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PlaceOrder(ctx, a0)
+	var r0 types.Order
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PlaceOrder(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 types.Order
+		appErr = s.interceptor(ctx, "PlaceOrder", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PlaceOrder(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -181,3 +236,7 @@ func (x *PlaceOrderRequest) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.Email = dec.String()
 	(&x.CreditCard).WeaverUnmarshal(dec)
 }
+
+func init() {
+	codegen.RegisterType[PlaceOrderRequest]()
+}
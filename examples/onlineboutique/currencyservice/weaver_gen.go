@@ -17,27 +17,51 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, convertMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T", Method: "Convert"}), getSupportedCurrenciesMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T", Method: "GetSupportedCurrencies"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦9ab4157b:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T:Convert,GetSupportedCurrencies⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, convertMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T", Method: "Convert"}), getSupportedCurrenciesMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T", Method: "GetSupportedCurrencies"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦27078fd7:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/currencyservice/T\",\"methods\":[{\"name\":\"Convert\",\"args\":[{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"},{\"kind\":\"string\"}],\"results\":[{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}]},{\"name\":\"GetSupportedCurrencies\",\"args\":[],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) Convert(ctx context.Context, a0 money.T, a1 string) (r0 money.T, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 money.T
+		err := intercept(ctx, "Convert", func(ctx context.Context) (err error) {
+			r0, err = s.Convert(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -55,6 +79,20 @@ func (s t_local_stub) Convert(ctx context.Context, a0 money.T, a1 string) (r0 mo
 }
 
 func (s t_local_stub) GetSupportedCurrencies(ctx context.Context) (r0 []string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 []string
+		err := intercept(ctx, "GetSupportedCurrencies", func(ctx context.Context) (err error) {
+			r0, err = s.GetSupportedCurrencies(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -75,11 +113,28 @@ func (s t_local_stub) GetSupportedCurrencies(ctx context.Context) (r0 []string,
 
 type t_client_stub struct {
 	stub                          codegen.Stub
+	interceptor                   codegen.Interceptor
 	convertMetrics                *codegen.MethodMetrics
 	getSupportedCurrenciesMetrics *codegen.MethodMetrics
 }
 
 func (s t_client_stub) Convert(ctx context.Context, a0 money.T, a1 string) (r0 money.T, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 money.T
+		err := intercept(ctx, "Convert", func(ctx context.Context) (err error) {
+			r0, err = s.Convert(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.convertMetrics.Count.Add(1)
@@ -110,7 +165,8 @@ func (s t_client_stub) Convert(ctx context.Context, a0 money.T, a1 string) (r0 m
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.String(a1)
 	var shardKey uint64
@@ -126,13 +182,27 @@ func (s t_client_stub) Convert(ctx context.Context, a0 money.T, a1 string) (r0 m
 	s.convertMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s t_client_stub) GetSupportedCurrencies(ctx context.Context) (r0 []string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 []string
+		err := intercept(ctx, "GetSupportedCurrencies", func(ctx context.Context) (err error) {
+			r0, err = s.GetSupportedCurrencies(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getSupportedCurrenciesMetrics.Count.Add(1)
@@ -175,7 +245,7 @@ func (s t_client_stub) GetSupportedCurrencies(ctx context.Context) (r0 []string,
 	s.getSupportedCurrenciesMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_string_4af10117(dec)
 	err = dec.Error()
 	return
@@ -184,8 +254,9 @@ func (s t_client_stub) GetSupportedCurrencies(ctx context.Context) (r0 []string,
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -209,7 +280,7 @@ func (s t_server_stub) convert(ctx context.Context, args []byte) (res []byte, er
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 money.T
 	(&a0).WeaverUnmarshal(dec)
 	var a1 string
@@ -218,7 +289,22 @@ func (s t_server_stub) convert(ctx context.Context, args []byte) (res []byte, er
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Convert(ctx, a0, a1)
+	var r0 money.T
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Convert(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 money.T
+		appErr = s.interceptor(ctx, "Convert", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Convert(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -238,7 +324,20 @@ func (s t_server_stub) getSupportedCurrencies(ctx context.Context, args []byte)
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetSupportedCurrencies(ctx)
+	var r0 []string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.GetSupportedCurrencies(ctx)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		var ir0 []string
+		appErr = s.interceptor(ctx, "GetSupportedCurrencies", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.GetSupportedCurrencies(ctx)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -17,27 +17,50 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/emailservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, sendOrderConfirmationMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/emailservice/T", Method: "SendOrderConfirmation"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/emailservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦8fec7ac7:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/emailservice/T:SendOrderConfirmation⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, sendOrderConfirmationMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/emailservice/T", Method: "SendOrderConfirmation"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦7a282c58:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/emailservice/T\",\"methods\":[{\"name\":\"SendOrderConfirmation\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types.Order\"}],\"results\":[]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) SendOrderConfirmation(ctx context.Context, a0 string, a1 types.Order) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "SendOrderConfirmation", func(ctx context.Context) (err error) {
+			err = s.SendOrderConfirmation(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -58,10 +81,26 @@ func (s t_local_stub) SendOrderConfirmation(ctx context.Context, a0 string, a1 t
 
 type t_client_stub struct {
 	stub                         codegen.Stub
+	interceptor                  codegen.Interceptor
 	sendOrderConfirmationMetrics *codegen.MethodMetrics
 }
 
 func (s t_client_stub) SendOrderConfirmation(ctx context.Context, a0 string, a1 types.Order) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "SendOrderConfirmation", func(ctx context.Context) (err error) {
+			err = s.SendOrderConfirmation(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.sendOrderConfirmationMetrics.Count.Add(1)
@@ -92,7 +131,8 @@ func (s t_client_stub) SendOrderConfirmation(ctx context.Context, a0 string, a1
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	enc.String(a0)
 	(a1).WeaverMarshal(enc)
 	var shardKey uint64
@@ -108,7 +148,7 @@ func (s t_client_stub) SendOrderConfirmation(ctx context.Context, a0 string, a1
 	s.sendOrderConfirmationMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -116,8 +156,9 @@ func (s t_client_stub) SendOrderConfirmation(ctx context.Context, a0 string, a1
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -139,7 +180,7 @@ func (s t_server_stub) sendOrderConfirmation(ctx context.Context, args []byte) (
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 types.Order
@@ -148,7 +189,19 @@ func (s t_server_stub) sendOrderConfirmation(ctx context.Context, args []byte) (
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.SendOrderConfirmation(ctx, a0, a1)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.SendOrderConfirmation(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		appErr = s.interceptor(ctx, "SendOrderConfirmation", func(ctx context.Context) (err error) {
+			err = s.impl.SendOrderConfirmation(ctx, a0, a1)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
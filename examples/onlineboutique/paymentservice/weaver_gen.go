@@ -18,27 +18,51 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, chargeMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice/T", Method: "Charge"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦0342fa67:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice/T:Charge⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, chargeMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice/T", Method: "Charge"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦e043e93e:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice/T\",\"methods\":[{\"name\":\"Charge\",\"args\":[{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice.CreditCardInfo\",\"fields\":[{\"name\":\"Number\",\"type\":{\"kind\":\"string\"}},{\"name\":\"CVV\",\"type\":{\"kind\":\"int32\"}},{\"name\":\"ExpirationYear\",\"type\":{\"kind\":\"int\"}},{\"name\":\"ExpirationMonth\",\"type\":{\"kind\":\"int\",\"name\":\"time.Month\"}}]}],\"results\":[{\"kind\":\"string\"}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/paymentservice.CreditCardInfo\",\"fields\":[{\"name\":\"Number\",\"type\":{\"kind\":\"string\"}},{\"name\":\"CVV\",\"type\":{\"kind\":\"int32\"}},{\"name\":\"ExpirationYear\",\"type\":{\"kind\":\"int\"}},{\"name\":\"ExpirationMonth\",\"type\":{\"kind\":\"int\",\"name\":\"time.Month\"}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) Charge(ctx context.Context, a0 money.T, a1 CreditCardInfo) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 string
+		err := intercept(ctx, "Charge", func(ctx context.Context) (err error) {
+			r0, err = s.Charge(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -59,10 +83,27 @@ func (s t_local_stub) Charge(ctx context.Context, a0 money.T, a1 CreditCardInfo)
 
 type t_client_stub struct {
 	stub          codegen.Stub
+	interceptor   codegen.Interceptor
 	chargeMetrics *codegen.MethodMetrics
 }
 
 func (s t_client_stub) Charge(ctx context.Context, a0 money.T, a1 CreditCardInfo) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 string
+		err := intercept(ctx, "Charge", func(ctx context.Context) (err error) {
+			r0, err = s.Charge(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.chargeMetrics.Count.Add(1)
@@ -93,7 +134,8 @@ func (s t_client_stub) Charge(ctx context.Context, a0 money.T, a1 CreditCardInfo
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	(a1).WeaverMarshal(enc)
 	var shardKey uint64
@@ -109,7 +151,7 @@ func (s t_client_stub) Charge(ctx context.Context, a0 money.T, a1 CreditCardInfo
 	s.chargeMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -118,8 +160,9 @@ func (s t_client_stub) Charge(ctx context.Context, a0 money.T, a1 CreditCardInfo
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -141,7 +184,7 @@ func (s t_server_stub) charge(ctx context.Context, args []byte) (res []byte, err
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 money.T
 	(&a0).WeaverUnmarshal(dec)
 	var a1 CreditCardInfo
@@ -150,7 +193,22 @@ func (s t_server_stub) charge(ctx context.Context, args []byte) (res []byte, err
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Charge(ctx, a0, a1)
+	var r0 string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Charge(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 string
+		appErr = s.interceptor(ctx, "Charge", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Charge(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -182,3 +240,7 @@ func (x *CreditCardInfo) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.ExpirationYear = dec.Int()
 	*(*int)(&x.ExpirationMonth) = dec.Int()
 }
+
+func init() {
+	codegen.RegisterType[CreditCardInfo]()
+}
@@ -17,27 +17,50 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, getProductMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T", Method: "GetProduct"}), listProductsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T", Method: "ListProducts"}), searchProductsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T", Method: "SearchProducts"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦1ae80aa7:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T:GetProduct,ListProducts,SearchProducts⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, getProductMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T", Method: "GetProduct"}), listProductsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T", Method: "ListProducts"}), searchProductsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T", Method: "SearchProducts"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦7da76051:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T\",\"methods\":[{\"name\":\"GetProduct\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice.Product\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Name\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Description\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Picture\",\"type\":{\"kind\":\"string\"}},{\"name\":\"PriceUSD\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}},{\"name\":\"Categories\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]},{\"name\":\"ListProducts\",\"args\":[],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice.Product\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Name\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Description\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Picture\",\"type\":{\"kind\":\"string\"}},{\"name\":\"PriceUSD\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}},{\"name\":\"Categories\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}}]},{\"name\":\"SearchProducts\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice.Product\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Name\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Description\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Picture\",\"type\":{\"kind\":\"string\"}},{\"name\":\"PriceUSD\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}},{\"name\":\"Categories\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice.Product\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Name\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Description\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Picture\",\"type\":{\"kind\":\"string\"}},{\"name\":\"PriceUSD\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}},{\"name\":\"Categories\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) GetProduct(ctx context.Context, a0 string) (r0 Product, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 Product
+		err := intercept(ctx, "GetProduct", func(ctx context.Context) (err error) {
+			r0, err = s.GetProduct(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -55,6 +78,20 @@ func (s t_local_stub) GetProduct(ctx context.Context, a0 string) (r0 Product, er
 }
 
 func (s t_local_stub) ListProducts(ctx context.Context) (r0 []Product, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 []Product
+		err := intercept(ctx, "ListProducts", func(ctx context.Context) (err error) {
+			r0, err = s.ListProducts(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -72,6 +109,21 @@ func (s t_local_stub) ListProducts(ctx context.Context) (r0 []Product, err error
 }
 
 func (s t_local_stub) SearchProducts(ctx context.Context, a0 string) (r0 []Product, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []Product
+		err := intercept(ctx, "SearchProducts", func(ctx context.Context) (err error) {
+			r0, err = s.SearchProducts(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -92,12 +144,28 @@ func (s t_local_stub) SearchProducts(ctx context.Context, a0 string) (r0 []Produ
 
 type t_client_stub struct {
 	stub                  codegen.Stub
+	interceptor           codegen.Interceptor
 	getProductMetrics     *codegen.MethodMetrics
 	listProductsMetrics   *codegen.MethodMetrics
 	searchProductsMetrics *codegen.MethodMetrics
 }
 
 func (s t_client_stub) GetProduct(ctx context.Context, a0 string) (r0 Product, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 Product
+		err := intercept(ctx, "GetProduct", func(ctx context.Context) (err error) {
+			r0, err = s.GetProduct(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getProductMetrics.Count.Add(1)
@@ -130,8 +198,8 @@ func (s t_client_stub) GetProduct(ctx context.Context, a0 string) (r0 Product, e
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -148,13 +216,27 @@ func (s t_client_stub) GetProduct(ctx context.Context, a0 string) (r0 Product, e
 	s.getProductMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s t_client_stub) ListProducts(ctx context.Context) (r0 []Product, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 []Product
+		err := intercept(ctx, "ListProducts", func(ctx context.Context) (err error) {
+			r0, err = s.ListProducts(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.listProductsMetrics.Count.Add(1)
@@ -197,13 +279,28 @@ func (s t_client_stub) ListProducts(ctx context.Context) (r0 []Product, err erro
 	s.listProductsMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_Product_3e9d9e07(dec)
 	err = dec.Error()
 	return
 }
 
 func (s t_client_stub) SearchProducts(ctx context.Context, a0 string) (r0 []Product, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []Product
+		err := intercept(ctx, "SearchProducts", func(ctx context.Context) (err error) {
+			r0, err = s.SearchProducts(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.searchProductsMetrics.Count.Add(1)
@@ -236,8 +333,8 @@ func (s t_client_stub) SearchProducts(ctx context.Context, a0 string) (r0 []Prod
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -254,7 +351,7 @@ func (s t_client_stub) SearchProducts(ctx context.Context, a0 string) (r0 []Prod
 	s.searchProductsMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_Product_3e9d9e07(dec)
 	err = dec.Error()
 	return
@@ -263,8 +360,9 @@ func (s t_client_stub) SearchProducts(ctx context.Context, a0 string) (r0 []Prod
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -290,14 +388,28 @@ func (s t_server_stub) getProduct(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetProduct(ctx, a0)
+	var r0 Product
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.GetProduct(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 Product
+		appErr = s.interceptor(ctx, "GetProduct", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.GetProduct(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -317,7 +429,20 @@ func (s t_server_stub) listProducts(ctx context.Context, args []byte) (res []byt
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.ListProducts(ctx)
+	var r0 []Product
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.ListProducts(ctx)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		var ir0 []Product
+		appErr = s.interceptor(ctx, "ListProducts", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.ListProducts(ctx)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -335,14 +460,28 @@ func (s t_server_stub) searchProducts(ctx context.Context, args []byte) (res []b
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.SearchProducts(ctx, a0)
+	var r0 []Product
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.SearchProducts(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 []Product
+		appErr = s.interceptor(ctx, "SearchProducts", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.SearchProducts(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -402,6 +541,10 @@ func serviceweaver_dec_slice_string_4af10117(dec *codegen.Decoder) []string {
 	return res
 }
 
+func init() {
+	codegen.RegisterType[Product]()
+}
+
 // Encoding/decoding implementations.
 
 func serviceweaver_enc_slice_Product_3e9d9e07(enc *codegen.Encoder, arg []Product) {
@@ -16,27 +16,51 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/recommendationservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, listRecommendationsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/recommendationservice/T", Method: "ListRecommendations"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/recommendationservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦d65640ac:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/recommendationservice/T:ListRecommendations⟧\n⟦d212c866:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/onlineboutique/recommendationservice/T→github.com/ServiceWeaver/weaver/examples/onlineboutique/productcatalogservice/T⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, listRecommendationsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/recommendationservice/T", Method: "ListRecommendations"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦0702103d:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/recommendationservice/T\",\"methods\":[{\"name\":\"ListRecommendations\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) ListRecommendations(ctx context.Context, a0 string, a1 []string) (r0 []string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 []string
+		err := intercept(ctx, "ListRecommendations", func(ctx context.Context) (err error) {
+			r0, err = s.ListRecommendations(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -57,10 +81,27 @@ func (s t_local_stub) ListRecommendations(ctx context.Context, a0 string, a1 []s
 
 type t_client_stub struct {
 	stub                       codegen.Stub
+	interceptor                codegen.Interceptor
 	listRecommendationsMetrics *codegen.MethodMetrics
 }
 
 func (s t_client_stub) ListRecommendations(ctx context.Context, a0 string, a1 []string) (r0 []string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 []string
+		err := intercept(ctx, "ListRecommendations", func(ctx context.Context) (err error) {
+			r0, err = s.ListRecommendations(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.listRecommendationsMetrics.Count.Add(1)
@@ -91,7 +132,8 @@ func (s t_client_stub) ListRecommendations(ctx context.Context, a0 string, a1 []
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	enc.String(a0)
 	serviceweaver_enc_slice_string_4af10117(enc, a1)
 	var shardKey uint64
@@ -107,7 +149,7 @@ func (s t_client_stub) ListRecommendations(ctx context.Context, a0 string, a1 []
 	s.listRecommendationsMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_string_4af10117(dec)
 	err = dec.Error()
 	return
@@ -116,8 +158,9 @@ func (s t_client_stub) ListRecommendations(ctx context.Context, a0 string, a1 []
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -139,7 +182,7 @@ func (s t_server_stub) listRecommendations(ctx context.Context, args []byte) (re
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 []string
@@ -148,7 +191,22 @@ func (s t_server_stub) listRecommendations(ctx context.Context, args []byte) (re
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.ListRecommendations(ctx, a0, a1)
+	var r0 []string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.ListRecommendations(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 []string
+		appErr = s.interceptor(ctx, "ListRecommendations", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.ListRecommendations(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -19,27 +19,51 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T",
-		Iface:       reflect.TypeOf((*T)(nil)).Elem(),
-		Impl:        reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return t_local_stub{impl: impl.(T), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return t_client_stub{stub: stub, getQuoteMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T", Method: "GetQuote"}), shipOrderMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T", Method: "ShipOrder"})}
+		Name:    "github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T",
+		Iface:   reflect.TypeOf((*T)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦5d0eb4c1:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T:GetQuote,ShipOrder⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return t_local_stub{impl: impl.(T), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return t_server_stub{impl: impl.(T), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return t_client_stub{stub: stub, interceptor: interceptor, getQuoteMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T", Method: "GetQuote"}), shipOrderMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T", Method: "ShipOrder"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return t_server_stub{impl: impl.(T), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦07ab09e4:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice/T\",\"methods\":[{\"name\":\"GetQuote\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice.Address\",\"fields\":[{\"name\":\"StreetAddress\",\"type\":{\"kind\":\"string\"}},{\"name\":\"City\",\"type\":{\"kind\":\"string\"}},{\"name\":\"State\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Country\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ZipCode\",\"type\":{\"kind\":\"int32\"}}]},{\"kind\":\"slice\",\"elem\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\"}}],\"results\":[{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}]},{\"name\":\"ShipOrder\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice.Address\",\"fields\":[{\"name\":\"StreetAddress\",\"type\":{\"kind\":\"string\"}},{\"name\":\"City\",\"type\":{\"kind\":\"string\"}},{\"name\":\"State\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Country\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ZipCode\",\"type\":{\"kind\":\"int32\"}}]},{\"kind\":\"slice\",\"elem\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\"}}],\"results\":[{\"kind\":\"string\"}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice.Address\",\"fields\":[{\"name\":\"StreetAddress\",\"type\":{\"kind\":\"string\"}},{\"name\":\"City\",\"type\":{\"kind\":\"string\"}},{\"name\":\"State\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Country\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ZipCode\",\"type\":{\"kind\":\"int32\"}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type t_local_stub struct {
-	impl   T
-	tracer trace.Tracer
+	impl        T
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s t_local_stub) GetQuote(ctx context.Context, a0 Address, a1 []cartservice.CartItem) (r0 money.T, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 money.T
+		err := intercept(ctx, "GetQuote", func(ctx context.Context) (err error) {
+			r0, err = s.GetQuote(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -57,6 +81,22 @@ func (s t_local_stub) GetQuote(ctx context.Context, a0 Address, a1 []cartservice
 }
 
 func (s t_local_stub) ShipOrder(ctx context.Context, a0 Address, a1 []cartservice.CartItem) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 string
+		err := intercept(ctx, "ShipOrder", func(ctx context.Context) (err error) {
+			r0, err = s.ShipOrder(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -77,11 +117,28 @@ func (s t_local_stub) ShipOrder(ctx context.Context, a0 Address, a1 []cartservic
 
 type t_client_stub struct {
 	stub             codegen.Stub
+	interceptor      codegen.Interceptor
 	getQuoteMetrics  *codegen.MethodMetrics
 	shipOrderMetrics *codegen.MethodMetrics
 }
 
 func (s t_client_stub) GetQuote(ctx context.Context, a0 Address, a1 []cartservice.CartItem) (r0 money.T, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 money.T
+		err := intercept(ctx, "GetQuote", func(ctx context.Context) (err error) {
+			r0, err = s.GetQuote(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getQuoteMetrics.Count.Add(1)
@@ -112,7 +169,8 @@ func (s t_client_stub) GetQuote(ctx context.Context, a0 Address, a1 []cartservic
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	serviceweaver_enc_slice_CartItem_7a7ff11c(enc, a1)
 	var shardKey uint64
@@ -128,13 +186,29 @@ func (s t_client_stub) GetQuote(ctx context.Context, a0 Address, a1 []cartservic
 	s.getQuoteMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s t_client_stub) ShipOrder(ctx context.Context, a0 Address, a1 []cartservice.CartItem) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 string
+		err := intercept(ctx, "ShipOrder", func(ctx context.Context) (err error) {
+			r0, err = s.ShipOrder(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.shipOrderMetrics.Count.Add(1)
@@ -165,7 +239,8 @@ func (s t_client_stub) ShipOrder(ctx context.Context, a0 Address, a1 []cartservi
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	serviceweaver_enc_slice_CartItem_7a7ff11c(enc, a1)
 	var shardKey uint64
@@ -181,7 +256,7 @@ func (s t_client_stub) ShipOrder(ctx context.Context, a0 Address, a1 []cartservi
 	s.shipOrderMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -190,8 +265,9 @@ func (s t_client_stub) ShipOrder(ctx context.Context, a0 Address, a1 []cartservi
 // Server stub implementations.
 
 type t_server_stub struct {
-	impl    T
-	addLoad func(key uint64, load float64)
+	impl        T
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -215,7 +291,7 @@ func (s t_server_stub) getQuote(ctx context.Context, args []byte) (res []byte, e
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 Address
 	(&a0).WeaverUnmarshal(dec)
 	var a1 []cartservice.CartItem
@@ -224,7 +300,22 @@ func (s t_server_stub) getQuote(ctx context.Context, args []byte) (res []byte, e
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetQuote(ctx, a0, a1)
+	var r0 money.T
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.GetQuote(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 money.T
+		appErr = s.interceptor(ctx, "GetQuote", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.GetQuote(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -242,7 +333,7 @@ func (s t_server_stub) shipOrder(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 Address
 	(&a0).WeaverUnmarshal(dec)
 	var a1 []cartservice.CartItem
@@ -251,7 +342,22 @@ func (s t_server_stub) shipOrder(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.ShipOrder(ctx, a0, a1)
+	var r0 string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.ShipOrder(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 string
+		appErr = s.interceptor(ctx, "ShipOrder", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.ShipOrder(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -286,6 +392,10 @@ func (x *Address) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.ZipCode = dec.Int32()
 }
 
+func init() {
+	codegen.RegisterType[Address]()
+}
+
 // Encoding/decoding implementations.
 
 func serviceweaver_enc_slice_CartItem_7a7ff11c(enc *codegen.Encoder, arg []cartservice.CartItem) {
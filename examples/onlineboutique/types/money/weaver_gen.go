@@ -8,6 +8,9 @@ import (
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 )
 
+func init() {
+	codegen.EmbedSchema("⟦4385f2d9:wEaVeRsChEmA:{\"components\":null,\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\",\"fields\":[{\"name\":\"CurrencyCode\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Units\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"Nanos\",\"type\":{\"kind\":\"int32\"}}]}]}⟧\n")
+}
 
 // Local stub implementations.
 
@@ -36,3 +39,7 @@ func (x *T) WeaverUnmarshal(dec *codegen.Decoder) {
 	x.Units = dec.Int64()
 	x.Nanos = dec.Int32()
 }
+
+func init() {
+	codegen.RegisterType[T]()
+}
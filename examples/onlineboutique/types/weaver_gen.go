@@ -8,6 +8,9 @@ import (
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 )
 
+func init() {
+	codegen.EmbedSchema("⟦ab89d138:wEaVeRsChEmA:{\"components\":null,\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types.Order\",\"fields\":[{\"name\":\"OrderID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ShippingTrackingID\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ShippingCost\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}},{\"name\":\"ShippingAddress\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/shippingservice.Address\"}},{\"name\":\"Items\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types.OrderItem\",\"fields\":[{\"name\":\"Item\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\"}},{\"name\":\"Cost\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}}]}}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types.OrderItem\",\"fields\":[{\"name\":\"Item\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/cartservice.CartItem\"}},{\"name\":\"Cost\",\"type\":{\"kind\":\"custom\",\"name\":\"github.com/ServiceWeaver/weaver/examples/onlineboutique/types/money.T\"}}]}]}⟧\n")
+}
 
 // Local stub implementations.
 
@@ -81,3 +84,8 @@ func (x *OrderItem) WeaverUnmarshal(dec *codegen.Decoder) {
 	(&x.Item).WeaverUnmarshal(dec)
 	(&x.Cost).WeaverUnmarshal(dec)
 }
+
+func init() {
+	codegen.RegisterType[Order]()
+	codegen.RegisterType[OrderItem]()
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+// CallInfo describes a component method call.
+type CallInfo struct {
+	Component string // full name of the called component (e.g., "example.com/app/Cache")
+	Method    string // name of the called method (e.g., "Get")
+}
+
+// An InterceptorFunc intercepts the component method call described by info.
+// It performs the call by calling invoke, possibly with a different context
+// (e.g., a context with additional metadata), and typically returns the error
+// returned by invoke. It can also reject the call by returning an error
+// without calling invoke. An error returned by an InterceptorFunc is returned
+// to the caller of the method, just like an error returned by the method.
+type InterceptorFunc func(ctx context.Context, info CallInfo, invoke func(context.Context) error) error
+
+// An Interceptor intercepts component method calls. Interceptors implement
+// cross-cutting concerns like authorization checks, audit logging, or rate
+// limiting in one place, rather than in every component method.
+//
+// Interceptors are passed to Run. Every component method call is intercepted
+// twice: once by the Client functions of the interceptors, on the side of the
+// caller, and once by the Server functions of the interceptors, on the side
+// of the component that executes the call. This happens whether the call is
+// local or remote, so interceptors behave the same regardless of how
+// components are co-located.
+//
+//	authorize := weaver.Interceptor{
+//	    Server: func(ctx context.Context, info weaver.CallInfo, invoke func(context.Context) error) error {
+//	        if _, ok := metadata.Value(ctx, "principal"); !ok {
+//	            return fmt.Errorf("%s.%s: unauthenticated", info.Component, info.Method)
+//	        }
+//	        return invoke(ctx)
+//	    },
+//	}
+//	weaver.Run(ctx, app, authorize)
+type Interceptor struct {
+	// Client, if not nil, intercepts calls in the process of the caller.
+	Client InterceptorFunc
+
+	// Server, if not nil, intercepts calls in the process of the component
+	// that executes them.
+	Server InterceptorFunc
+}
+
+// interceptor returns a codegen.Interceptor that runs calls of the methods of
+// the provided component through the Client functions (if client is true) and
+// the Server functions (if server is true) of the provided interceptors. The
+// Client functions run first. Interceptors run in the order in which they are
+// provided, with the first interceptor being the outermost. interceptor
+// returns nil if there is nothing to run.
+func interceptor(component string, interceptors []Interceptor, client, server bool) codegen.Interceptor {
+	var fns []InterceptorFunc
+	if client {
+		for _, i := range interceptors {
+			if i.Client != nil {
+				fns = append(fns, i.Client)
+			}
+		}
+	}
+	if server {
+		for _, i := range interceptors {
+			if i.Server != nil {
+				fns = append(fns, i.Server)
+			}
+		}
+	}
+	if len(fns) == 0 {
+		return nil
+	}
+
+	return func(ctx context.Context, method string, invoke func(context.Context) error) error {
+		info := CallInfo{Component: component, Method: method}
+		var run func(ctx context.Context, i int) error
+		run = func(ctx context.Context, i int) error {
+			if i == len(fns) {
+				return invoke(ctx)
+			}
+			return fns[i](ctx, info, func(ctx context.Context) error {
+				return run(ctx, i+1)
+			})
+		}
+		return run(ctx, 0)
+	}
+}
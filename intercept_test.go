@@ -0,0 +1,91 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInterceptor(t *testing.T) {
+	var calls []string
+	record := func(name string) InterceptorFunc {
+		return func(ctx context.Context, info CallInfo, invoke func(context.Context) error) error {
+			calls = append(calls, name+":"+info.Component+"."+info.Method)
+			return invoke(ctx)
+		}
+	}
+	interceptors := []Interceptor{
+		{Client: record("c1"), Server: record("s1")},
+		{Server: record("s2")},
+		{Client: record("c3")},
+	}
+	invoke := func(context.Context) error {
+		calls = append(calls, "invoke")
+		return nil
+	}
+
+	for _, test := range []struct {
+		name           string
+		client, server bool
+		want           []string
+	}{
+		{"Local", true, true, []string{"c1:C.M", "c3:C.M", "s1:C.M", "s2:C.M", "invoke"}},
+		{"Client", true, false, []string{"c1:C.M", "c3:C.M", "invoke"}},
+		{"Server", false, true, []string{"s1:C.M", "s2:C.M", "invoke"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			calls = nil
+			intercept := interceptor("C", interceptors, test.client, test.server)
+			if err := intercept(context.Background(), "M", invoke); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, calls); diff != "" {
+				t.Fatalf("calls (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInterceptorReject(t *testing.T) {
+	rejected := errors.New("rejected")
+	intercept := interceptor("C", []Interceptor{{
+		Server: func(context.Context, CallInfo, func(context.Context) error) error {
+			return rejected
+		},
+	}}, false, true)
+	err := intercept(context.Background(), "M", func(context.Context) error {
+		t.Fatal("unexpected invoke")
+		return nil
+	})
+	if !errors.Is(err, rejected) {
+		t.Fatalf("got error %v, want %v", err, rejected)
+	}
+}
+
+func TestNoInterceptors(t *testing.T) {
+	if interceptor("C", nil, true, true) != nil {
+		t.Fatal("unexpected non-nil interceptor")
+	}
+	onlyClient := []Interceptor{{Client: func(ctx context.Context, _ CallInfo, invoke func(context.Context) error) error {
+		return invoke(ctx)
+	}}}
+	if interceptor("C", onlyClient, false, true) != nil {
+		t.Fatal("unexpected non-nil server interceptor")
+	}
+}
@@ -309,6 +309,56 @@ func BenchmarkEncDec(b *testing.B) {
 	}
 }
 
+// BenchmarkStub mimics the serialization work done by a generated client
+// stub and the corresponding server stub for a single method call: the
+// arguments are encoded, copied into a fresh message buffer (as the transport
+// does when it reads a message), and decoded. It compares the allocations
+// made with and without encoder pooling and aliasing decoders.
+func BenchmarkStub(b *testing.B) {
+	payloads := genWorkload(1000)
+	for _, bench := range []struct {
+		name  string
+		pool  bool // use codegen.GetEncoder and codegen.PutEncoder?
+		alias bool // use codegen.NewAliasingDecoder?
+	}{
+		{"Fresh", false, false},
+		{"Pooled", true, false},
+		{"PooledAliasing", true, true},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				p := payloads[rand.Intn(len(payloads))]
+				size := serviceweaver_size_payloadC_7e82696e(p)
+
+				// Encode.
+				var enc *codegen.Encoder
+				if bench.pool {
+					enc = codegen.GetEncoder(size)
+				} else {
+					enc = codegen.NewEncoder()
+					enc.Reset(size)
+				}
+				p.WeaverMarshal(enc)
+				msg := bytes.Clone(enc.Data())
+				if bench.pool {
+					codegen.PutEncoder(enc)
+				}
+
+				// Decode.
+				var dec *codegen.Decoder
+				if bench.alias {
+					dec = codegen.NewAliasingDecoder(msg)
+				} else {
+					dec = codegen.NewDecoder(msg)
+				}
+				var got payloadC
+				got.WeaverUnmarshal(dec)
+			}
+		})
+	}
+}
+
 // BenchmarkPing tests the performance of sending a payload of a given size
 // through an N-deep chain of components.
 //
@@ -17,135 +17,186 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1",
-		Iface:       reflect.TypeOf((*Ping1)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping1{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping1_local_stub{impl: impl.(Ping1), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping1_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1",
+		Iface:   reflect.TypeOf((*Ping1)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping1{}),
+		RefData: "⟦9b29b468:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1:PingC,PingS⟧\n⟦544443c5:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping1_local_stub{impl: impl.(Ping1), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping1_server_stub{impl: impl.(Ping1), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping1_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1", Method: "PingS"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping1_server_stub{impl: impl.(Ping1), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10",
-		Iface:       reflect.TypeOf((*Ping10)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping10{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping10_local_stub{impl: impl.(Ping10), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping10_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10",
+		Iface:   reflect.TypeOf((*Ping10)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping10{}),
+		RefData: "⟦0daeaba2:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10:PingC,PingS⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping10_local_stub{impl: impl.(Ping10), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping10_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10", Method: "PingS"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping10_server_stub{impl: impl.(Ping10), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping10_server_stub{impl: impl.(Ping10), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2",
-		Iface:       reflect.TypeOf((*Ping2)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping2{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping2_local_stub{impl: impl.(Ping2), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping2_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2",
+		Iface:   reflect.TypeOf((*Ping2)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping2{}),
+		RefData: "⟦53563549:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2:PingC,PingS⟧\n⟦b42b173c:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping2_local_stub{impl: impl.(Ping2), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping2_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2", Method: "PingS"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping2_server_stub{impl: impl.(Ping2), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping2_server_stub{impl: impl.(Ping2), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3",
-		Iface:       reflect.TypeOf((*Ping3)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping3{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping3_local_stub{impl: impl.(Ping3), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping3_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3",
+		Iface:   reflect.TypeOf((*Ping3)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping3{}),
+		RefData: "⟦e40ccda6:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3:PingC,PingS⟧\n⟦8c498b47:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping3_local_stub{impl: impl.(Ping3), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping3_server_stub{impl: impl.(Ping3), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping3_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3", Method: "PingS"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping3_server_stub{impl: impl.(Ping3), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4",
-		Iface:       reflect.TypeOf((*Ping4)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping4{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping4_local_stub{impl: impl.(Ping4), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping4_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4",
+		Iface:   reflect.TypeOf((*Ping4)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping4{}),
+		RefData: "⟦4187863d:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4:PingC,PingS⟧\n⟦90669915:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping4_local_stub{impl: impl.(Ping4), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping4_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4", Method: "PingS"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping4_server_stub{impl: impl.(Ping4), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping4_server_stub{impl: impl.(Ping4), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5",
-		Iface:       reflect.TypeOf((*Ping5)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping5{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping5_local_stub{impl: impl.(Ping5), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping5_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5",
+		Iface:   reflect.TypeOf((*Ping5)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping5{}),
+		RefData: "⟦6849630c:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5:PingC,PingS⟧\n⟦a38d1914:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping5_local_stub{impl: impl.(Ping5), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping5_server_stub{impl: impl.(Ping5), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping5_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5", Method: "PingS"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping5_server_stub{impl: impl.(Ping5), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6",
-		Iface:       reflect.TypeOf((*Ping6)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping6{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping6_local_stub{impl: impl.(Ping6), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping6_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6",
+		Iface:   reflect.TypeOf((*Ping6)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping6{}),
+		RefData: "⟦5d1319fa:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6:PingC,PingS⟧\n⟦ebf8b6d3:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping6_local_stub{impl: impl.(Ping6), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping6_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6", Method: "PingS"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping6_server_stub{impl: impl.(Ping6), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping6_server_stub{impl: impl.(Ping6), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7",
-		Iface:       reflect.TypeOf((*Ping7)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping7{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping7_local_stub{impl: impl.(Ping7), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping7_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7",
+		Iface:   reflect.TypeOf((*Ping7)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping7{}),
+		RefData: "⟦b0c6f4c7:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7:PingC,PingS⟧\n⟦88d68418:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping7_local_stub{impl: impl.(Ping7), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping7_server_stub{impl: impl.(Ping7), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping7_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7", Method: "PingS"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping7_server_stub{impl: impl.(Ping7), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8",
-		Iface:       reflect.TypeOf((*Ping8)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping8{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping8_local_stub{impl: impl.(Ping8), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping8_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8",
+		Iface:   reflect.TypeOf((*Ping8)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping8{}),
+		RefData: "⟦6247b202:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8:PingC,PingS⟧\n⟦ed98271d:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping8_local_stub{impl: impl.(Ping8), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping8_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8", Method: "PingS"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping8_server_stub{impl: impl.(Ping8), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping8_server_stub{impl: impl.(Ping8), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9",
-		Iface:       reflect.TypeOf((*Ping9)(nil)).Elem(),
-		Impl:        reflect.TypeOf(ping9{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return ping9_local_stub{impl: impl.(Ping9), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return ping9_client_stub{stub: stub, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9", Method: "PingS"})}
+		Name:    "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9",
+		Iface:   reflect.TypeOf((*Ping9)(nil)).Elem(),
+		Impl:    reflect.TypeOf(ping9{}),
+		RefData: "⟦032278cc:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9:PingC,PingS⟧\n⟦5ceb96a7:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return ping9_local_stub{impl: impl.(Ping9), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return ping9_server_stub{impl: impl.(Ping9), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return ping9_client_stub{stub: stub, interceptor: interceptor, pingCMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9", Method: "PingC"}), pingSMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9", Method: "PingS"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return ping9_server_stub{impl: impl.(Ping9), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦68ba08c7:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9\",\"methods\":[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/internal/benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type ping1_local_stub struct {
-	impl   Ping1
-	tracer trace.Tracer
+	impl        Ping1
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping1_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -163,6 +214,22 @@ func (s ping1_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping1_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -180,11 +247,28 @@ func (s ping1_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 }
 
 type ping10_local_stub struct {
-	impl   Ping10
-	tracer trace.Tracer
+	impl        Ping10
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping10_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -202,6 +286,22 @@ func (s ping10_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 }
 
 func (s ping10_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -219,11 +319,28 @@ func (s ping10_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 }
 
 type ping2_local_stub struct {
-	impl   Ping2
-	tracer trace.Tracer
+	impl        Ping2
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping2_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -241,6 +358,22 @@ func (s ping2_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping2_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -258,11 +391,28 @@ func (s ping2_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 }
 
 type ping3_local_stub struct {
-	impl   Ping3
-	tracer trace.Tracer
+	impl        Ping3
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping3_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -280,6 +430,22 @@ func (s ping3_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping3_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -297,11 +463,28 @@ func (s ping3_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 }
 
 type ping4_local_stub struct {
-	impl   Ping4
-	tracer trace.Tracer
+	impl        Ping4
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping4_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -319,6 +502,22 @@ func (s ping4_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping4_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -336,11 +535,28 @@ func (s ping4_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 }
 
 type ping5_local_stub struct {
-	impl   Ping5
-	tracer trace.Tracer
+	impl        Ping5
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping5_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -358,6 +574,22 @@ func (s ping5_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping5_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -375,11 +607,28 @@ func (s ping5_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 }
 
 type ping6_local_stub struct {
-	impl   Ping6
-	tracer trace.Tracer
+	impl        Ping6
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping6_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -397,6 +646,22 @@ func (s ping6_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping6_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -414,11 +679,28 @@ func (s ping6_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 }
 
 type ping7_local_stub struct {
-	impl   Ping7
-	tracer trace.Tracer
+	impl        Ping7
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping7_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -436,6 +718,22 @@ func (s ping7_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping7_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -453,11 +751,28 @@ func (s ping7_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 }
 
 type ping8_local_stub struct {
-	impl   Ping8
-	tracer trace.Tracer
+	impl        Ping8
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping8_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -475,6 +790,22 @@ func (s ping8_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping8_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -492,11 +823,28 @@ func (s ping8_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 }
 
 type ping9_local_stub struct {
-	impl   Ping9
-	tracer trace.Tracer
+	impl        Ping9
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s ping9_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -514,6 +862,22 @@ func (s ping9_local_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 pa
 }
 
 func (s ping9_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -534,11 +898,28 @@ func (s ping9_local_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 pa
 
 type ping1_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping1_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -572,8 +953,8 @@ func (s ping1_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -591,13 +972,29 @@ func (s ping1_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping1_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -628,7 +1025,8 @@ func (s ping1_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -644,7 +1042,7 @@ func (s ping1_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -652,11 +1050,28 @@ func (s ping1_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 
 type ping10_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping10_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -690,8 +1105,8 @@ func (s ping10_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -709,13 +1124,29 @@ func (s ping10_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping10_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -746,7 +1177,8 @@ func (s ping10_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -762,7 +1194,7 @@ func (s ping10_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -770,11 +1202,28 @@ func (s ping10_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0
 
 type ping2_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping2_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -808,8 +1257,8 @@ func (s ping2_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -827,13 +1276,29 @@ func (s ping2_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping2_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -864,7 +1329,8 @@ func (s ping2_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -880,7 +1346,7 @@ func (s ping2_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -888,11 +1354,28 @@ func (s ping2_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 
 type ping3_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping3_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -926,8 +1409,8 @@ func (s ping3_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -945,13 +1428,29 @@ func (s ping3_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping3_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -982,7 +1481,8 @@ func (s ping3_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -998,7 +1498,7 @@ func (s ping3_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1006,11 +1506,28 @@ func (s ping3_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 
 type ping4_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping4_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -1044,8 +1561,8 @@ func (s ping4_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -1063,13 +1580,29 @@ func (s ping4_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping4_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -1100,7 +1633,8 @@ func (s ping4_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1116,7 +1650,7 @@ func (s ping4_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1124,11 +1658,28 @@ func (s ping4_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 
 type ping5_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping5_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -1162,8 +1713,8 @@ func (s ping5_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -1181,13 +1732,29 @@ func (s ping5_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping5_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -1218,7 +1785,8 @@ func (s ping5_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1234,7 +1802,7 @@ func (s ping5_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1242,11 +1810,28 @@ func (s ping5_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 
 type ping6_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping6_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -1280,8 +1865,8 @@ func (s ping6_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -1299,13 +1884,29 @@ func (s ping6_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping6_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -1336,7 +1937,8 @@ func (s ping6_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1352,7 +1954,7 @@ func (s ping6_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1360,11 +1962,28 @@ func (s ping6_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 
 type ping7_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping7_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -1398,8 +2017,8 @@ func (s ping7_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -1417,13 +2036,29 @@ func (s ping7_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping7_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -1454,7 +2089,8 @@ func (s ping7_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1470,7 +2106,7 @@ func (s ping7_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1478,11 +2114,28 @@ func (s ping7_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 
 type ping8_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping8_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -1516,8 +2169,8 @@ func (s ping8_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -1535,13 +2188,29 @@ func (s ping8_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping8_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -1572,7 +2241,8 @@ func (s ping8_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1588,7 +2258,7 @@ func (s ping8_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1596,11 +2266,28 @@ func (s ping8_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 
 type ping9_client_stub struct {
 	stub         codegen.Stub
+	interceptor  codegen.Interceptor
 	pingCMetrics *codegen.MethodMetrics
 	pingSMetrics *codegen.MethodMetrics
 }
 
 func (s ping9_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 payloadC, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadC
+		err := intercept(ctx, "PingC", func(ctx context.Context) (err error) {
+			r0, err = s.PingC(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingCMetrics.Count.Add(1)
@@ -1634,8 +2321,8 @@ func (s ping9_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	size := 0
 	size += serviceweaver_size_payloadC_7e82696e(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	(a0).WeaverMarshal(enc)
@@ -1653,13 +2340,29 @@ func (s ping9_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	s.pingCMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
 }
 
 func (s ping9_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 payloadS, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 payloadS
+		err := intercept(ctx, "PingS", func(ctx context.Context) (err error) {
+			r0, err = s.PingS(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingSMetrics.Count.Add(1)
@@ -1690,7 +2393,8 @@ func (s ping9_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	(a0).WeaverMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1706,7 +2410,7 @@ func (s ping9_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	s.pingSMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1715,8 +2419,9 @@ func (s ping9_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 // Server stub implementations.
 
 type ping1_server_stub struct {
-	impl    Ping1
-	addLoad func(key uint64, load float64)
+	impl        Ping1
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -1740,7 +2445,7 @@ func (s ping1_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -1749,7 +2454,22 @@ func (s ping1_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -1767,7 +2487,7 @@ func (s ping1_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -1776,7 +2496,22 @@ func (s ping1_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -1786,8 +2521,9 @@ func (s ping1_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping10_server_stub struct {
-	impl    Ping10
-	addLoad func(key uint64, load float64)
+	impl        Ping10
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -1811,7 +2547,7 @@ func (s ping10_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -1820,7 +2556,22 @@ func (s ping10_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -1838,7 +2589,7 @@ func (s ping10_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -1847,7 +2598,22 @@ func (s ping10_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -1857,8 +2623,9 @@ func (s ping10_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping2_server_stub struct {
-	impl    Ping2
-	addLoad func(key uint64, load float64)
+	impl        Ping2
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -1882,7 +2649,7 @@ func (s ping2_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -1891,7 +2658,22 @@ func (s ping2_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -1909,7 +2691,7 @@ func (s ping2_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -1918,7 +2700,22 @@ func (s ping2_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -1928,8 +2725,9 @@ func (s ping2_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping3_server_stub struct {
-	impl    Ping3
-	addLoad func(key uint64, load float64)
+	impl        Ping3
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -1953,7 +2751,7 @@ func (s ping3_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -1962,7 +2760,22 @@ func (s ping3_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -1980,7 +2793,7 @@ func (s ping3_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -1989,7 +2802,22 @@ func (s ping3_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -1999,8 +2827,9 @@ func (s ping3_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping4_server_stub struct {
-	impl    Ping4
-	addLoad func(key uint64, load float64)
+	impl        Ping4
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -2024,7 +2853,7 @@ func (s ping4_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2033,7 +2862,22 @@ func (s ping4_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2051,7 +2895,7 @@ func (s ping4_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2060,7 +2904,22 @@ func (s ping4_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2070,8 +2929,9 @@ func (s ping4_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping5_server_stub struct {
-	impl    Ping5
-	addLoad func(key uint64, load float64)
+	impl        Ping5
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -2095,7 +2955,7 @@ func (s ping5_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2104,7 +2964,22 @@ func (s ping5_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2122,7 +2997,7 @@ func (s ping5_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2131,7 +3006,22 @@ func (s ping5_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2141,8 +3031,9 @@ func (s ping5_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping6_server_stub struct {
-	impl    Ping6
-	addLoad func(key uint64, load float64)
+	impl        Ping6
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -2166,7 +3057,7 @@ func (s ping6_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2175,7 +3066,22 @@ func (s ping6_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2193,7 +3099,7 @@ func (s ping6_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2202,7 +3108,22 @@ func (s ping6_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2212,8 +3133,9 @@ func (s ping6_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping7_server_stub struct {
-	impl    Ping7
-	addLoad func(key uint64, load float64)
+	impl        Ping7
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -2237,7 +3159,7 @@ func (s ping7_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2246,7 +3168,22 @@ func (s ping7_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2264,7 +3201,7 @@ func (s ping7_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2273,7 +3210,22 @@ func (s ping7_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2283,8 +3235,9 @@ func (s ping7_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping8_server_stub struct {
-	impl    Ping8
-	addLoad func(key uint64, load float64)
+	impl        Ping8
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -2308,7 +3261,7 @@ func (s ping8_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2317,7 +3270,22 @@ func (s ping8_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2335,7 +3303,7 @@ func (s ping8_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2344,7 +3312,22 @@ func (s ping8_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2354,8 +3337,9 @@ func (s ping8_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 }
 
 type ping9_server_stub struct {
-	impl    Ping9
-	addLoad func(key uint64, load float64)
+	impl        Ping9
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -2379,7 +3363,7 @@ func (s ping9_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadC
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2388,7 +3372,22 @@ func (s ping9_server_stub) pingC(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingC(ctx, a0, a1)
+	var r0 payloadC
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingC(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadC
+		appErr = s.interceptor(ctx, "PingC", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingC(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2406,7 +3405,7 @@ func (s ping9_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 payloadS
 	(&a0).WeaverUnmarshal(dec)
 	var a1 int
@@ -2415,7 +3414,22 @@ func (s ping9_server_stub) pingS(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.PingS(ctx, a0, a1)
+	var r0 payloadS
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.PingS(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 payloadS
+		appErr = s.interceptor(ctx, "PingS", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.PingS(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -2655,6 +3669,17 @@ func serviceweaver_dec_slice_string_4af10117(dec *codegen.Decoder) []string {
 	return res
 }
 
+func init() {
+	codegen.RegisterType[X1]()
+	codegen.RegisterType[X2]()
+	codegen.RegisterType[X3]()
+	codegen.RegisterType[X4]()
+	codegen.RegisterType[X5]()
+	codegen.RegisterType[X6]()
+	codegen.RegisterType[payloadC]()
+	codegen.RegisterType[payloadS]()
+}
+
 // Size implementations.
 
 // serviceweaver_size_X1_25e7d26b returns the size (in bytes) of the serialization
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/queue"
 	"github.com/ServiceWeaver/weaver/internal/traceio"
@@ -339,6 +340,25 @@ func (e *EnvelopeConn) UpdateRoutingInfoRPC(routing *protos.RoutingInfo) error {
 	return nil
 }
 
+// ShutdownRPC asks the weavelet to shut down gracefully within the provided
+// grace period. A zero grace period lets the weavelet take as long as it
+// needs. ShutdownRPC returns once the weavelet has shut down.
+func (e *EnvelopeConn) ShutdownRPC(grace time.Duration) error {
+	req := &protos.EnvelopeMsg{
+		ShutdownRequest: &protos.ShutdownRequest{
+			GracePeriodNs: int64(grace),
+		},
+	}
+	reply, err := e.rpc(req)
+	if err != nil {
+		return err
+	}
+	if reply.ShutdownReply == nil {
+		return fmt.Errorf("nil ShutdownReply received from weavelet")
+	}
+	return nil
+}
+
 func (e *EnvelopeConn) rpc(request *protos.EnvelopeMsg) (*protos.WeaveletMsg, error) {
 	response, err := e.conn.doBlockingRPC(request)
 	if err != nil {
@@ -31,8 +31,9 @@ import (
 )
 
 // WeaveletHandler handles messages from the envelope. A handler should not
-// block and should not perform RPCs over the pipe. Values passed to the
-// handlers are only valid for the duration of the handler's execution.
+// block and should not perform RPCs over the pipe, with the exception of
+// Shutdown, which is run in its own goroutine. Values passed to the handlers
+// are only valid for the duration of the handler's execution.
 type WeaveletHandler interface {
 	// TODO(mwhittaker): Add context.Context to these methods?
 
@@ -45,6 +46,10 @@ type WeaveletHandler interface {
 
 	// UpdateRoutingInfo updates a component's routing information.
 	UpdateRoutingInfo(*protos.UpdateRoutingInfoRequest) (*protos.UpdateRoutingInfoReply, error)
+
+	// Shutdown gracefully shuts down the weavelet. It blocks until the
+	// weavelet has drained or the request's grace period has elapsed.
+	Shutdown(*protos.ShutdownRequest) (*protos.ShutdownReply, error)
 }
 
 // WeaveletConn is the weavelet side of the connection between a weavelet and
@@ -217,6 +222,22 @@ func (w *WeaveletConn) handleMessage(msg *protos.EnvelopeMsg) error {
 			Error:                  errstring(err),
 			UpdateRoutingInfoReply: reply,
 		})
+	case msg.ShutdownRequest != nil:
+		// Shutting down blocks until in-progress method calls finish, and
+		// therefore we process it in a separate goroutine, similar to
+		// profiling requests.
+		id := msg.Id
+		req := protomsg.Clone(msg.ShutdownRequest)
+		go func() {
+			reply, err := w.handler.Shutdown(req)
+			//nolint:errcheck //errMsg will be returned on next send
+			w.conn.send(&protos.WeaveletMsg{
+				Id:            -id,
+				Error:         errstring(err),
+				ShutdownReply: reply,
+			})
+		}()
+		return nil
 	default:
 		err := fmt.Errorf("weavelet_conn: unexpected message %+v", msg)
 		w.conn.cleanup(err)
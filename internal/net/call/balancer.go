@@ -17,6 +17,7 @@ package call
 import (
 	"fmt"
 	"math/rand"
+	"time"
 )
 
 // A Balancer picks the endpoint to which which an RPC client performs a call. A
@@ -45,6 +46,22 @@ type Balancer interface {
 	Pick(CallOptions) (Endpoint, error)
 }
 
+// A HealthObserver is a Balancer that is informed of the health of the
+// endpoints it picks. A Connection reports the outcome of every call made to
+// an endpoint picked by a HealthObserver, along with the outcome of every
+// health check (see ClientOptions.HealthCheckInterval), by calling Observe.
+// Like Pick, Observe is never called concurrently with the other methods of
+// the Balancer.
+type HealthObserver interface {
+	Balancer
+
+	// Observe records that a call or health check to the provided endpoint
+	// took the provided amount of time and failed with the provided error, or
+	// succeeded if err is nil. Errors returned by the application are not
+	// failures; only errors encountered while delivering a call are.
+	Observe(endpoint Endpoint, latency time.Duration, err error)
+}
+
 // balancerFuncImpl is the imeplementation of the "functional" balancer
 // returned by BalancerFunc.
 type balancerFuncImpl struct {
@@ -96,6 +113,50 @@ func (rr *roundRobin) Pick(CallOptions) (Endpoint, error) {
 	return endpoint, nil
 }
 
+type leastLoaded struct {
+	endpoints []Endpoint
+}
+
+var _ Balancer = &leastLoaded{}
+
+// LeastLoaded returns a balancer that picks two endpoints at random and
+// returns the one with fewer in-flight calls, a strategy known as "the power
+// of two choices". The in-flight calls of an endpoint are the pending calls
+// that the Connection using the balancer has issued to the endpoint; calls
+// issued by other Connections that share the same Pool don't count. Slow
+// endpoints accumulate in-flight calls, so they are picked less often than
+// fast ones.
+func LeastLoaded() Balancer {
+	return &leastLoaded{}
+}
+
+func (ll *leastLoaded) Update(endpoints []Endpoint) {
+	ll.endpoints = endpoints
+}
+
+// Pick implements the Balancer interface.
+func (ll *leastLoaded) Pick(opts CallOptions) (Endpoint, error) {
+	n := len(ll.endpoints)
+	switch n {
+	case 0:
+		return nil, fmt.Errorf("%w: no endpoints available", Unreachable)
+	case 1:
+		return ll.endpoints[0], nil
+	}
+
+	// Pick two distinct endpoints.
+	i := rand.Intn(n)
+	j := rand.Intn(n - 1)
+	if j >= i {
+		j++
+	}
+	a, b := ll.endpoints[i], ll.endpoints[j]
+	if opts.inflight != nil && opts.inflight(b.Address()) < opts.inflight(a.Address()) {
+		return b, nil
+	}
+	return a, nil
+}
+
 // Sharded returns a new sharded balancer.
 //
 // Given a list of n endpoints e1, ..., en, for a request with shard key k, a
@@ -65,6 +65,27 @@ package call
 // the resolver later returns a new set of endpoints that includes a draining
 // connection that hasn't closed itself, the connection is transitioned out of
 // the draining phase and is once again allowed to process new RPCs.
+//
+// # Connection sharing
+//
+// Multiple clients can share the same network connections by constructing
+// them with the same Pool. Every client has its own resolver, set of
+// endpoints, and balancer, but there is at most one network connection to
+// every endpoint address in the pool, regardless of how many clients use the
+// address. The pool tracks the number of clients using every address, and a
+// network connection starts draining only when no client uses its address
+// anymore.
+//
+// # Health checking
+//
+// The outcome of every call is reported to the balancer that picked its
+// endpoint, if the balancer implements HealthObserver. This allows a balancer
+// (e.g., the one returned by OutlierEjection) to passively track the error
+// rate and latency of every endpoint. If ClientOptions.HealthCheckInterval is
+// set, the client also spawns a healthCheck goroutine that periodically sends
+// a ping message to every endpoint it is connected to and reports the outcome
+// of the ping to the balancer. Pings detect servers that are unresponsive even
+// when no calls are being made to them.
 
 import (
 	"bufio"
@@ -78,28 +99,32 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ServiceWeaver/weaver/metadata"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
 	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slog"
 )
 
 const (
-	// Size of the header included in each message.
-	msgHeaderSize = 16 + 8 + traceHeaderLen // handler_key + deadline + trace_context
+	// Size of the fixed-size header included in each request message. The
+	// header is followed by the serialized context metadata, if any.
+	msgHeaderSize = 16 + 8 + traceHeaderLen + 4 // handler_key + deadline + trace_context + metadata_length
 
 	// maxReconnectTries is the maximum number of times a reconnecting
 	// connection will try and create a connection before erroring out.
 	maxReconnectTries = 3
+
+	// maxPickTries is the maximum number of times a reconnecting connection
+	// asks its balancer to pick an endpoint other than an excluded one.
+	maxPickTries = 3
 )
 
 // TODO:
-// - Preserve error types (maybe via registration and Gob?)
 // - Load balancer
 //   - API to allow changes to set
-//   - health-checks
-//   - track subset that is healthy
 //   - track load info
 //   - data structure for efficient picking (randomize? weighted?)
 //   - pick on call (error if none available)
@@ -109,6 +134,10 @@ type Connection interface {
 	// Call makes an RPC over a Connection.
 	Call(context.Context, MethodKey, []byte, CallOptions) ([]byte, error)
 
+	// Stream starts a streaming RPC over a Connection. The returned stream
+	// must be closed once the caller is done with it.
+	Stream(context.Context, MethodKey, []byte, CallOptions) (*ClientStream, error)
+
 	// Close closes a connection. Pending invocations of Call are cancelled and
 	// return an error. All future invocations of Call fail and return an error
 	// immediately. Close can be called more than once.
@@ -127,18 +156,44 @@ type Listener interface {
 // after a shutdown.
 type reconnectingConnection struct {
 	opts ClientOptions
+	pool *Pool
+
+	// mu guards the following fields and the Balancers used by the
+	// connection. When acquired together, mu is acquired before pool.mu, which
+	// is acquired before clientConnection.mu.
+	mu        sync.Mutex
+	endpoints []Endpoint
+	closed    bool
+	inflight  map[string]*atomic.Int64 // in-flight calls per endpoint address
+
+	resolver Resolver
+	cancel   func()         // cancels the watchResolver goroutine
+	done     sync.WaitGroup // used to wait for watchResolver to finish
+}
 
-	// mu guards the following fields and some of the fields in the
-	// clientConnections inside connections and draining.
+// A Pool is a set of client-side network connections that can be shared by
+// multiple Connections. Connections that use the same Pool share a single
+// network connection to every endpoint address, and their calls are
+// multiplexed over it. See ClientOptions.Pool.
+type Pool struct {
+	// mu guards the following fields. Network connections are dialed and
+	// verified without holding mu.
 	mu          sync.Mutex
-	endpoints   []Endpoint
 	connections map[string]*clientConnection // keys are endpoint addresses
 	draining    map[string]*clientConnection // keys are endpoint addresses
-	closed      bool
 
-	resolver       Resolver
-	cancelResolver func()         // cancels the watchResolver goroutine
-	resolverDone   sync.WaitGroup // used to wait for watchResolver to finish
+	// users holds the Connections that use every address, along with their
+	// endpoint for the address.
+	users map[string]map[*reconnectingConnection]Endpoint
+}
+
+// NewPool returns a new, empty Pool.
+func NewPool() *Pool {
+	return &Pool{
+		connections: map[string]*clientConnection{},
+		draining:    map[string]*clientConnection{},
+		users:       map[string]map[*reconnectingConnection]Endpoint{},
+	}
 }
 
 // clientConnection manages one network connection on the client-side.
@@ -146,20 +201,29 @@ type clientConnection struct {
 	logger         *slog.Logger
 	endpoint       Endpoint
 	c              net.Conn
-	cbuf           *bufio.Reader    // Buffered reader wrapped around c
-	wlock          sync.Mutex       // Guards writes to c
-	mu             *sync.Mutex      // Same as reconnectingConnection.mu
-	draining       bool             // is this clientConnection draining?
-	ended          bool             // has this clientConnection ended?
-	loggedShutdown bool             // Have we logged a shutdown error?
-	version        version          // Version number to use for connection
-	calls          map[uint64]*call // In-progress calls
-	lastID         uint64           // Last assigned request ID for a call
+	cbuf           *bufio.Reader         // Buffered reader wrapped around c
+	wlock          sync.Mutex            // Guards writes to c
+	cmp            compressor            // Compresses requests and stream data
+	mu             sync.Mutex            // Guards the following fields
+	draining       bool                  // is this clientConnection draining?
+	ended          bool                  // has this clientConnection ended?
+	loggedShutdown bool                  // Have we logged a shutdown error?
+	version        version               // Version number to use for connection
+	calls          map[uint64]*call      // In-progress calls
+	pings          map[uint64]chan error // In-progress pings
+	lastID         uint64                // Last assigned request ID for a call or ping
+	verified       map[any]bool          // verifier keys that accepted the server
 }
 
 // call holds the state for an active call at the client.
 type call struct {
 	id         uint64
+	owner      *reconnectingConnection // the Connection that issued the call
+	stream     *streamState            // non-nil for streaming calls
+	balancer   Balancer                // the Balancer that picked endpoint
+	endpoint   Endpoint                // the endpoint the call was sent to
+	inflight   *atomic.Int64           // owner's in-flight calls to endpoint
+	start      time.Time               // when the request was sent
 	doneSignal chan struct{}
 
 	// Fields below are accessed across goroutines, but their access is
@@ -180,9 +244,11 @@ type serverConnection struct {
 	cbuf        *bufio.Reader // Buffered reader wrapped around c
 	wlock       sync.Mutex    // Guards writes to c
 	mu          sync.Mutex
-	closed      bool              // has c been closed?
-	version     version           // Version number to use for connection
-	cancelFuncs map[uint64]func() // Cancellation functions for in-progress calls
+	closed      bool                    // has c been closed?
+	version     version                 // Version number to use for connection
+	cmp         compressor              // Compresses responses and stream data
+	cancelFuncs map[uint64]func()       // Cancellation functions for in-progress calls
+	streams     map[uint64]*streamState // In-progress streaming calls
 }
 
 // serverState tracks all live server-side connections so we can clean things up when canceled.
@@ -249,7 +315,9 @@ func (ss *serverState) serveConnection(ctx context.Context, conn net.Conn, hmap
 		cbuf:        bufio.NewReader(conn),
 		version:     initialVersion, // Updated when we hear from client
 		cancelFuncs: map[uint64]func(){},
+		streams:     map[uint64]*streamState{},
 	}
+	c.cmp.threshold = ss.opts.CompressThreshold
 	ss.register(c)
 
 	go c.readRequests(ctx, hmap, func() { ss.unregister(c) })
@@ -282,13 +350,14 @@ func (ss *serverState) unregister(c *serverConnection) {
 // resolver.
 func Connect(ctx context.Context, resolver Resolver, opts ClientOptions) (Connection, error) {
 	// Construct the connection.
+	opts = opts.withDefaults()
 	conn := reconnectingConnection{
-		opts:           opts.withDefaults(),
-		endpoints:      []Endpoint{},
-		connections:    map[string]*clientConnection{},
-		draining:       map[string]*clientConnection{},
-		resolver:       resolver,
-		cancelResolver: func() {},
+		opts:      opts,
+		pool:      opts.Pool,
+		endpoints: []Endpoint{},
+		inflight:  map[string]*atomic.Int64{},
+		resolver:  resolver,
+		cancel:    func() {},
 	}
 
 	// Compute the initial set of endpoints.
@@ -309,13 +378,14 @@ func Connect(ctx context.Context, resolver Resolver, opts ClientOptions) (Connec
 		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	conn.cancel = cancel
+
 	// If the resolver is non-constant, then we start a goroutine to watch for
 	// updates to the set of endpoints. If the resolver is constant, then we
 	// don't need to do this because the endpoints never change.
 	if !resolver.IsConstant() {
-		ctx, cancel := context.WithCancel(ctx)
-		conn.cancelResolver = cancel
-		conn.resolverDone.Add(1)
+		conn.done.Add(1)
 		go conn.watchResolver(ctx, version)
 	}
 
@@ -331,29 +401,42 @@ func (rc *reconnectingConnection) Close() {
 			return
 		}
 		rc.closed = true
-		for _, conn := range rc.connections {
-			conn.endCalls(fmt.Errorf("%w: %s", CommunicationError, "connection closed"))
+
+		p := rc.pool
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		// End the calls issued by rc and stop using its endpoints. Network
+		// connections that are no longer used by any Connection in the pool
+		// are closed.
+		err := fmt.Errorf("%w: %s", CommunicationError, "connection closed")
+		for _, conn := range p.connections {
+			conn.endCallsOf(rc, err)
 		}
-		for _, conn := range rc.draining {
-			conn.endCalls(fmt.Errorf("%w: %s", CommunicationError, "connection closed"))
+		for _, conn := range p.draining {
+			conn.endCallsOf(rc, err)
 		}
+		p.release(rc, rc.endpoints)
+		rc.endpoints = nil
+		p.drainUnused()
+		p.removeDrainedConnections()
 	}
 	closeWithLock()
 
-	// Cancel the watchResolver goroutine and wait for it to terminate. If the
-	// watchResolver has already been terminated, then this code is a no-op.
-	// Note that if we hold the lock while waiting for watchResolver to
-	// terminate, we may deadlock.
-	rc.cancelResolver()
-	rc.resolverDone.Wait()
+	// Cancel the watchResolver goroutine and wait for it to terminate. If it
+	// has already been terminated, then this code is a no-op. Note that if we
+	// hold the lock while waiting for the goroutine to terminate, we may
+	// deadlock.
+	rc.cancel()
+	rc.done.Wait()
 }
 
-// Call makes an RPC over connection c.
-func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
-	var hdr [msgHeaderSize]byte
+// requestHeader returns the header of a request for method h, including the
+// context metadata (if any) contained in ctx.
+func requestHeader(ctx context.Context, h MethodKey) ([]byte, error) {
+	hdr := make([]byte, msgHeaderSize)
 	copy(hdr[0:], h[:])
-	deadline, haveDeadline := ctx.Deadline()
-	if haveDeadline {
+	if deadline, haveDeadline := ctx.Deadline(); haveDeadline {
 		// Send the deadline in the header. We use the relative time instead
 		// of absolute in case there is significant clock skew. This does mean
 		// that we will not count transmission delay against the deadline.
@@ -370,31 +453,63 @@ func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []b
 	// Send trace information in the header.
 	writeTraceContext(ctx, hdr[24:])
 
-	rpc := &call{}
+	// Send context metadata after the fixed-size header.
+	hdr = appendContextMetadata(ctx, hdr)
+	binary.LittleEndian.PutUint32(hdr[24+traceHeaderLen:], uint32(len(hdr)-msgHeaderSize))
+	return hdr, nil
+}
+
+// Call makes an RPC over connection c.
+func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
+	rpc, conn, err := rc.send(ctx, h, arg, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.HedgeDelay > 0 {
+		return rc.hedge(ctx, h, arg, opts, rpc, conn)
+	}
+	return rc.waitAndReport(ctx, rpc, conn, opts)
+}
+
+// send starts a call and sends its request.
+func (rc *reconnectingConnection) send(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) (*call, *clientConnection, error) {
+	hdr, err := requestHeader(ctx, h)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rpc := &call{owner: rc}
 	rpc.doneSignal = make(chan struct{})
 
 	// TODO: Arrange to obey deadline in any reconnection done inside startCall.
 	//
 	// TODO(mwhittaker): Right now, every RPC call is tried on a single server
 	// connection. If the call fails, it is not retried. If a call fails on a
-	// connection, we may want to try it again on a different connection. We
-	// may also want to detect that certain connections are bad and avoid them
-	// outright.
+	// connection, we may want to try it again on a different connection.
 	conn, err := rc.startCall(ctx, rpc, opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	rpc.start = time.Now()
 
-	if err := writeMessage(conn.c, &conn.wlock, requestMessage, rpc.id, hdr[:], arg, rc.opts.WriteFlattenLimit); err != nil {
+	if err := writeCompressible(conn.c, &conn.wlock, requestMessage, rpc.id, hdr, arg, rc.opts.WriteFlattenLimit, &conn.cmp); err != nil {
 		conn.shutdown("client send request", err)
 		conn.endCall(rpc)
-		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
+		err = fmt.Errorf("%w: %s", CommunicationError, err)
+		rc.observe(rpc.balancer, rpc.endpoint, time.Since(rpc.start), err)
+		return nil, nil, err
 	}
+	return rpc, conn, nil
+}
 
+// wait waits for a call sent over conn to finish and returns its result. If
+// ctx is done first, wait cancels the call.
+func (rc *reconnectingConnection) wait(ctx context.Context, rpc *call, conn *clientConnection) ([]byte, error) {
 	if rc.opts.OptimisticSpinDuration > 0 {
 		// Optimistically spin, waiting for the results.
-		for start := time.Now(); time.Since(start) < rc.opts.OptimisticSpinDuration; {
+		for time.Since(rpc.start) < rc.opts.OptimisticSpinDuration {
 			if atomic.LoadUint32(&rpc.done) > 0 {
+				rc.observe(rpc.balancer, rpc.endpoint, time.Since(rpc.start), rpc.err)
 				return rpc.response, rpc.err
 			}
 		}
@@ -408,6 +523,7 @@ func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []b
 			// Canceled or deadline expired.
 			conn.endCall(rpc)
 
+			deadline, haveDeadline := ctx.Deadline()
 			if !haveDeadline || time.Now().Before(deadline) {
 				// Early cancellation. Tell server about it.
 				if err := writeMessage(conn.c, &conn.wlock, cancelMessage, rpc.id, nil, nil, rc.opts.WriteFlattenLimit); err != nil {
@@ -415,20 +531,123 @@ func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []b
 				}
 			}
 
+			if ctx.Err() == context.DeadlineExceeded {
+				// A call that doesn't finish before its deadline counts
+				// against the health of the endpoint. A canceled call
+				// doesn't.
+				rc.observe(rpc.balancer, rpc.endpoint, time.Since(rpc.start), ctx.Err())
+			}
 			return nil, ctx.Err()
 		}
 	} else {
 		<-rpc.doneSignal
 	}
+	rc.observe(rpc.balancer, rpc.endpoint, time.Since(rpc.start), rpc.err)
 	return rpc.response, rpc.err
 }
 
+// waitAndReport is like wait, but also reports the latency of a successful
+// call to opts.Latency.
+func (rc *reconnectingConnection) waitAndReport(ctx context.Context, rpc *call, conn *clientConnection, opts CallOptions) ([]byte, error) {
+	response, err := rc.wait(ctx, rpc, conn)
+	if err == nil && opts.Latency != nil {
+		opts.Latency(time.Since(rpc.start))
+	}
+	return response, err
+}
+
+// hedge waits for a call sent over conn to finish. If the call doesn't finish
+// within opts.HedgeDelay, hedge sends a duplicate request to a different
+// endpoint and returns the first successful result of the two calls. The
+// other call is canceled, which sends a cancelMessage to its server.
+func (rc *reconnectingConnection) hedge(ctx context.Context, h MethodKey, arg []byte, opts CallOptions, rpc *call, conn *clientConnection) ([]byte, error) {
+	timer := time.NewTimer(opts.HedgeDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-rpc.doneSignal:
+		return rc.waitAndReport(ctx, rpc, conn, opts)
+	case <-ctx.Done():
+		return rc.waitAndReport(ctx, rpc, conn, opts)
+	}
+
+	// Send the duplicate request. If hedging is not allowed, if there is no
+	// other endpoint, or if the request can't be sent, keep waiting for the
+	// original call.
+	if opts.AllowHedge != nil && !opts.AllowHedge() {
+		return rc.waitAndReport(ctx, rpc, conn, opts)
+	}
+	hopts := opts
+	hopts.exclude = rpc.endpoint
+	hedged, hconn, err := rc.send(ctx, h, arg, hopts)
+	if err != nil {
+		return rc.waitAndReport(ctx, rpc, conn, opts)
+	}
+
+	// Wait for the first successful call. Returning cancels the other one.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		response []byte
+		err      error
+		original bool          // is this the result of the original call?
+		latency  time.Duration // latency of the original call so far
+	}
+	results := make(chan result, 2)
+	waitFor := func(c *call, conn *clientConnection) {
+		response, err := rc.wait(ctx, c, conn)
+		results <- result{response, err, c == rpc, time.Since(rpc.start)}
+	}
+	go waitFor(rpc, conn)
+	go waitFor(hedged, hconn)
+
+	first := <-results
+	winner := first
+	if first.err != nil {
+		winner = <-results
+		if winner.err != nil {
+			return first.response, first.err
+		}
+	}
+	if opts.Latency != nil && (winner.original || first.err == nil) {
+		// Report the latency of the original call, unless it failed.
+		opts.Latency(winner.latency)
+	}
+	return winner.response, nil
+}
+
+// Stream starts a streaming RPC over connection c.
+func (rc *reconnectingConnection) Stream(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) (*ClientStream, error) {
+	hdr, err := requestHeader(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	rpc := &call{owner: rc, stream: newStreamState()}
+	rpc.doneSignal = make(chan struct{})
+	conn, err := rc.startCall(ctx, rpc, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeCompressible(conn.c, &conn.wlock, streamRequestMessage, rpc.id, hdr, arg, rc.opts.WriteFlattenLimit, &conn.cmp); err != nil {
+		conn.shutdown("client send stream request", err)
+		conn.endCall(rpc)
+		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+	return &ClientStream{
+		ctx:     ctx,
+		conn:    conn,
+		rpc:     rpc,
+		flatten: rc.opts.WriteFlattenLimit,
+	}, nil
+}
+
 // watchResolver watches for updates to the set of endpoints. When a new set of
 // updates is available, watchResolver passes it to updateEndpoints.
 // REQUIRES: version != nil.
 // REQUIRES: rc.mu is not held.
 func (rc *reconnectingConnection) watchResolver(ctx context.Context, version *Version) {
-	defer rc.resolverDone.Done()
+	defer rc.done.Done()
 
 	for r := retry.Begin(); r.Continue(ctx); {
 		endpoints, newVersion, err := rc.resolver.Resolve(ctx, version)
@@ -451,67 +670,136 @@ func (rc *reconnectingConnection) watchResolver(ctx context.Context, version *Ve
 	}
 }
 
+// observe reports the outcome of a call or ping to the provided endpoint to
+// balancer, if balancer is a HealthObserver.
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) observe(balancer Balancer, endpoint Endpoint, latency time.Duration, err error) {
+	h, ok := balancer.(HealthObserver)
+	if !ok {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	h.Observe(endpoint, latency, err)
+}
+
 // updateEndpoints updates the set of endpoints. Existing connections are
 // retained, and stale connections are closed.
 // REQUIRES: rc.mu is not held.
 func (rc *reconnectingConnection) updateEndpoints(endpoints []Endpoint) error {
 	rc.mu.Lock()
 	defer rc.mu.Unlock()
-
 	if rc.closed {
 		return fmt.Errorf("updateEndpoints on closed Connection")
 	}
 
+	p := rc.pool
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	// Remove fully drained connections since they have been closed already and
 	// cannot be reused.
-	rc.removeDrainedConnections()
+	p.removeDrainedConnections()
 
-	// Retain existing connections.
-	connections := make(map[string]*clientConnection, len(endpoints))
+	// Retain existing connections. Note that connections are only drained by
+	// drainUnused below, so the connections to endpoints in both the old and
+	// new sets are never drained.
+	p.release(rc, rc.endpoints)
+	p.acquire(rc, endpoints)
+
+	// Update our state. The in-flight calls to the endpoints that are still
+	// present are retained.
+	rc.endpoints = endpoints
+	inflight := make(map[string]*atomic.Int64, len(endpoints))
 	for _, endpoint := range endpoints {
 		addr := endpoint.Address()
-		if conn, ok := rc.connections[addr]; ok {
-			connections[addr] = conn
-			delete(rc.connections, addr)
-		} else if conn, ok := rc.draining[addr]; ok {
-			conn.draining = false
-			connections[addr] = conn
-			delete(rc.draining, addr)
+		if n, ok := rc.inflight[addr]; ok {
+			inflight[addr] = n
 		} else {
-			// If we don't have an existing connection, it will be created
-			// on-demand when Call is invoked. We don't have to insert anything
-			// into rc.connections.
+			inflight[addr] = &atomic.Int64{}
 		}
 	}
-
-	// Update our state.
-	rc.endpoints = endpoints
-	for addr, conn := range rc.connections {
-		conn.draining = true
-		rc.draining[addr] = conn
-	}
-	rc.connections = connections
+	rc.inflight = inflight
 	rc.opts.Balancer.Update(endpoints)
 
-	// Close draining connections that don't have any pending requests. If a
-	// draining connection does have pending requests, then the connection will
-	// close itself when it finishes processing all of its requests.
-	rc.removeDrainedConnections()
+	// Drain the connections that are no longer used, and close draining
+	// connections that don't have any pending requests. If a draining
+	// connection does have pending requests, then the connection will close
+	// itself when it finishes processing all of its requests.
+	p.drainUnused()
+	p.removeDrainedConnections()
 
 	// TODO(mwhittaker): Close draining connections after a delay?
 
 	return nil
 }
 
+// acquire records that rc uses the provided endpoints. Draining connections
+// to the endpoints are transitioned out of the draining phase.
+//
+// REQUIRES: p.mu is held.
+func (p *Pool) acquire(rc *reconnectingConnection, endpoints []Endpoint) {
+	for _, endpoint := range endpoints {
+		addr := endpoint.Address()
+		if p.users[addr] == nil {
+			p.users[addr] = map[*reconnectingConnection]Endpoint{}
+		}
+		p.users[addr][rc] = endpoint
+		if conn, ok := p.draining[addr]; ok {
+			conn.mu.Lock()
+			conn.draining = false
+			conn.mu.Unlock()
+			p.connections[addr] = conn
+			delete(p.draining, addr)
+		}
+		// If we don't have an existing connection, it will be created
+		// on-demand when Call is invoked. We don't have to insert anything
+		// into p.connections.
+	}
+}
+
+// release records that rc no longer uses the provided endpoints, previously
+// recorded by acquire.
+//
+// REQUIRES: p.mu is held.
+func (p *Pool) release(rc *reconnectingConnection, endpoints []Endpoint) {
+	for _, endpoint := range endpoints {
+		addr := endpoint.Address()
+		delete(p.users[addr], rc)
+		if len(p.users[addr]) == 0 {
+			delete(p.users, addr)
+		}
+	}
+}
+
+// drainUnused transitions the connections to addresses that are not used by
+// any Connection into the draining phase.
+//
+// REQUIRES: p.mu is held.
+func (p *Pool) drainUnused() {
+	for addr, conn := range p.connections {
+		if len(p.users[addr]) == 0 {
+			conn.mu.Lock()
+			conn.draining = true
+			conn.mu.Unlock()
+			p.draining[addr] = conn
+			delete(p.connections, addr)
+		}
+	}
+}
+
 // removeDrainedConnections closes and removes any fully drained connections
-// from rc.draining.
+// from p.draining.
 //
-// REQUIRES: rc.mu is held.
-func (rc *reconnectingConnection) removeDrainedConnections() {
-	for addr, conn := range rc.draining {
+// REQUIRES: p.mu is held.
+func (p *Pool) removeDrainedConnections() {
+	for addr, conn := range p.draining {
+		conn.mu.Lock()
 		conn.endIfDrained()
-		if conn.ended {
-			delete(rc.draining, addr)
+		ended := conn.ended
+		conn.mu.Unlock()
+		if ended {
+			delete(p.draining, addr)
 		}
 	}
 }
@@ -531,72 +819,242 @@ func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts
 	}
 
 	// Note that it is important to hold rc.mu when calling Pick(), and it's
-	// important that we index into rc.connections with addr while still
-	// holding rc.mu. Otherwise, a Pick() call could operate on a stale set of
-	// endpoints and return an endpoint that does not exist in rc.connections.
+	// important that we fetch the connection to the picked endpoint while
+	// still holding rc.mu. Otherwise, a Pick() call could operate on a stale
+	// set of endpoints and return an endpoint that rc no longer uses, whose
+	// connection may be drained.
 	var balancer = rc.opts.Balancer
 	if opts.Balancer != nil {
 		balancer = opts.Balancer
 		balancer.Update(rc.endpoints)
 	}
+	opts.inflight = rc.inflightTo
 
 	// TODO(mwhittaker): Think about the other places where we can perform
 	// automatic retries. We need to be careful about non-idempotent
 	// operations.
 	var connectErr error
 	for i := 0; i < maxReconnectTries; i++ {
-		endpoint, err := balancer.Pick(opts)
+		endpoint, err := pick(balancer, opts)
 		if err != nil {
 			return nil, err
 		}
-		addr := endpoint.Address()
 
-		if conn, ok := rc.connections[addr]; !ok || conn.ended {
-			c, err := rc.reconnect(ctx, endpoint)
-			if err != nil {
-				connectErr = err
-				continue
+		c, err := rc.pool.get(ctx, rc.opts, endpoint)
+		if err != nil {
+			if h, ok := balancer.(HealthObserver); ok {
+				h.Observe(endpoint, 0, err)
 			}
-			rc.connections[addr] = c
+			connectErr = err
+			continue
 		}
 
-		c := rc.connections[addr]
-		c.lastID++
-		rpc.id = c.lastID
-		c.calls[rpc.id] = rpc
+		rpc.balancer = balancer
+		rpc.endpoint = endpoint
+		rpc.inflight = rc.inflight[endpoint.Address()]
+		rpc.inflight.Add(1)
+		if !c.register(rpc) {
+			// The connection ended after we fetched it.
+			rpc.inflight.Add(-1)
+			connectErr = fmt.Errorf("%w: connection ended", CommunicationError)
+			continue
+		}
 		return c, nil
 	}
 	return nil, connectErr
 }
 
-// reconnect establishes (or re-establishes) the network connection to the server.
+// inflightTo returns the number of in-flight calls issued by rc to the
+// endpoint with the provided address.
+//
 // REQUIRES: rc.mu is held.
-func (rc *reconnectingConnection) reconnect(ctx context.Context, endpoint Endpoint) (*clientConnection, error) {
+func (rc *reconnectingConnection) inflightTo(addr string) int {
+	if n, ok := rc.inflight[addr]; ok {
+		return int(n.Load())
+	}
+	return 0
+}
+
+// pick picks an endpoint using balancer. If opts.exclude is set, pick avoids
+// picking it, and fails if balancer keeps picking it.
+func pick(balancer Balancer, opts CallOptions) (Endpoint, error) {
+	for i := 0; ; i++ {
+		endpoint, err := balancer.Pick(opts)
+		if err != nil || opts.exclude == nil || endpoint.Address() != opts.exclude.Address() {
+			return endpoint, err
+		}
+		if i+1 >= maxPickTries {
+			return nil, fmt.Errorf("no endpoint other than %v", opts.exclude.Address())
+		}
+	}
+}
+
+// get returns the pooled network connection to the provided endpoint, dialing
+// it if needed. A network connection dialed on behalf of one endpoint is only
+// returned for another endpoint with the same address after the other
+// endpoint verifies the server too (see verifier).
+//
+// REQUIRES: p.mu is not held.
+func (p *Pool) get(ctx context.Context, opts ClientOptions, endpoint Endpoint) (*clientConnection, error) {
+	addr := endpoint.Address()
+	p.mu.Lock()
+	conn, ok := p.connections[addr]
+	p.mu.Unlock()
+	if ok && !conn.isEnded() {
+		return conn, conn.verify(endpoint)
+	}
+
+	// Dial without holding p.mu, so that the other Connections can keep
+	// using the pool in the meantime.
+	dialed, err := dial(ctx, opts, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	conn, ok = p.connections[addr]
+	if !ok || conn.isEnded() {
+		p.connections[addr] = dialed
+		p.mu.Unlock()
+		go dialed.readResponses()
+		if opts.HealthCheckInterval > 0 {
+			go p.healthCheck(dialed, opts)
+		}
+		return dialed, nil
+	}
+	p.mu.Unlock()
+
+	// Another Connection dialed addr concurrently. Use its network connection.
+	dialed.c.Close()
+	return conn, conn.verify(endpoint)
+}
+
+// healthCheck pings the server of c every opts.HealthCheckInterval until c
+// ends, and reports the outcomes to the Connections that use c. Pings that
+// don't complete within the interval fail.
+//
+// REQUIRES: opts.HealthCheckInterval > 0.
+func (p *Pool) healthCheck(c *clientConnection, opts ClientOptions) {
+	ticker := time.NewTicker(opts.HealthCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if c.isEnded() {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), opts.HealthCheckInterval)
+		start := time.Now()
+		err := c.ping(ctx, opts.WriteFlattenLimit)
+		cancel()
+		p.observe(c, time.Since(start), err)
+	}
+}
+
+// observe reports the outcome of a ping over c to the Balancers of the
+// Connections that use c.
+//
+// REQUIRES: p.mu is not held.
+func (p *Pool) observe(c *clientConnection, latency time.Duration, err error) {
+	addr := c.endpoint.Address()
+	p.mu.Lock()
+	if p.connections[addr] != c {
+		// c is draining or was replaced. Nobody uses it.
+		p.mu.Unlock()
+		return
+	}
+	users := maps.Clone(p.users[addr])
+	p.mu.Unlock()
+
+	for rc, endpoint := range users {
+		rc.observe(rc.opts.Balancer, endpoint, latency, err)
+	}
+}
+
+// dial establishes a new network connection to the server.
+func dial(ctx context.Context, opts ClientOptions, endpoint Endpoint) (*clientConnection, error) {
 	nc, err := endpoint.Dial(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
 	}
 	conn := &clientConnection{
-		logger:   rc.opts.Logger,
+		logger:   opts.Logger,
 		endpoint: endpoint,
 		c:        nc,
 		cbuf:     bufio.NewReader(nc),
-		mu:       &rc.mu,
 		version:  initialVersion, // Updated when we hear from server
 		calls:    map[uint64]*call{},
+		pings:    map[uint64]chan error{},
 		lastID:   0,
+		verified: map[any]bool{},
+	}
+	if v, ok := endpoint.(verifier); ok {
+		// The endpoint verified the server when dialing it.
+		conn.verified[v.verifyKey()] = true
 	}
+	conn.cmp.threshold = opts.CompressThreshold
 	if err := writeVersion(conn.c, &conn.wlock); err != nil {
+		nc.Close()
 		return nil, fmt.Errorf("%w: client send version: %s", CommunicationError, err)
 	}
-	go conn.readResponses()
 	return conn, nil
 }
 
+// verify checks that the provided endpoint accepts the server on the other
+// end of c, if the endpoint is a verifier. Successful verifications are
+// cached.
+//
+// REQUIRES: c.mu is not held.
+func (c *clientConnection) verify(endpoint Endpoint) error {
+	v, ok := endpoint.(verifier)
+	if !ok {
+		return nil
+	}
+	key := v.verifyKey()
+	c.mu.Lock()
+	verified := c.verified[key]
+	c.mu.Unlock()
+	if verified {
+		return nil
+	}
+
+	// Verify without holding c.mu, since verification may be slow.
+	if err := v.verify(c.c); err != nil {
+		return fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+	c.mu.Lock()
+	c.verified[key] = true
+	c.mu.Unlock()
+	return nil
+}
+
+// register registers rpc as an in-progress call on c. It returns false if c
+// has ended.
+//
+// REQUIRES: c.mu is not held.
+func (c *clientConnection) register(rpc *call) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ended {
+		return false
+	}
+	c.lastID++
+	rpc.id = c.lastID
+	c.calls[rpc.id] = rpc
+	return true
+}
+
+// isEnded returns whether c has ended.
+//
+// REQUIRES: c.mu is not held.
+func (c *clientConnection) isEnded() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ended
+}
+
 func (c *clientConnection) endCall(rpc *call) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.calls, rpc.id)
+	c.removeCall(rpc)
 	c.endIfDrained()
 }
 
@@ -605,22 +1063,68 @@ func (c *clientConnection) findAndEndCall(id uint64) *call {
 	defer c.mu.Unlock()
 	rpc := c.calls[id]
 	if rpc != nil {
-		delete(c.calls, id)
+		c.removeCall(rpc)
 		c.endIfDrained()
 	}
 	return rpc
 }
 
+// removeCall removes rpc from the in-progress calls of c, if present, and
+// returns whether it was present.
+//
+// REQUIRES: c.mu is held.
+func (c *clientConnection) removeCall(rpc *call) bool {
+	if c.calls[rpc.id] != rpc {
+		return false
+	}
+	delete(c.calls, rpc.id)
+	rpc.inflight.Add(-1)
+	return true
+}
+
+// ping sends a ping message to the server and waits for the server to reply.
+// ping returns an error if the reply doesn't arrive before ctx is done. Pings
+// are not calls: they don't keep a draining connection open.
+//
+// REQUIRES: c.mu is not held.
+func (c *clientConnection) ping(ctx context.Context, flattenLimit int) error {
+	pong := make(chan error, 1)
+	c.mu.Lock()
+	if c.ended {
+		c.mu.Unlock()
+		return fmt.Errorf("%w: connection ended", CommunicationError)
+	}
+	c.lastID++
+	id := c.lastID
+	c.pings[id] = pong
+	c.mu.Unlock()
+
+	if err := writeMessage(c.c, &c.wlock, pingMessage, id, nil, nil, flattenLimit); err != nil {
+		c.shutdown("client send ping", err)
+		return fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+
+	select {
+	case err := <-pong:
+		return err
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.pings, id)
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
 // endIfDrained closes c if it is a fully drained connection.
 //
 // REQUIRES: c.mu is held.
 func (c *clientConnection) endIfDrained() {
 	// Note that endIfDrained closes c, but it doesn't remove c from
-	// reconnectingConnection.draining. rc.updateEndpoints will remove drained
-	// connections from rc.draining. This approach leaves some drained
-	// connections around, but it simplifies the code. Specifically, a
-	// reconnectingConnection may modify a child clientConnection, but a
-	// clientConnection never modifies its parent reconnectingConnection.
+	// Pool.draining. Pool.removeDrainedConnections will remove drained
+	// connections from p.draining. This approach leaves some drained
+	// connections around, but it simplifies the code. Specifically, a Pool
+	// may modify a child clientConnection, but a clientConnection never
+	// modifies its parent Pool.
 	if c.draining && len(c.calls) == 0 {
 		c.endCalls(fmt.Errorf("connection drained"))
 	}
@@ -641,16 +1145,39 @@ func (c *clientConnection) shutdown(details string, err error) {
 	c.endCalls(fmt.Errorf("%w: %s: %s", CommunicationError, details, err))
 }
 
-// endCalls closes the network connection and ends any in-progress calls.
+// endCalls closes the network connection and ends any in-progress calls and
+// pings.
 // REQUIRES: c.mu is held.
 func (c *clientConnection) endCalls(err error) {
 	c.c.Close()
 	c.ended = true
-	for id, active := range c.calls {
+	for _, active := range c.calls {
+		c.removeCall(active)
+		active.err = err
+		atomic.StoreUint32(&active.done, 1)
+		close(active.doneSignal)
+	}
+	for id, pong := range c.pings {
+		pong <- err
+		delete(c.pings, id)
+	}
+}
+
+// endCallsOf ends the in-progress calls issued by the provided Connection
+// with the provided error. The network connection is left open for the calls
+// issued by other Connections.
+// REQUIRES: c.mu is not held.
+func (c *clientConnection) endCallsOf(rc *reconnectingConnection, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, active := range c.calls {
+		if active.owner != rc {
+			continue
+		}
+		c.removeCall(active)
 		active.err = err
 		atomic.StoreUint32(&active.done, 1)
 		close(active.doneSignal)
-		delete(c.calls, id)
 	}
 }
 
@@ -665,7 +1192,7 @@ func (c *clientConnection) readResponses() {
 
 		switch mt {
 		case versionMessage:
-			v, err := getVersion(id, msg)
+			v, codecs, err := getVersion(id, msg)
 			if err != nil {
 				c.shutdown("client read", err)
 				return
@@ -673,6 +1200,7 @@ func (c *clientConnection) readResponses() {
 			c.mu.Lock()
 			c.version = v
 			c.mu.Unlock()
+			c.cmp.setPeerCodecs(codecs)
 		case responseMessage, responseError:
 			rpc := c.findAndEndCall(id)
 			if rpc == nil {
@@ -689,6 +1217,30 @@ func (c *clientConnection) readResponses() {
 			}
 			atomic.StoreUint32(&rpc.done, 1)
 			close(rpc.doneSignal)
+		case pongMessage:
+			c.mu.Lock()
+			pong, ok := c.pings[id]
+			delete(c.pings, id)
+			c.mu.Unlock()
+			if ok {
+				pong <- nil
+			}
+		case streamDataMessage, streamCreditMessage:
+			c.mu.Lock()
+			rpc := c.calls[id]
+			c.mu.Unlock()
+			if rpc == nil || rpc.stream == nil {
+				continue // May have ended
+			}
+			if mt == streamDataMessage {
+				err = rpc.stream.deliver(msg)
+			} else {
+				err = rpc.stream.addCredits(msg)
+			}
+			if err != nil {
+				c.shutdown("client read", err)
+				return
+			}
 		default:
 			c.shutdown("client read", fmt.Errorf("invalid response %d", mt))
 			return
@@ -708,7 +1260,7 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 
 		switch mt {
 		case versionMessage:
-			v, err := getVersion(id, msg)
+			v, codecs, err := getVersion(id, msg)
 			if err != nil {
 				c.shutdown("server read version", err)
 				onDone()
@@ -717,6 +1269,7 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 			c.mu.Lock()
 			c.version = v
 			c.mu.Unlock()
+			c.cmp.setPeerCodecs(codecs)
 
 			// Respond with my version.
 			if err := writeVersion(c.c, &c.wlock); err != nil {
@@ -731,17 +1284,53 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 				t := time.AfterFunc(c.opts.InlineHandlerDuration, func() {
 					c.readRequests(ctx, hmap, onDone)
 				})
-				c.runHandler(hmap, id, msg)
+				c.runHandler(hmap, id, msg, nil)
 				if !t.Stop() {
 					// Another goroutine is reading incoming requests: bail out.
 					return
 				}
 			} else {
 				// Run the handler in a separate goroutine.
-				go c.runHandler(hmap, id, msg)
+				go c.runHandler(hmap, id, msg, nil)
+			}
+		case streamRequestMessage:
+			// Register the stream before reading any more messages, since
+			// the client may send stream data right away. Streaming handlers
+			// are never inlined, since they may block waiting for data.
+			s := newStreamState()
+			c.mu.Lock()
+			c.streams[id] = s
+			c.mu.Unlock()
+			go c.runHandler(hmap, id, msg, s)
+		case streamDataMessage, streamEndMessage, streamCreditMessage:
+			c.mu.Lock()
+			s := c.streams[id]
+			c.mu.Unlock()
+			if s == nil {
+				continue // May have ended
+			}
+			switch mt {
+			case streamDataMessage:
+				err = s.deliver(msg)
+			case streamEndMessage:
+				s.endRecv()
+			case streamCreditMessage:
+				err = s.addCredits(msg)
+			}
+			if err != nil {
+				c.shutdown("server read", err)
+				onDone()
+				return
 			}
 		case cancelMessage:
 			c.endRequest(id)
+		case pingMessage:
+			// Reply right away, without running any handler.
+			if err := writeMessage(c.c, &c.wlock, pongMessage, id, nil, nil, c.opts.WriteFlattenLimit); err != nil {
+				c.shutdown("server send pong", err)
+				onDone()
+				return
+			}
 		default:
 			c.shutdown("server read", fmt.Errorf("invalid request type %d", mt))
 			onDone()
@@ -754,7 +1343,12 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 
 // runHandler runs an application specified RPC handler at the server side.
 // The result (or error) from the handler is sent back to the client over c.
-func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
+// If s is not nil, the call is a streaming call and s holds its stream state.
+func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte, s *streamState) {
+	if s != nil {
+		defer c.endStream(id)
+	}
+
 	// Extract request header from front of payload.
 	if len(msg) < msgHeaderSize {
 		c.shutdown("server handler", fmt.Errorf("missing request header"))
@@ -782,6 +1376,21 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 		defer span.End()
 	}
 
+	// Add context metadata from the header to the context.
+	mdLen := binary.LittleEndian.Uint32(msg[24+traceHeaderLen:])
+	if uint64(len(msg)-msgHeaderSize) < uint64(mdLen) {
+		c.shutdown("server handler", fmt.Errorf("truncated request metadata"))
+		return
+	}
+	md, err := readContextMetadata(msg[msgHeaderSize : msgHeaderSize+int(mdLen)])
+	if err != nil {
+		c.shutdown("server handler", err)
+		return
+	}
+	if md != nil {
+		ctx = metadata.NewContext(ctx, md)
+	}
+
 	// Add deadline information from the header to the context.
 	micros := binary.LittleEndian.Uint64(msg[16:])
 	var cancelFunc func()
@@ -796,12 +1405,35 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 			cancelFunc()
 		}
 	}()
+	if s != nil {
+		cancel := cancelFunc
+		go func() {
+			select {
+			case <-s.canceled:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
 
 	// Call the handler passing it the payload.
-	payload := msg[msgHeaderSize:]
-	var err error
+	payload := msg[msgHeaderSize+int(mdLen):]
 	var result []byte
 	fn, ok := hmap.handlers[hkey]
+	if s != nil {
+		var sfn StreamHandler
+		sfn, ok = hmap.streams[hkey]
+		fn = func(ctx context.Context, args []byte) ([]byte, error) {
+			stream := &ServerStream{
+				ctx:     ctx,
+				conn:    c,
+				id:      id,
+				state:   s,
+				flatten: c.opts.WriteFlattenLimit,
+			}
+			return sfn(ctx, args, stream)
+		}
+	}
 	if !ok {
 		err = fmt.Errorf("internal error: unknown function")
 	} else {
@@ -822,7 +1454,7 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 		span.SetStatus(codes.Error, err.Error())
 	}
 
-	if err := writeMessage(c.c, &c.wlock, mt, id, nil, result, c.opts.WriteFlattenLimit); err != nil {
+	if err := writeCompressible(c.c, &c.wlock, mt, id, nil, result, c.opts.WriteFlattenLimit, &c.cmp); err != nil {
 		c.shutdown("server write "+hmap.names[hkey], err)
 	}
 }
@@ -837,6 +1469,12 @@ func (c *serverConnection) startRequest(id uint64, cancelFunc func()) error {
 	return nil
 }
 
+func (c *serverConnection) endStream(id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.streams, id)
+}
+
 func (c *serverConnection) endRequest(id uint64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -844,6 +1482,9 @@ func (c *serverConnection) endRequest(id uint64) {
 		delete(c.cancelFuncs, id)
 		cancelFunc()
 	}
+	if s, ok := c.streams[id]; ok {
+		s.cancel()
+	}
 }
 
 // shutdown processes an error detected while operating on a connection.
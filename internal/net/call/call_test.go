@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"math/rand"
 	"net"
@@ -36,7 +37,9 @@ import (
 	"github.com/ServiceWeaver/weaver/internal/cond"
 	"github.com/ServiceWeaver/weaver/internal/net/call"
 	"github.com/ServiceWeaver/weaver/internal/traceio"
+	"github.com/ServiceWeaver/weaver/metadata"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/google/go-cmp/cmp"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -50,6 +53,9 @@ var (
 	cancelWaitKey = call.MakeMethodKey("", "cancelwait")
 	sleepKey      = call.MakeMethodKey("", "sleep")
 	traceKey      = call.MakeMethodKey("", "trace")
+	streamEchoKey = call.MakeMethodKey("", "streamecho")
+	streamGenKey  = call.MakeMethodKey("", "streamgen")
+	streamWaitKey = call.MakeMethodKey("", "streamwait")
 	handlers      = makeHandlerMap()
 	tlsConfig     = makeTLSConfig()
 
@@ -69,6 +75,9 @@ func makeHandlerMap() *call.HandlerMap {
 	m.Set("", "error", errorHandler)
 	m.Set("", "cancelwait", cancelWaitHandler)
 	m.Set("", "sleep", sleepHandler)
+	m.SetStream("", "streamecho", streamEchoHandler)
+	m.SetStream("", "streamgen", streamGenHandler)
+	m.SetStream("", "streamwait", streamWaitHandler)
 	return m
 }
 
@@ -208,6 +217,54 @@ func cancelWaitHandler(ctx context.Context, _ []byte) ([]byte, error) {
 	}
 }
 
+// streamEchoHandler sends back every data message it receives. It returns the
+// number of received messages.
+func streamEchoHandler(_ context.Context, _ []byte, stream *call.ServerStream) ([]byte, error) {
+	n := 0
+	for {
+		data, err := stream.Recv()
+		if err == io.EOF {
+			return []byte(strconv.Itoa(n)), nil
+		} else if err != nil {
+			return nil, err
+		}
+		n++
+		if err := stream.Send(data); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// streamGenHandler sends the numbers [0, n) as data messages, where n is
+// parsed from arg.
+func streamGenHandler(_ context.Context, arg []byte, stream *call.ServerStream) ([]byte, error) {
+	n, err := strconv.Atoi(string(arg))
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		if err := stream.Send([]byte(strconv.Itoa(i))); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+var streamCancelCount int64
+
+// streamWaitHandler waits for the call to be canceled.
+func streamWaitHandler(ctx context.Context, _ []byte, stream *call.ServerStream) ([]byte, error) {
+	t := time.NewTimer(testTimeout)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil, fmt.Errorf("streamWait handler timed out")
+	case <-ctx.Done():
+		atomic.AddInt64(&streamCancelCount, 1)
+		return nil, ctx.Err()
+	}
+}
+
 // sleepHandler sleeps for the provided amount of time. arg must be parseable
 // by time.ParseDuration.
 func sleepHandler(ctx context.Context, arg []byte) ([]byte, error) {
@@ -423,6 +480,90 @@ func testCall(t *testing.T, client call.Connection) {
 	}
 }
 
+func testStreamEcho(t *testing.T, client call.Connection) {
+	ctx := context.Background()
+	stream, err := client.Stream(ctx, streamEchoKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	// Send more messages than fit in the flow control window, concurrently
+	// with receiving them.
+	const n = 100
+	errs := make(chan error, 1)
+	go func() {
+		for i := 0; i < n; i++ {
+			if err := stream.Send([]byte(strconv.Itoa(i))); err != nil {
+				errs <- err
+				return
+			}
+		}
+		errs <- stream.CloseSend()
+	}()
+
+	for i := 0; i < n; i++ {
+		data, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv %d: %v", i, err)
+		}
+		if got, want := string(data), strconv.Itoa(i); got != want {
+			t.Fatalf("Recv %d: got %q, want %q", i, got, want)
+		}
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Recv: got %v, want io.EOF", err)
+	}
+	result, err := stream.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(result), strconv.Itoa(n); got != want {
+		t.Fatalf("Result: got %q, want %q", got, want)
+	}
+}
+
+func testStreamFlowControl(t *testing.T, client call.Connection) {
+	ctx := context.Background()
+	const n = 100
+	stream, err := client.Stream(ctx, streamGenKey, []byte(strconv.Itoa(n)), call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	// The server can't finish until we consume its messages.
+	time.Sleep(shortDelay)
+	for i := 0; i < n; i++ {
+		data, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv %d: %v", i, err)
+		}
+		if got, want := string(data), strconv.Itoa(i); got != want {
+			t.Fatalf("Recv %d: got %q, want %q", i, got, want)
+		}
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Recv: got %v, want io.EOF", err)
+	}
+}
+
+func testStreamClose(t *testing.T, client call.Connection) {
+	atomic.StoreInt64(&streamCancelCount, 0)
+	stream, err := client.Stream(context.Background(), streamWaitKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Close()
+	if _, err := stream.Recv(); err == nil || err == io.EOF {
+		t.Fatalf("Recv after Close: got %v, want error", err)
+	}
+	waitUntil(t, func() bool { return atomic.LoadInt64(&streamCancelCount) == 1 })
+}
+
 func testConcurrentCalls(t *testing.T, client call.Connection) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortDelay)
 	defer cancel()
@@ -543,6 +684,9 @@ func TestSingleTCPServer(t *testing.T) {
 		{"TestConcurrentCalls", testConcurrentCalls},
 		{"TestError", testError},
 		{"TestDeadlineHandling", testDeadlineHandling},
+		{"TestStreamEcho", testStreamEcho},
+		{"TestStreamFlowControl", testStreamFlowControl},
+		{"TestStreamClose", testStreamClose},
 		// Note that testClose has to come last because once the connection is
 		// closed, all other operations will fail.
 		{"TestClose", testClose},
@@ -597,6 +741,51 @@ func TestTracePropagation(t *testing.T) {
 	}
 }
 
+// TestMetadataPropagation tests that context metadata is propagated across
+// an RPC.
+func TestMetadataPropagation(t *testing.T) {
+	for _, md := range []map[string]string{
+		nil,
+		{},
+		{"tenant": "acme"},
+		{"tenant": "acme", "principal": "alice", "": "", "empty": ""},
+	} {
+		t.Run(fmt.Sprint(md), func(t *testing.T) {
+			h := &call.HandlerMap{}
+			h.Set("", "metadata", func(ctx context.Context, args []byte) ([]byte, error) {
+				got, _ := metadata.FromContext(ctx)
+				if len(md) == 0 && len(got) == 0 {
+					return args, nil
+				}
+				if diff := cmp.Diff(md, got); diff != "" {
+					return nil, fmt.Errorf("metadata (-want +got):\n%s", diff)
+				}
+				return args, nil
+			})
+			ep := pipeEndpoint{t: t, handlers: h}
+			opts := call.ClientOptions{Logger: logging.NewTestLogger(t)}
+			client, err := call.Connect(context.Background(), call.NewConstantResolver(&ep), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			ctx := context.Background()
+			if md != nil {
+				ctx = metadata.NewContext(ctx, md)
+			}
+			key := call.MakeMethodKey("", "metadata")
+			result, err := client.Call(ctx, key, []byte("hello"), call.CallOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(result), "hello"; got != want {
+				t.Fatalf("result: got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 // TestMultipleEndpoints tests that RPC calls succeed when the resolver returns
 // a constant set of multiple endpoints.
 func TestMultipleEndpoints(t *testing.T) {
@@ -749,6 +938,101 @@ func TestCallOptionsBalancer(t *testing.T) {
 	}
 }
 
+// TestLeastLoadedBalancer tests that a least-loaded balancer avoids an
+// endpoint with an in-flight call.
+func TestLeastLoadedBalancer(t *testing.T) {
+	ctx := context.Background()
+	resolver := call.NewConstantResolver(server(t, "1"), server(t, "2"))
+	opts := call.ClientOptions{
+		Balancer: call.LeastLoaded(),
+		Logger:   logging.NewTestLogger(t),
+	}
+	client, err := call.Connect(ctx, resolver, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	// Block one of the endpoints with a call that doesn't return until it is
+	// canceled.
+	blockCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.Call(blockCtx, cancelWaitKey, []byte{}, call.CallOptions{})
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Once the blocking call is in flight, all calls go to the other
+	// endpoint.
+	waitUntil(t, func() bool {
+		names := map[string]bool{}
+		for i := 0; i < 20; i++ {
+			result, err := client.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			names[string(result)] = true
+		}
+		return len(names) == 1
+	})
+}
+
+// TestLeastLoadedBalancerSharedPool tests that a least-loaded balancer only
+// counts the in-flight calls of its own client, not those of other clients
+// that share the same pool.
+func TestLeastLoadedBalancerSharedPool(t *testing.T) {
+	ctx := context.Background()
+	endpoints := []call.Endpoint{server(t, "1"), server(t, "2")}
+	pool := call.NewPool()
+	connect := func(endpoints ...call.Endpoint) call.Connection {
+		opts := call.ClientOptions{
+			Balancer: call.LeastLoaded(),
+			Logger:   logging.NewTestLogger(t),
+			Pool:     pool,
+		}
+		client, err := call.Connect(ctx, call.NewConstantResolver(endpoints...), opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Cleanup(client.Close)
+		return client
+	}
+	blocked, free := connect(endpoints[0]), connect(endpoints...)
+
+	// Block the first endpoint with calls from one client that don't return
+	// until they are canceled.
+	blockCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			blocked.Call(blockCtx, cancelWaitKey, []byte{}, call.CallOptions{})
+		}()
+	}
+
+	// The calls of the other client still go to both endpoints.
+	waitUntil(t, func() bool {
+		names := map[string]bool{}
+		for i := 0; i < 20; i++ {
+			result, err := free.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			names[string(result)] = true
+		}
+		return len(names) == 2
+	})
+}
+
 // TestNoEndpointsConstant tests that it is an error to call Connect with a
 // constant resolver that returns no endpoints.
 func TestNoEndpointsConstant(t *testing.T) {
@@ -1092,6 +1376,105 @@ func TestRefreshDraining(t *testing.T) {
 	}
 }
 
+// TestSharedPool tests that clients that use the same Pool share a single
+// network connection to every endpoint, and that the network connection stays
+// open until all of the clients stop using it.
+func TestSharedPool(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	// Construct the network. The endpoint can only be dialed once.
+	c, s := pipe(t)
+	sopts := call.ServerOptions{Logger: logging.NewTestLogger(t)}
+	call.ServeOn(ctx, s, handlersFor("1"), sopts)
+	m := &closeMock{connWrapper: connWrapper{c}}
+	server1 := &connsEndpoint{name: "1", conns: []net.Conn{m}}
+
+	// Construct two clients that share a pool.
+	copts := call.ClientOptions{Logger: logging.NewTestLogger(t), Pool: call.NewPool()}
+	client1, err := call.Connect(ctx, newDynamicResolver(server1), copts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client1.Close()
+	client2, err := call.Connect(ctx, newDynamicResolver(server1), copts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client2.Close()
+
+	// Make calls on both clients. If the clients didn't share a connection,
+	// the second dial would fail.
+	for _, client := range []call.Connection{client1, client2} {
+		if _, err := client.Call(ctx, echoKey, []byte{}, call.CallOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Closing one client should leave the connection open for the other.
+	client1.Close()
+	if m.Closed() {
+		t.Fatal("shared connection closed while still in use")
+	}
+	if _, err := client2.Call(ctx, echoKey, []byte{}, call.CallOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Closing the last client should close the connection.
+	client2.Close()
+	if !m.Closed() {
+		t.Fatal("unused connection not closed")
+	}
+}
+
+// TestSharedPoolVerifiesServer tests that a network connection dialed by one
+// client is only reused by another client that shares the pool after the other
+// client verifies the server too.
+func TestSharedPoolVerifiesServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	// Start an MTLS server.
+	lis, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sopts := call.ServerOptions{Logger: logging.NewTestLogger(t)}
+	go call.Serve(ctx, testListener{Listener: lis, tlsConfig: tlsConfig}, sopts)
+	addr := call.TCP(lis.Addr().String())
+
+	// Construct two clients that share a pool. The second client rejects the
+	// server.
+	rejecting := tlsConfig.Clone()
+	rejecting.VerifyPeerCertificate = func([][]byte, [][]*x509.Certificate) error {
+		return fmt.Errorf("untrusted server")
+	}
+	copts := call.ClientOptions{Logger: logging.NewTestLogger(t), Pool: call.NewPool()}
+	trusting, err := call.Connect(ctx, call.NewConstantResolver(call.MTLS(tlsConfig, addr)), copts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer trusting.Close()
+	distrusting, err := call.Connect(ctx, call.NewConstantResolver(call.MTLS(rejecting, addr)), copts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer distrusting.Close()
+
+	// The first client dials the server. The second client must not reuse
+	// the network connection.
+	if _, err := trusting.Call(ctx, echoKey, []byte{}, call.CallOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = distrusting.Call(ctx, echoKey, []byte{}, call.CallOptions{})
+	if err == nil || !strings.Contains(err.Error(), "untrusted server") {
+		t.Fatalf("got error %v, want untrusted server", err)
+	}
+	if _, err := trusting.Call(ctx, echoKey, []byte{}, call.CallOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestCommunicationErrors(t *testing.T) {
 	for name, maker := range resolverMakers {
 		t.Run(name, func(t *testing.T) {
@@ -1374,6 +1757,283 @@ func TestResolverError(t *testing.T) {
 	}
 }
 
+// TestHedging tests that a hedged call that is slow on one endpoint returns
+// the result of a duplicate call sent to another endpoint, and that the slow
+// call is canceled.
+func TestHedging(t *testing.T) {
+	ctx := context.Background()
+	var canceled atomic.Int64
+	slow := makeHandlerMap()
+	slow.Set("", "who", func(ctx context.Context, _ []byte) ([]byte, error) {
+		select {
+		case <-ctx.Done():
+			canceled.Add(1)
+			return nil, ctx.Err()
+		case <-time.After(testTimeout):
+			return []byte("slow"), nil
+		}
+	})
+	resolver := call.NewConstantResolver(
+		&pipeEndpoint{name: "slow", handlers: slow, t: t},
+		server(t, "fast"),
+	)
+	opts := call.ClientOptions{Logger: logging.NewTestLogger(t)}
+	client, err := call.Connect(ctx, resolver, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Every call picks two endpoints, so round-robin sends every call to the
+	// slow endpoint first. The reported latency is the latency of the
+	// original call to the slow endpoint, not that of the hedged call.
+	const n = 4
+	const delay = 10 * time.Millisecond
+	for i := 0; i < n; i++ {
+		var latency time.Duration
+		opts := call.CallOptions{
+			HedgeDelay: delay,
+			Latency:    func(l time.Duration) { latency = l },
+		}
+		result, err := client.Call(ctx, whoKey, []byte{}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(result), "fast"; got != want {
+			t.Fatalf("bad result: got %q, want %q", got, want)
+		}
+		if latency < delay {
+			t.Fatalf("bad latency: got %v, want >= %v", latency, delay)
+		}
+	}
+	waitUntil(t, func() bool { return canceled.Load() == n })
+}
+
+// TestHedgingNotAllowed tests that a hedged call doesn't send a duplicate
+// request if AllowHedge returns false.
+func TestHedgingNotAllowed(t *testing.T) {
+	ctx := context.Background()
+	slow := makeHandlerMap()
+	slow.Set("", "who", func(context.Context, []byte) ([]byte, error) {
+		time.Sleep(shortDelay)
+		return []byte("slow"), nil
+	})
+	resolver := call.NewConstantResolver(
+		&pipeEndpoint{name: "slow", handlers: slow, t: t},
+		server(t, "fast"),
+	)
+	client, err := call.Connect(ctx, resolver, call.ClientOptions{Logger: logging.NewTestLogger(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	opts := call.CallOptions{
+		HedgeDelay: time.Millisecond,
+		AllowHedge: func() bool { return false },
+	}
+	result, err := client.Call(ctx, whoKey, []byte{}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(result), "slow"; got != want {
+		t.Fatalf("bad result: got %q, want %q", got, want)
+	}
+}
+
+// TestHedgingSingleEndpoint tests that a hedged call with a single endpoint
+// waits for the original call.
+func TestHedgingSingleEndpoint(t *testing.T) {
+	ctx := context.Background()
+	client := getClientConn(t, "tcp", server(t, "1"), resolverMakers["Constant"])
+	arg := []byte(shortDelay.String())
+	opts := call.CallOptions{HedgeDelay: time.Millisecond}
+	if _, err := client.Call(ctx, sleepKey, arg, opts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestHealthCheck tests that a client with a health check interval pings the
+// endpoints it is connected to and reports the outcomes to its balancer.
+func TestHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	balancer := &observingBalancer{Balancer: call.RoundRobin()}
+	opts := call.ClientOptions{
+		Balancer:            balancer,
+		Logger:              logging.NewTestLogger(t),
+		HealthCheckInterval: 10 * time.Millisecond,
+	}
+	client, err := call.Connect(ctx, call.NewConstantResolver(server(t, "1")), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Establish a connection, and wait for some pings.
+	if _, err := client.Call(ctx, echoKey, []byte{}, call.CallOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitUntil(t, func() bool { return balancer.count(nil) >= 5 })
+	if n := balancer.count(errFailed); n > 0 {
+		t.Fatalf("%d failed health checks", n)
+	}
+}
+
+// TestSharedHealthCheck tests that a network connection shared by clients is
+// pinged once per health check interval, and that every ping is reported to
+// the balancers of all of the clients.
+func TestSharedHealthCheck(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	c, s := pipe(t)
+	sopts := call.ServerOptions{Logger: logging.NewTestLogger(t)}
+	call.ServeOn(ctx, s, handlersFor("1"), sopts)
+	counter := &pingCounter{connWrapper: connWrapper{c}}
+	server1 := &connsEndpoint{name: "1", conns: []net.Conn{counter}}
+
+	pool := call.NewPool()
+	var balancers []*observingBalancer
+	for i := 0; i < 2; i++ {
+		balancer := &observingBalancer{Balancer: call.RoundRobin()}
+		balancers = append(balancers, balancer)
+		opts := call.ClientOptions{
+			Balancer:            balancer,
+			Logger:              logging.NewTestLogger(t),
+			Pool:                pool,
+			HealthCheckInterval: 10 * time.Millisecond,
+		}
+		client, err := call.Connect(ctx, newDynamicResolver(server1), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+		if _, err := client.Call(ctx, echoKey, []byte{}, call.CallOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	waitUntil(t, func() bool {
+		return balancers[0].count(nil) >= 10 && balancers[1].count(nil) >= 10
+	})
+	// Every ping is reported to both balancers, along with one call each.
+	if got, max := counter.pings(), balancers[0].count(nil)+1; got > max {
+		t.Fatalf("got %d pings, want at most %d", got, max)
+	}
+}
+
+// TestEjectUnresponsive tests that calls avoid an endpoint that doesn't
+// respond to calls.
+func TestEjectUnresponsive(t *testing.T) {
+	ctx := context.Background()
+	wedged := makeHandlerMap()
+	wedged.Set("", "who", func(ctx context.Context, _ []byte) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	resolver := call.NewConstantResolver(
+		server(t, "1"),
+		&pipeEndpoint{name: "2", handlers: wedged, t: t},
+	)
+	opts := call.ClientOptions{
+		Balancer: call.OutlierEjection(call.RoundRobin(), call.EjectionOptions{
+			ConsecutiveFailures: 2,
+			BaseEjectionTime:    time.Hour,
+		}),
+		Logger: logging.NewTestLogger(t),
+	}
+	client, err := call.Connect(ctx, resolver, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Calls to the wedged server time out until it is ejected.
+	failures := 0
+	for i := 0; i < 4; i++ {
+		ctx, cancel := context.WithTimeout(ctx, shortDelay)
+		_, err := client.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+		cancel()
+		if err != nil {
+			failures++
+		}
+	}
+	if got, want := failures, 2; got != want {
+		t.Fatalf("got %d failures, want %d", got, want)
+	}
+
+	// All subsequent calls go to the healthy server.
+	for i := 0; i < 10; i++ {
+		result, err := client.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(result), "1"; got != want {
+			t.Fatalf("bad result: got %q, want %q", got, want)
+		}
+	}
+}
+
+// TestCompression tests that large requests and responses are compressed
+// when the client and server enable compression.
+func TestCompression(t *testing.T) {
+	for _, test := range []struct {
+		name             string
+		client, server   int  // compression thresholds
+		compressRequest  bool // is the request compressed?
+		compressResponse bool // is the response compressed?
+	}{
+		{"Disabled", 0, 0, false, false},
+		{"Client", 1 << 10, 0, true, false},
+		{"Server", 0, 1 << 10, false, true},
+		{"Both", 1 << 10, 1 << 10, true, true},
+		{"AboveThreshold", 1 << 30, 1 << 30, false, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			client, server := pipe(t)
+			conn := &countingConn{connWrapper: connWrapper{client}}
+			sopts := call.ServerOptions{
+				Logger:            logging.NewTestLogger(t),
+				CompressThreshold: test.server,
+			}
+			call.ServeOn(ctx, server, handlers, sopts)
+			copts := call.ClientOptions{
+				Logger:            logging.NewTestLogger(t),
+				CompressThreshold: test.client,
+			}
+			c, err := call.Connect(ctx, call.NewConstantResolver(&connEndpoint{"server", conn}), copts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+
+			// Make a small call to make sure the handshake is done. Requests
+			// sent before the handshake is done are never compressed.
+			if _, err := c.Call(ctx, echoKey, []byte("hello"), call.CallOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			arg := bytes.Repeat([]byte("compressible "), 1<<16)
+			written, read := conn.written.Load(), conn.read.Load()
+			result, err := c.Call(ctx, echoKey, arg, call.CallOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(result, arg) {
+				t.Fatalf("bad result: got %d bytes, want %d", len(result), len(arg))
+			}
+			n := int64(len(arg))
+			if got := conn.written.Load()-written < n; got != test.compressRequest {
+				t.Errorf("request compressed: got %v, want %v", got, test.compressRequest)
+			}
+			if got := conn.read.Load()-read < n; got != test.compressResponse {
+				t.Errorf("response compressed: got %v, want %v", got, test.compressResponse)
+			}
+		})
+	}
+}
+
 func BenchmarkCall(b *testing.B) {
 	ctx := context.Background()
 	opts := call.ServerOptions{Logger: logging.NewTestLogger(b)}
@@ -1467,6 +2127,35 @@ func (f *failResolver) Get() int {
 	return f.n
 }
 
+// observingBalancer is a HealthObserver that records the outcomes it
+// observes.
+type observingBalancer struct {
+	call.Balancer
+
+	mu       sync.Mutex
+	outcomes []error
+}
+
+func (o *observingBalancer) Observe(_ call.Endpoint, _ time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.outcomes = append(o.outcomes, err)
+}
+
+// count returns the number of observed successes if err is nil, or the
+// number of observed failures otherwise.
+func (o *observingBalancer) count(err error) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n := 0
+	for _, outcome := range o.outcomes {
+		if (outcome == nil) == (err == nil) {
+			n++
+		}
+	}
+	return n
+}
+
 // dynamicResolver is a non-constant Resolver testing stub.
 type dynamicResolver struct {
 	m         sync.Mutex      // guards all of the following fields
@@ -1538,6 +2227,27 @@ func (w *connWrapper) SetWriteDeadline(t time.Time) error { return w.c.SetWriteD
 func (w *connWrapper) Read(b []byte) (int, error)         { return w.c.Read(b) }
 func (w *connWrapper) Write(b []byte) (int, error)        { return w.c.Write(b) }
 
+// pingCounter counts the ping messages written to a connection.
+type pingCounter struct {
+	connWrapper
+	n atomic.Int64
+}
+
+var _ net.Conn = &pingCounter{}
+
+func (c *pingCounter) Write(b []byte) (int, error) {
+	// A ping message is a 16 byte header, with the message type in byte 8.
+	const pingMessage = 9
+	if len(b) == 16 && b[8] == pingMessage {
+		c.n.Add(1)
+	}
+	return c.connWrapper.Write(b)
+}
+
+func (c *pingCounter) pings() int {
+	return int(c.n.Load())
+}
+
 // writeErrorInjector injects an error on writes after some number of bytes are written.
 type writeErrorInjector struct {
 	connWrapper
@@ -1558,6 +2268,27 @@ func (c *writeErrorInjector) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// countingConn counts the number of bytes written and read.
+type countingConn struct {
+	connWrapper
+	written atomic.Int64
+	read    atomic.Int64
+}
+
+var _ net.Conn = &countingConn{}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.connWrapper.Write(b)
+	c.written.Add(int64(n))
+	return n, err
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.connWrapper.Read(b)
+	c.read.Add(int64(n))
+	return n, err
+}
+
 // readErrorInjector injects an error on writes after some number of bytes are read.
 type readErrorInjector struct {
 	connWrapper
@@ -0,0 +1,175 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// # Compression
+//
+// In its versionMessage, every peer lists the compression codecs it can
+// decode, most preferred first. Once a peer has received the versionMessage
+// of the other peer, it compresses the payloads of the requests, responses,
+// and stream data messages it sends that are larger than a configurable
+// threshold, using the first codec listed by the other peer. A compressed
+// message has compressedFlag set in its type, and its payload holds:
+//
+//    codec    [1]byte       -- the codec used to compress the payload
+//    data     remainder     -- the compressed payload
+//
+// Peers that predate compression speak an older protocol version and are
+// rejected by getVersion, so every connected peer lists its codecs. A peer
+// that lists no codec we support never receives compressed messages. Note
+// that a client may send requests before it receives the versionMessage of
+// the server. These requests are never compressed.
+
+// codec identifies a compression codec.
+type codec uint8
+
+const (
+	noCodec codec = iota
+	flateCodec
+	gzipCodec
+)
+
+// compressedFlag is set in the type of messages with a compressed payload.
+const compressedFlag messageType = 0x80
+
+// supportedCodecs are the codecs we can decode, most preferred first.
+var supportedCodecs = []codec{flateCodec, gzipCodec}
+
+// compressor compresses the payloads of outgoing messages.
+type compressor struct {
+	threshold int           // if positive, compress larger payloads
+	codec     atomic.Uint32 // the codec to use, picked from the peer's codecs
+}
+
+// setPeerCodecs sets the codecs that the peer can decode, most preferred
+// first. Codecs we don't support are ignored.
+func (c *compressor) setPeerCodecs(codecs []codec) {
+	for _, peer := range codecs {
+		for _, supported := range supportedCodecs {
+			if peer == supported {
+				c.codec.Store(uint32(peer))
+				return
+			}
+		}
+	}
+	c.codec.Store(uint32(noCodec))
+}
+
+// writeCompressible is like writeMessage, but it compresses the message
+// payload using cmp, if the payload is large enough and the peer supports
+// compression.
+func writeCompressible(w io.Writer, wlock *sync.Mutex, mt messageType, id uint64, extraHdr []byte, payload []byte, flattenLimit int, cmp *compressor) error {
+	codec := codec(cmp.codec.Load())
+	size := len(extraHdr) + len(payload)
+	if cmp.threshold <= 0 || codec == noCodec || size <= cmp.threshold {
+		return writeMessage(w, wlock, mt, id, extraHdr, payload, flattenLimit)
+	}
+	compressed, err := compress(codec, extraHdr, payload)
+	if err != nil {
+		return err
+	}
+	if len(compressed) >= size {
+		// Compression didn't help.
+		return writeMessage(w, wlock, mt, id, extraHdr, payload, flattenLimit)
+	}
+	return writeMessage(w, wlock, mt|compressedFlag, id, nil, compressed, flattenLimit)
+}
+
+// resetWriter is a compressing writer that can be reused.
+type resetWriter interface {
+	io.WriteCloser
+	Reset(io.Writer)
+}
+
+var (
+	flateWriters = sync.Pool{New: func() any {
+		w, _ := flate.NewWriter(nil, flate.BestSpeed)
+		return w
+	}}
+	gzipWriters = sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+		return w
+	}}
+)
+
+// compress returns the compressed payload of a message, formed by
+// concatenating extraHdr and payload.
+func compress(codec codec, extraHdr []byte, payload []byte) ([]byte, error) {
+	var pool *sync.Pool
+	switch codec {
+	case flateCodec:
+		pool = &flateWriters
+	case gzipCodec:
+		pool = &gzipWriters
+	default:
+		return nil, fmt.Errorf("unknown compression codec %d", codec)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(byte(codec))
+	w := pool.Get().(resetWriter)
+	defer pool.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(extraHdr); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress returns the decompressed payload of a compressed message.
+func decompress(msg []byte) ([]byte, error) {
+	if len(msg) == 0 {
+		return nil, fmt.Errorf("empty compressed message")
+	}
+	var r io.ReadCloser
+	switch codec(msg[0]) {
+	case flateCodec:
+		r = flate.NewReader(bytes.NewReader(msg[1:]))
+	case gzipCodec:
+		var err error
+		if r, err = gzip.NewReader(bytes.NewReader(msg[1:])); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown compression codec %d", msg[0])
+	}
+	defer r.Close()
+
+	// Guard against payloads that decompress to overly large messages.
+	data, err := io.ReadAll(io.LimitReader(r, maxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxMessageSize {
+		return nil, fmt.Errorf("overly large decompressed message")
+	}
+	return data, nil
+}
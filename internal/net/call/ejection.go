@@ -0,0 +1,334 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/metrics"
+)
+
+var (
+	ejectionCount = metrics.NewCounterMap[ejectionLabels](
+		"serviceweaver_call_ejection_count",
+		"Number of times an unhealthy endpoint was ejected from load balancing",
+	)
+	ejectedEndpoints = metrics.NewGaugeMap[ejectedLabels](
+		"serviceweaver_call_ejected_endpoints",
+		"Number of endpoints currently ejected from load balancing",
+	)
+)
+
+type ejectionLabels struct {
+	Component string // component whose endpoint was ejected
+	Reason    string // "consecutive_failures", "failure_rate", or "latency"
+}
+
+type ejectedLabels struct {
+	Component string // component whose endpoints are ejected
+}
+
+// EjectionOptions configure the balancer returned by OutlierEjection. Zero
+// values are replaced with defaults.
+type EjectionOptions struct {
+	// Component is the name of the component whose endpoints are balanced.
+	// It is only used to label metrics.
+	Component string
+
+	// An endpoint is ejected after this many consecutive failures. Defaults
+	// to 5.
+	ConsecutiveFailures int
+
+	// An endpoint is ejected if its recent failure rate, in the range [0, 1],
+	// exceeds FailureRate. Defaults to 0.5.
+	FailureRate float64
+
+	// An endpoint is ejected because of its latency if its recent average
+	// latency is LatencyFactor times larger than the median recent average
+	// latency of all endpoints. Latency ejection needs at least three
+	// endpoints. Defaults to 10.
+	LatencyFactor float64
+
+	// The failure rate and latency of an endpoint are only considered after
+	// MinRequests outcomes have been observed since the endpoint was last
+	// ejected. Defaults to 20.
+	MinRequests int
+
+	// An endpoint is ejected for BaseEjectionTime the first time. Every
+	// subsequent ejection doubles the ejection time, up to MaxEjectionTime.
+	// An endpoint that stays healthy for MaxEjectionTime goes back to
+	// BaseEjectionTime. Default to 1 second and 30 seconds respectively.
+	BaseEjectionTime time.Duration
+	MaxEjectionTime  time.Duration
+
+	// At most MaxEjectionPercent percent of the endpoints are ejected at
+	// once. At least one endpoint is never ejected. Defaults to 50.
+	MaxEjectionPercent int
+}
+
+// decay is the weight of the past in the exponentially weighted moving
+// averages of endpoint failure rates and latencies.
+const decay = 0.9
+
+// withDefaults returns a copy of the EjectionOptions with zero values
+// replaced with default values.
+func (o EjectionOptions) withDefaults() EjectionOptions {
+	if o.ConsecutiveFailures == 0 {
+		o.ConsecutiveFailures = 5
+	}
+	if o.FailureRate == 0 {
+		o.FailureRate = 0.5
+	}
+	if o.LatencyFactor == 0 {
+		o.LatencyFactor = 10
+	}
+	if o.MinRequests == 0 {
+		o.MinRequests = 20
+	}
+	if o.BaseEjectionTime == 0 {
+		o.BaseEjectionTime = time.Second
+	}
+	if o.MaxEjectionTime == 0 {
+		o.MaxEjectionTime = 30 * time.Second
+	}
+	if o.MaxEjectionTime < o.BaseEjectionTime {
+		o.MaxEjectionTime = o.BaseEjectionTime
+	}
+	if o.MaxEjectionPercent == 0 {
+		o.MaxEjectionPercent = 50
+	}
+	return o
+}
+
+// health is the health of a single endpoint.
+type health struct {
+	endpoint Endpoint
+
+	// Outcomes observed since the endpoint was last ejected.
+	requests    int           // number of outcomes observed
+	consecutive int           // number of consecutive failures
+	failureRate float64       // moving average of the failure rate
+	latency     time.Duration // moving average of the latency of successes
+
+	ejected      bool      // is the endpoint ejected?
+	ejections    int       // number of back-to-back ejections
+	ejectedUntil time.Time // when the current or last ejection ends
+}
+
+// ejector is the Balancer returned by OutlierEjection.
+type ejector struct {
+	inner     Balancer
+	opts      EjectionOptions
+	endpoints map[string]*health // keyed by endpoint address
+	order     []*health          // endpoints, in the order passed to Update
+	ejected   int                // number of ejected endpoints
+	nextCheck time.Time          // earliest ejectedUntil of ejected endpoints
+}
+
+var _ HealthObserver = &ejector{}
+
+// OutlierEjection returns a Balancer that tracks the health of endpoints and
+// ejects unhealthy endpoints for a back-off period. Healthy endpoints are
+// picked by inner.
+//
+// An endpoint is unhealthy if calls to it fail repeatedly, fail often, or are
+// much slower than calls to the other endpoints. See EjectionOptions for
+// details. The balancer exports the number of ejections and the number of
+// currently ejected endpoints as metrics.
+func OutlierEjection(inner Balancer, opts EjectionOptions) HealthObserver {
+	return &ejector{
+		inner:     inner,
+		opts:      opts.withDefaults(),
+		endpoints: map[string]*health{},
+	}
+}
+
+// Update implements the Balancer interface.
+func (e *ejector) Update(endpoints []Endpoint) {
+	if e.unchanged(endpoints) {
+		return
+	}
+
+	// Retain the health of endpoints that are still present.
+	old := e.endpoints
+	e.endpoints = make(map[string]*health, len(endpoints))
+	e.order = make([]*health, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		addr := endpoint.Address()
+		if _, ok := e.endpoints[addr]; ok {
+			continue
+		}
+		h, ok := old[addr]
+		if ok {
+			h.endpoint = endpoint
+			delete(old, addr)
+		} else {
+			h = &health{endpoint: endpoint}
+		}
+		e.endpoints[addr] = h
+		e.order = append(e.order, h)
+	}
+	for _, h := range old {
+		if h.ejected {
+			e.ejected--
+			ejectedEndpoints.Get(ejectedLabels{e.opts.Component}).Sub(1)
+		}
+	}
+	e.updateInner()
+}
+
+// unchanged returns whether endpoints have the same addresses as the
+// endpoints passed to the latest call to Update. If so, it replaces the
+// previous endpoints with endpoints. Connections that use per-call balancers
+// call Update on every call, so this check needs to be cheap.
+func (e *ejector) unchanged(endpoints []Endpoint) bool {
+	if len(endpoints) != len(e.order) {
+		return false
+	}
+	for i, endpoint := range endpoints {
+		if e.order[i].endpoint.Address() != endpoint.Address() {
+			return false
+		}
+	}
+	for i, endpoint := range endpoints {
+		e.order[i].endpoint = endpoint
+	}
+	return true
+}
+
+// Pick implements the Balancer interface.
+func (e *ejector) Pick(opts CallOptions) (Endpoint, error) {
+	if e.ejected > 0 && !time.Now().Before(e.nextCheck) {
+		e.restore()
+	}
+	return e.inner.Pick(opts)
+}
+
+// Observe implements the HealthObserver interface.
+func (e *ejector) Observe(endpoint Endpoint, latency time.Duration, err error) {
+	h, ok := e.endpoints[endpoint.Address()]
+	if !ok || h.ejected {
+		// The endpoint is stale or already ejected.
+		return
+	}
+
+	h.requests++
+	if err != nil {
+		h.consecutive++
+		h.failureRate = decay*h.failureRate + (1 - decay)
+	} else {
+		h.consecutive = 0
+		h.failureRate = decay * h.failureRate
+		if h.latency == 0 {
+			h.latency = latency
+		} else {
+			h.latency = time.Duration(decay*float64(h.latency) + (1-decay)*float64(latency))
+		}
+	}
+
+	switch {
+	case h.consecutive >= e.opts.ConsecutiveFailures:
+		e.eject(h, "consecutive_failures")
+	case h.requests >= e.opts.MinRequests && h.failureRate > e.opts.FailureRate:
+		e.eject(h, "failure_rate")
+	case h.requests >= e.opts.MinRequests && e.slow(h):
+		e.eject(h, "latency")
+	}
+}
+
+// slow returns whether h is much slower than the other endpoints.
+func (e *ejector) slow(h *health) bool {
+	var latencies []time.Duration
+	for _, other := range e.order {
+		if !other.ejected && other.requests >= e.opts.MinRequests && other.latency > 0 {
+			latencies = append(latencies, other.latency)
+		}
+	}
+	if len(latencies) < 3 {
+		return false
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	median := latencies[len(latencies)/2]
+	return float64(h.latency) > e.opts.LatencyFactor*float64(median)
+}
+
+// eject ejects h, if doing so doesn't eject too many endpoints.
+func (e *ejector) eject(h *health, reason string) {
+	n := len(e.order)
+	if e.ejected+1 >= n || (e.ejected+1)*100 > n*e.opts.MaxEjectionPercent {
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(h.ejectedUntil) > e.opts.MaxEjectionTime {
+		// The endpoint has been healthy for a while. Forget past ejections.
+		h.ejections = 0
+	}
+	h.ejections++
+	d := e.opts.BaseEjectionTime
+	for i := 1; i < h.ejections && d < e.opts.MaxEjectionTime; i++ {
+		d *= 2
+	}
+	if d > e.opts.MaxEjectionTime {
+		d = e.opts.MaxEjectionTime
+	}
+
+	h.ejected = true
+	h.ejectedUntil = now.Add(d)
+	h.requests, h.consecutive, h.failureRate, h.latency = 0, 0, 0, 0
+	if e.ejected == 0 || h.ejectedUntil.Before(e.nextCheck) {
+		e.nextCheck = h.ejectedUntil
+	}
+	e.ejected++
+	ejectionCount.Get(ejectionLabels{e.opts.Component, reason}).Add(1)
+	ejectedEndpoints.Get(ejectedLabels{e.opts.Component}).Add(1)
+	e.updateInner()
+}
+
+// restore returns the endpoints whose ejection has ended to load balancing.
+func (e *ejector) restore() {
+	now := time.Now()
+	e.nextCheck = time.Time{}
+	restored := 0
+	for _, h := range e.order {
+		if !h.ejected {
+			continue
+		}
+		if now.Before(h.ejectedUntil) {
+			if e.nextCheck.IsZero() || h.ejectedUntil.Before(e.nextCheck) {
+				e.nextCheck = h.ejectedUntil
+			}
+			continue
+		}
+		h.ejected = false
+		restored++
+	}
+	e.ejected -= restored
+	ejectedEndpoints.Get(ejectedLabels{e.opts.Component}).Sub(float64(restored))
+	e.updateInner()
+}
+
+// updateInner updates the inner balancer with the endpoints that are not
+// ejected.
+func (e *ejector) updateInner() {
+	healthy := make([]Endpoint, 0, len(e.order)-e.ejected)
+	for _, h := range e.order {
+		if !h.ejected {
+			healthy = append(healthy, h.endpoint)
+		}
+	}
+	e.inner.Update(healthy)
+}
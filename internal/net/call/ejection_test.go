@@ -0,0 +1,190 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+)
+
+var errFailed = errors.New("failed")
+
+// picks returns the set of addresses picked by b in n calls to Pick.
+func picks(t *testing.T, b call.Balancer, n int) map[string]bool {
+	t.Helper()
+	picked := map[string]bool{}
+	for i := 0; i < n; i++ {
+		endpoint, err := b.Pick(call.CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		picked[endpoint.Address()] = true
+	}
+	return picked
+}
+
+func TestEjectConsecutiveFailures(t *testing.T) {
+	a, b, c := call.TCP("a"), call.TCP("b"), call.TCP("c")
+	opts := call.EjectionOptions{
+		ConsecutiveFailures: 3,
+		BaseEjectionTime:    shortDelay,
+	}
+	balancer := call.OutlierEjection(call.RoundRobin(), opts)
+	balancer.Update([]call.Endpoint{a, b, c})
+
+	// Two failures aren't enough to eject a. A success resets the count.
+	balancer.Observe(a, 0, errFailed)
+	balancer.Observe(a, 0, errFailed)
+	balancer.Observe(a, 0, nil)
+	balancer.Observe(a, 0, errFailed)
+	balancer.Observe(a, 0, errFailed)
+	if !picks(t, balancer, 3)[a.Address()] {
+		t.Fatalf("a ejected after two consecutive failures")
+	}
+
+	// A third consecutive failure ejects a.
+	balancer.Observe(a, 0, errFailed)
+	if picks(t, balancer, 10)[a.Address()] {
+		t.Fatalf("a not ejected after three consecutive failures")
+	}
+
+	// a returns after the ejection time.
+	time.Sleep(shortDelay)
+	if !picks(t, balancer, 3)[a.Address()] {
+		t.Fatalf("a not returned after %v", shortDelay)
+	}
+}
+
+func TestEjectFailureRate(t *testing.T) {
+	a, b, c := call.TCP("a"), call.TCP("b"), call.TCP("c")
+	opts := call.EjectionOptions{
+		ConsecutiveFailures: 100,
+		FailureRate:         0.35,
+		MinRequests:         20,
+		BaseEjectionTime:    time.Hour,
+	}
+	balancer := call.OutlierEjection(call.RoundRobin(), opts)
+	balancer.Update([]call.Endpoint{a, b, c})
+
+	// Fail every other call to a.
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			balancer.Observe(a, 0, errFailed)
+		} else {
+			balancer.Observe(a, 0, nil)
+		}
+		balancer.Observe(b, 0, nil)
+		balancer.Observe(c, 0, errFailed)
+		balancer.Observe(c, 0, nil)
+		balancer.Observe(c, 0, nil)
+		balancer.Observe(c, 0, nil)
+	}
+	picked := picks(t, balancer, 10)
+	if picked[a.Address()] {
+		t.Errorf("a not ejected with a 50%% failure rate")
+	}
+	if !picked[b.Address()] || !picked[c.Address()] {
+		t.Errorf("healthy endpoints ejected: picked %v", picked)
+	}
+}
+
+func TestEjectLatency(t *testing.T) {
+	a, b, c := call.TCP("a"), call.TCP("b"), call.TCP("c")
+	opts := call.EjectionOptions{
+		LatencyFactor:    5,
+		MinRequests:      10,
+		BaseEjectionTime: time.Hour,
+	}
+	balancer := call.OutlierEjection(call.RoundRobin(), opts)
+	balancer.Update([]call.Endpoint{a, b, c})
+
+	for i := 0; i < 10; i++ {
+		balancer.Observe(b, time.Millisecond, nil)
+		balancer.Observe(c, 2*time.Millisecond, nil)
+		balancer.Observe(a, 100*time.Millisecond, nil)
+	}
+	picked := picks(t, balancer, 10)
+	if picked[a.Address()] {
+		t.Errorf("slow endpoint a not ejected")
+	}
+	if !picked[b.Address()] || !picked[c.Address()] {
+		t.Errorf("fast endpoints ejected: picked %v", picked)
+	}
+}
+
+func TestMaxEjectionPercent(t *testing.T) {
+	endpoints := []call.Endpoint{call.TCP("a"), call.TCP("b"), call.TCP("c"), call.TCP("d")}
+	opts := call.EjectionOptions{
+		ConsecutiveFailures: 1,
+		BaseEjectionTime:    time.Hour,
+		MaxEjectionPercent:  50,
+	}
+	balancer := call.OutlierEjection(call.RoundRobin(), opts)
+	balancer.Update(endpoints)
+
+	// Only two of the four endpoints can be ejected.
+	for _, endpoint := range endpoints {
+		balancer.Observe(endpoint, 0, errFailed)
+	}
+	if got, want := len(picks(t, balancer, 10)), 2; got != want {
+		t.Fatalf("got %d picked endpoints, want %d", got, want)
+	}
+}
+
+func TestEjectionBackoff(t *testing.T) {
+	a, b := call.TCP("a"), call.TCP("b")
+	opts := call.EjectionOptions{
+		ConsecutiveFailures: 1,
+		BaseEjectionTime:    shortDelay,
+		MaxEjectionTime:     time.Hour,
+	}
+	balancer := call.OutlierEjection(call.RoundRobin(), opts)
+	balancer.Update([]call.Endpoint{a, b})
+
+	// Eject a, wait for it to return, and eject it again. The second
+	// ejection lasts twice as long as the first.
+	balancer.Observe(a, 0, errFailed)
+	waitUntil(t, func() bool { return picks(t, balancer, 2)[a.Address()] })
+	balancer.Observe(a, 0, errFailed)
+	time.Sleep(shortDelay)
+	if picks(t, balancer, 10)[a.Address()] {
+		t.Fatalf("a returned after %v, want %v", shortDelay, 2*shortDelay)
+	}
+	waitUntil(t, func() bool { return picks(t, balancer, 2)[a.Address()] })
+}
+
+func TestEjectionSurvivesUpdate(t *testing.T) {
+	a, b, c := call.TCP("a"), call.TCP("b"), call.TCP("c")
+	opts := call.EjectionOptions{
+		ConsecutiveFailures: 1,
+		BaseEjectionTime:    time.Hour,
+	}
+	balancer := call.OutlierEjection(call.RoundRobin(), opts)
+	balancer.Update([]call.Endpoint{a, b})
+	balancer.Observe(a, 0, errFailed)
+
+	// a stays ejected when the set of endpoints changes.
+	balancer.Update([]call.Endpoint{c, a, b})
+	picked := picks(t, balancer, 10)
+	if picked[a.Address()] {
+		t.Fatalf("a returned after Update")
+	}
+	if !picked[b.Address()] || !picked[c.Address()] {
+		t.Fatalf("healthy endpoints ejected: picked %v", picked)
+	}
+}
@@ -17,6 +17,7 @@ package call
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"strings"
@@ -107,12 +108,30 @@ func ParseNetEndpoint(endpoint string) (NetEndpoint, error) {
 	return NetEndpoint{Net: net, Addr: addr}, nil
 }
 
+// A verifier is an Endpoint that authenticates the server of every network
+// connection it dials. Connections that share a Pool may dial the same address
+// through different verifiers, e.g., MTLS endpoints with different configs. A
+// network connection dialed through one verifier is only reused through
+// another after the other verifier authenticates the server too.
+type verifier interface {
+	Endpoint
+
+	// verifyKey returns a comparable key that identifies how the verifier
+	// authenticates servers. Verifiers with equal keys authenticate servers
+	// identically.
+	verifyKey() any
+
+	// verify authenticates the server of a network connection dialed by an
+	// Endpoint with the same address as the verifier.
+	verify(net.Conn) error
+}
+
 type tlsEndpoint struct {
 	config *tls.Config
 	ep     Endpoint
 }
 
-var _ Endpoint = &tlsEndpoint{}
+var _ verifier = &tlsEndpoint{}
 
 // Dial implements the Endpoint interface.
 func (t *tlsEndpoint) Dial(ctx context.Context) (net.Conn, error) {
@@ -127,6 +146,56 @@ func (t *tlsEndpoint) Dial(ctx context.Context) (net.Conn, error) {
 	return tlsConn, nil
 }
 
+// verifyKey implements the verifier interface.
+func (t *tlsEndpoint) verifyKey() any {
+	return t.config
+}
+
+// verify implements the verifier interface.
+func (t *tlsEndpoint) verify(conn net.Conn) error {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return fmt.Errorf("%v: not a TLS connection", t.Address())
+	}
+
+	// Repeat the server checks that a TLS handshake with t.config performs.
+	state := tlsConn.ConnectionState()
+	var chains [][]*x509.Certificate
+	if !t.config.InsecureSkipVerify {
+		if len(state.PeerCertificates) == 0 {
+			return fmt.Errorf("%v: no server certificate", t.Address())
+		}
+		opts := x509.VerifyOptions{
+			Roots:         t.config.RootCAs,
+			DNSName:       t.config.ServerName,
+			Intermediates: x509.NewCertPool(),
+		}
+		for _, cert := range state.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		var err error
+		chains, err = state.PeerCertificates[0].Verify(opts)
+		if err != nil {
+			return fmt.Errorf("%v: %w", t.Address(), err)
+		}
+	}
+	if t.config.VerifyPeerCertificate != nil {
+		rawCerts := make([][]byte, len(state.PeerCertificates))
+		for i, cert := range state.PeerCertificates {
+			rawCerts[i] = cert.Raw
+		}
+		if err := t.config.VerifyPeerCertificate(rawCerts, chains); err != nil {
+			return fmt.Errorf("%v: %w", t.Address(), err)
+		}
+	}
+	if t.config.VerifyConnection != nil {
+		if err := t.config.VerifyConnection(state); err != nil {
+			return fmt.Errorf("%v: %w", t.Address(), err)
+		}
+	}
+	return nil
+}
+
 // Address implements the Endpoint interface.
 func (t *tlsEndpoint) Address() string {
 	return fmt.Sprintf("mtls://%s", t.ep.Address())
@@ -37,10 +37,18 @@ func MakeMethodKey(component, method string) MethodKey {
 // successfully.
 type Handler func(ctx context.Context, args []byte) ([]byte, error)
 
+// StreamHandler is a function that handles streaming remote procedure calls.
+// In addition to the call arguments, a StreamHandler receives the server side
+// of the call's stream, which it can use to exchange data messages with the
+// client until it returns. Like for a Handler, regular application errors
+// should be serialized in the returned bytes.
+type StreamHandler func(ctx context.Context, args []byte, stream *ServerStream) ([]byte, error)
+
 // HandlerMap is a mapping from MethodID to a Handler. The zero value for a
 // HandlerMap is an empty map.
 type HandlerMap struct {
 	handlers map[MethodKey]Handler
+	streams  map[MethodKey]StreamHandler
 	names    map[MethodKey]string
 }
 
@@ -54,3 +62,17 @@ func (hm *HandlerMap) Set(component, method string, handler Handler) {
 	hm.handlers[fp] = handler
 	hm.names[fp] = component + "." + method
 }
+
+// SetStream registers a streaming handler for the specified method of
+// component.
+func (hm *HandlerMap) SetStream(component, method string, handler StreamHandler) {
+	if hm.streams == nil {
+		hm.streams = map[MethodKey]StreamHandler{}
+	}
+	if hm.names == nil {
+		hm.names = map[MethodKey]string{}
+	}
+	fp := MakeMethodKey(component, method)
+	hm.streams[fp] = handler
+	hm.names[fp] = component + "." + method
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/ServiceWeaver/weaver/metadata"
+)
+
+// Context metadata (see the metadata package) is serialized as a sequence of
+// key-value pairs, sorted by key:
+//
+//	keyLen   [4]byte
+//	key      [keyLen]byte
+//	valueLen [4]byte
+//	value    [valueLen]byte
+
+// appendContextMetadata appends the serialization of the metadata (if any)
+// contained in ctx to b and returns the extended slice.
+func appendContextMetadata(ctx context.Context, b []byte) []byte {
+	md, ok := metadata.FromContext(ctx)
+	if !ok || len(md) == 0 {
+		return b
+	}
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(k)))
+		b = append(b, k...)
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(md[k])))
+		b = append(b, md[k]...)
+	}
+	return b
+}
+
+// readContextMetadata returns the metadata serialized in b, or nil if b is
+// empty.
+func readContextMetadata(b []byte) (map[string]string, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	md := map[string]string{}
+	readString := func() (string, error) {
+		if len(b) < 4 {
+			return "", fmt.Errorf("truncated metadata")
+		}
+		n := binary.LittleEndian.Uint32(b)
+		b = b[4:]
+		if uint64(len(b)) < uint64(n) {
+			return "", fmt.Errorf("truncated metadata")
+		}
+		s := string(b[:n])
+		b = b[n:]
+		return s, nil
+	}
+	for len(b) > 0 {
+		k, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		v, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		md[k] = v
+	}
+	return md, nil
+}
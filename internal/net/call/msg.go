@@ -31,9 +31,13 @@ const (
 	responseMessage
 	responseError
 	cancelMessage
+	streamRequestMessage
+	streamDataMessage
+	streamEndMessage
+	streamCreditMessage
+	pingMessage
+	pongMessage
 	// Other types to add?
-	// - chunked request/response messages?
-	// - health check
 	// - server status info
 )
 
@@ -42,9 +46,19 @@ type version uint32
 
 const (
 	initialVersion version = iota
+
+	// metadataVersion adds streams, pings, compression codecs in the version
+	// message, and context metadata in request messages.
+	metadataVersion
 )
 
-const currentVersion = initialVersion
+// currentVersion is the protocol version spoken by this package. Both sides
+// of a connection must speak the same version; see getVersion.
+const currentVersion = metadataVersion
+
+// maxMessageSize is the maximum size of a message payload, after
+// decompression.
+const maxMessageSize = 100 << 20
 
 // # Message formats
 //
@@ -58,11 +72,16 @@ const currentVersion = initialVersion
 //
 // versionMessage: this is the first message sent on a connection by both sides.
 //    version  [4]byte
+//    codecs   [n]byte       -- compression codecs the sender can decode
+//
+// See compress.go for a description of compression.
 //
 // requestMessage:
 //    headerKey    [16]byte   -- fingerprint of method name
 //    deadline      [8]byte   -- zero, or deadline in microseconds
 //    traceContext [25]byte   -- zero, or trace context
+//    metadataLen   [4]byte   -- length of the context metadata
+//    metadata      [n]byte   -- context metadata (see metadata.go)
 //    remainder               -- call argument serialization
 //
 // responseMessage:
@@ -73,6 +92,30 @@ const currentVersion = initialVersion
 //
 // cancelMessage:
 //    payload is empty
+//
+// streamRequestMessage:
+//    same format as requestMessage
+//
+// streamDataMessage:
+//    payload holds a stream data message
+//
+// streamEndMessage:
+//    payload is empty
+//
+// streamCreditMessage:
+//    credits  [4]byte       -- number of additional data messages allowed
+//
+// See stream.go for a description of streaming calls.
+//
+// pingMessage:
+//    payload is empty
+//
+// pongMessage:
+//    payload is empty
+//
+// A server replies to every pingMessage with a pongMessage carrying the same
+// id. Clients send pings to check the health of the servers they are
+// connected to (see ClientOptions.HealthCheckInterval).
 
 // writeMessage formats and sends a message over w.
 //
@@ -156,8 +199,7 @@ func readMessage(r io.Reader) (messageType, uint64, []byte, error) {
 	w2 := binary.LittleEndian.Uint64(hdr[8:])
 	mt := messageType(w2 & 0xff)
 	dataLen := w2 >> 8
-	const maxSize = 100 << 20
-	if dataLen > maxSize {
+	if dataLen > maxMessageSize {
 		return 0, 0, nil, fmt.Errorf("overly large message length %d", dataLen)
 	}
 
@@ -166,31 +208,48 @@ func readMessage(r io.Reader) (messageType, uint64, []byte, error) {
 	if _, err := io.ReadFull(r, msg); err != nil {
 		return 0, 0, nil, err
 	}
+
+	// Decompress the payload, if needed.
+	if mt&compressedFlag != 0 {
+		mt &^= compressedFlag
+		var err error
+		if msg, err = decompress(msg); err != nil {
+			return 0, 0, nil, err
+		}
+	}
 	return mt, id, msg, nil
 }
 
-// writeVersion sends my version number to the peer.
+// writeVersion sends my version number, along with the compression codecs I
+// can decode, to the peer.
 func writeVersion(w io.Writer, wlock *sync.Mutex) error {
-	var msg [4]byte
-	binary.LittleEndian.PutUint32(msg[:], uint32(currentVersion))
-	return writeFlat(w, wlock, versionMessage, 0, nil, msg[:])
+	msg := make([]byte, 4+len(supportedCodecs))
+	binary.LittleEndian.PutUint32(msg, uint32(currentVersion))
+	for i, codec := range supportedCodecs {
+		msg[4+i] = byte(codec)
+	}
+	return writeFlat(w, wlock, versionMessage, 0, nil, msg)
 }
 
-// getVersion extracts the version number sent by the peer and picks the
-// appropriate version number to use for communicating with the peer.
-func getVersion(id uint64, msg []byte) (version, error) {
+// getVersion extracts the version number sent by the peer and returns the
+// version number to use for communicating with the peer. It also returns the
+// compression codecs that the peer can decode. Older versions use different
+// message formats, so a peer that doesn't speak currentVersion is rejected.
+func getVersion(id uint64, msg []byte) (version, []codec, error) {
 	if id != 0 {
-		return 0, fmt.Errorf("invalid ID %d in handshake", id)
+		return 0, nil, fmt.Errorf("invalid ID %d in handshake", id)
 	}
 	// Allow messages longer than needed so that future updates can send more info.
 	if len(msg) < 4 {
-		return 0, fmt.Errorf("bad version message length %d, must be >= 4", len(msg))
+		return 0, nil, fmt.Errorf("bad version message length %d, must be >= 4", len(msg))
 	}
 	v := binary.LittleEndian.Uint32(msg)
-
-	// We use the minimum of the peer and my version numbers.
-	if v < uint32(currentVersion) {
-		return version(v), nil
+	if v != uint32(currentVersion) {
+		return 0, nil, fmt.Errorf("unsupported protocol version %d, want %d", v, currentVersion)
+	}
+	codecs := make([]codec, len(msg)-4)
+	for i, b := range msg[4:] {
+		codecs[i] = codec(b)
 	}
-	return currentVersion, nil
+	return currentVersion, codecs, nil
 }
@@ -16,6 +16,7 @@ package call
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"math/rand"
 	"net"
@@ -100,6 +101,96 @@ func TestConcurrentWrites(t *testing.T) {
 	}
 }
 
+func TestCompressedMessages(t *testing.T) {
+	extraHdr := []byte("header")
+	compressible := bytes.Repeat([]byte("compressible "), 1000)
+	incompressible := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(incompressible)
+
+	for _, test := range []struct {
+		name       string
+		threshold  int
+		peer       []codec
+		payload    []byte
+		compressed bool
+	}{
+		{"Flate", 100, []codec{flateCodec, gzipCodec}, compressible, true},
+		{"Gzip", 100, []codec{gzipCodec, flateCodec}, compressible, true},
+		{"UnknownCodec", 100, []codec{42, gzipCodec}, compressible, true},
+		{"NoPeerCodecs", 100, nil, compressible, false},
+		{"OnlyUnknownCodecs", 100, []codec{42}, compressible, false},
+		{"BelowThreshold", 1 << 20, []codec{flateCodec}, compressible, false},
+		{"Disabled", 0, []codec{flateCodec}, compressible, false},
+		{"Incompressible", 100, []codec{flateCodec}, incompressible, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cmp := &compressor{threshold: test.threshold}
+			cmp.setPeerCodecs(test.peer)
+			var buf bytes.Buffer
+			var wlock sync.Mutex
+			if err := writeCompressible(&buf, &wlock, requestMessage, 42, extraHdr, test.payload, 0, cmp); err != nil {
+				t.Fatal(err)
+			}
+
+			// The message type is the ninth byte of a message.
+			if got := messageType(buf.Bytes()[8])&compressedFlag != 0; got != test.compressed {
+				t.Errorf("compressed: got %v, want %v", got, test.compressed)
+			}
+
+			mt, id, msg, err := readMessage(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mt != requestMessage || id != 42 {
+				t.Errorf("got message (%d, %d), want (%d, %d)", mt, id, requestMessage, 42)
+			}
+			if want := append(append([]byte{}, extraHdr...), test.payload...); !bytes.Equal(msg, want) {
+				t.Errorf("bad payload: got %d bytes, want %d", len(msg), len(want))
+			}
+		})
+	}
+}
+
+func TestVersionCodecs(t *testing.T) {
+	var buf bytes.Buffer
+	var wlock sync.Mutex
+	if err := writeVersion(&buf, &wlock); err != nil {
+		t.Fatal(err)
+	}
+	_, id, msg, err := readMessage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, codecs, err := getVersion(id, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != currentVersion {
+		t.Errorf("version: got %d, want %d", v, currentVersion)
+	}
+	if fmt.Sprint(codecs) != fmt.Sprint(supportedCodecs) {
+		t.Errorf("codecs: got %v, want %v", codecs, supportedCodecs)
+	}
+
+	// Peers that don't support any codec only send a version.
+	_, codecs, err = getVersion(0, binary.LittleEndian.AppendUint32(nil, uint32(currentVersion)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codecs) != 0 {
+		t.Errorf("codecs: got %v, want none", codecs)
+	}
+}
+
+func TestVersionMismatch(t *testing.T) {
+	for _, v := range []version{initialVersion, currentVersion + 1} {
+		msg := binary.LittleEndian.AppendUint32(nil, uint32(v))
+		if _, _, err := getVersion(0, msg); err == nil {
+			t.Errorf("getVersion(%d): unexpected success", v)
+		}
+	}
+}
+
 func BenchmarkReadWrite(b *testing.B) {
 	for _, network := range []string{"tcp"} {
 		out, in := net.Pipe()
@@ -38,6 +38,23 @@ type ClientOptions struct {
 	// If non-zero, all writes smaller than this limit are flattened into
 	// a single buffer before being written on the connection.
 	WriteFlattenLimit int
+
+	// Pool, if not nil, is the pool of network connections used by the
+	// client. Clients that use the same Pool share their network connections.
+	// Defaults to a new pool used only by the client.
+	Pool *Pool
+
+	// If non-zero, every network connection dialed by the client is pinged
+	// with this period, and the outcome of every ping is reported to the
+	// Balancers of the clients that use the connection, if the Balancers are
+	// HealthObservers. A ping fails if the endpoint doesn't reply within the
+	// period. A network connection shared through a Pool is pinged once per
+	// period, no matter how many clients use it.
+	HealthCheckInterval time.Duration
+
+	// If positive, request and stream data payloads larger than this many
+	// bytes are compressed, if the server supports compression.
+	CompressThreshold int
 }
 
 // ServerOption are the options to configure an RPC server.
@@ -55,6 +72,10 @@ type ServerOptions struct {
 	// If non-zero, all writes smaller than this limit are flattened into
 	// a single buffer before being written on the connection.
 	WriteFlattenLimit int
+
+	// If positive, response and stream data payloads larger than this many
+	// bytes are compressed, if the client supports compression.
+	CompressThreshold int
 }
 
 // CallOptions are call-specific options.
@@ -70,6 +91,32 @@ type CallOptions struct {
 	// Balancer that the client was constructed with (provided in
 	// ClientOptions).
 	Balancer Balancer
+
+	// HedgeDelay, if positive, hedges the call: if the call doesn't finish
+	// within HedgeDelay, a duplicate of the call is sent to a different
+	// endpoint, and the first successful result of the two calls is returned.
+	// The other call is canceled. Only calls to idempotent methods should be
+	// hedged. Streaming calls are never hedged.
+	HedgeDelay time.Duration
+
+	// AllowHedge, if not nil, is called before a hedged call sends its
+	// duplicate request. The duplicate is only sent if AllowHedge returns
+	// true, which lets the caller bound the extra load of hedging.
+	AllowHedge func() bool
+
+	// Latency, if not nil, is called with the latency of a call that
+	// succeeds. The latency of a hedged call is the latency of its original
+	// request, even if the duplicate request succeeds first, in which case
+	// the latency is the time until the original request was canceled.
+	Latency func(time.Duration)
+
+	// exclude, if not nil, is an endpoint that the call must not be sent to.
+	exclude Endpoint
+
+	// inflight, if not nil, returns the number of in-flight calls issued by
+	// the Connection making the call to the endpoint with the provided
+	// address. It is set by the Connection before picking an endpoint.
+	inflight func(addr string) int
 }
 
 // withDefaults returns a copy of the ClientOptions with zero values replaced
@@ -81,6 +128,9 @@ func (c ClientOptions) withDefaults() ClientOptions {
 	if c.Balancer == nil {
 		c.Balancer = RoundRobin()
 	}
+	if c.Pool == nil {
+		c.Pool = NewPool()
+	}
 	return c
 }
 
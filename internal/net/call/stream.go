@@ -0,0 +1,328 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+// # Streaming calls
+//
+// A streaming call is like a regular call, except that, in addition to the
+// call arguments and the call result, the client and the server can exchange
+// an arbitrary number of data messages while the call is in progress.
+//
+// A streaming call starts with a streamRequestMessage, which has the same
+// format as a requestMessage. The client then sends data messages to the
+// server, followed by a streamEndMessage once it has no more data to send.
+// Concurrently, the server sends data messages to the client. The call ends
+// when the server sends a responseMessage or responseError, exactly like a
+// regular call. Data messages received after the end of the call are
+// discarded.
+//
+// Both directions are flow controlled. A sender may have at most streamWindow
+// data messages that have not yet been consumed by the receiver. When a
+// receiver consumes data messages, it returns credits to the sender in a
+// streamCreditMessage, which allows the sender to send more data messages.
+// This bounds the amount of memory buffered for a stream on both sides,
+// regardless of the relative speeds of the sender and the receiver.
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// streamWindow is the maximum number of data messages a stream sender may have
+// in flight.
+const streamWindow = 32
+
+// streamState holds the flow control state of one side of a streaming call.
+type streamState struct {
+	// recv holds received data messages that haven't been consumed yet. The
+	// connection's reader goroutine is the only writer to recv. Flow control
+	// ensures that recv never overflows.
+	recv       chan []byte
+	recvClosed bool // has recv been closed? Accessed only by the reader.
+
+	mu       sync.Mutex
+	credits  int           // number of data messages we may send
+	consumed int           // messages consumed since we last returned credits
+	creditc  chan struct{} // signaled when credits are added
+
+	// canceled is closed when the call is canceled. A server may receive a
+	// cancellation before the handler for the call has started, so the
+	// cancellation is recorded here rather than in the handler's context.
+	canceled   chan struct{}
+	cancelOnce sync.Once
+}
+
+func newStreamState() *streamState {
+	return &streamState{
+		recv:     make(chan []byte, streamWindow),
+		credits:  streamWindow,
+		creditc:  make(chan struct{}, 1),
+		canceled: make(chan struct{}),
+	}
+}
+
+// cancel records that the call was canceled.
+func (s *streamState) cancel() {
+	s.cancelOnce.Do(func() { close(s.canceled) })
+}
+
+// deliver delivers a received data message. It returns an error if the sender
+// violated flow control.
+func (s *streamState) deliver(msg []byte) error {
+	if s.recvClosed {
+		return fmt.Errorf("stream data after end of stream")
+	}
+	select {
+	case s.recv <- msg:
+		return nil
+	default:
+		return fmt.Errorf("stream flow control violated")
+	}
+}
+
+// endRecv marks the end of the received data messages.
+func (s *streamState) endRecv() {
+	if !s.recvClosed {
+		s.recvClosed = true
+		close(s.recv)
+	}
+}
+
+// addCredits adds the credits carried by a streamCreditMessage.
+func (s *streamState) addCredits(msg []byte) error {
+	if len(msg) < 4 {
+		return fmt.Errorf("bad stream credit message length %d", len(msg))
+	}
+	n := binary.LittleEndian.Uint32(msg)
+	s.mu.Lock()
+	s.credits += int(n)
+	s.mu.Unlock()
+	select {
+	case s.creditc <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// acquireCredit blocks until we are allowed to send a data message, the
+// context is done, or done is closed. It returns false if a credit was not
+// acquired.
+func (s *streamState) acquireCredit(ctx context.Context, done <-chan struct{}) bool {
+	for {
+		s.mu.Lock()
+		if s.credits > 0 {
+			s.credits--
+			s.mu.Unlock()
+			return true
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.creditc:
+		case <-done:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// consume records that a received data message was consumed. It returns the
+// number of credits to return to the sender, or zero if credits shouldn't be
+// returned yet. Credits are returned in batches to reduce the number of
+// credit messages.
+func (s *streamState) consume() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consumed++
+	if s.consumed < streamWindow/2 {
+		return 0
+	}
+	n := s.consumed
+	s.consumed = 0
+	return uint32(n)
+}
+
+// writeCredits sends a streamCreditMessage carrying n credits.
+func writeCredits(w io.Writer, wlock *sync.Mutex, id uint64, n uint32, flattenLimit int) error {
+	var msg [4]byte
+	binary.LittleEndian.PutUint32(msg[:], n)
+	return writeMessage(w, wlock, streamCreditMessage, id, nil, msg[:], flattenLimit)
+}
+
+// ClientStream is the client side of a streaming call. See Connection.Stream.
+//
+// Send and CloseSend may be called concurrently with Recv and Result, but
+// Send and CloseSend must not be called concurrently with each other, and Recv
+// and Result must not be called concurrently with each other.
+type ClientStream struct {
+	ctx       context.Context
+	conn      *clientConnection
+	rpc       *call
+	flatten   int
+	closeOnce sync.Once
+}
+
+// Send sends a data message to the server. It blocks if the server hasn't yet
+// consumed previously sent messages.
+func (s *ClientStream) Send(data []byte) error {
+	if !s.rpc.stream.acquireCredit(s.ctx, s.rpc.doneSignal) {
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: send on finished stream", CommunicationError)
+	}
+	if err := writeCompressible(s.conn.c, &s.conn.wlock, streamDataMessage, s.rpc.id, nil, data, s.flatten, &s.conn.cmp); err != nil {
+		s.conn.shutdown("client send stream data", err)
+		return fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+	return nil
+}
+
+// CloseSend informs the server that the client will not send any more data
+// messages.
+func (s *ClientStream) CloseSend() error {
+	if err := writeMessage(s.conn.c, &s.conn.wlock, streamEndMessage, s.rpc.id, nil, nil, s.flatten); err != nil {
+		s.conn.shutdown("client send stream end", err)
+		return fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+	return nil
+}
+
+// Recv returns the next data message sent by the server. Once the server has
+// finished the call and all of its data messages have been received, Recv
+// returns io.EOF if the call succeeded, or the call's error otherwise.
+func (s *ClientStream) Recv() ([]byte, error) {
+	select {
+	case data := <-s.rpc.stream.recv:
+		s.consumed()
+		return data, nil
+	case <-s.rpc.doneSignal:
+		// Data messages received before the call ended are still buffered.
+		select {
+		case data := <-s.rpc.stream.recv:
+			s.consumed()
+			return data, nil
+		default:
+		}
+		if s.rpc.err != nil {
+			return nil, s.rpc.err
+		}
+		return nil, io.EOF
+	case <-s.ctx.Done():
+		s.Close()
+		return nil, s.ctx.Err()
+	}
+}
+
+// consumed returns credits to the server, if needed.
+func (s *ClientStream) consumed() {
+	n := s.rpc.stream.consume()
+	if n == 0 || atomic.LoadUint32(&s.rpc.done) > 0 {
+		return
+	}
+	if err := writeCredits(s.conn.c, &s.conn.wlock, s.rpc.id, n, s.flatten); err != nil {
+		s.conn.shutdown("client send stream credits", err)
+	}
+}
+
+// Result waits for the call to finish and returns the call's result. Any data
+// messages that haven't been received yet are discarded.
+func (s *ClientStream) Result() ([]byte, error) {
+	select {
+	case <-s.rpc.doneSignal:
+		return s.rpc.response, s.rpc.err
+	case <-s.ctx.Done():
+		s.Close()
+		return nil, s.ctx.Err()
+	}
+}
+
+// Close ends the call. If the call is still in progress, it is canceled. Close
+// can be called more than once.
+func (s *ClientStream) Close() {
+	s.closeOnce.Do(func() {
+		if !s.conn.abortCall(s.rpc, fmt.Errorf("stream closed")) {
+			// The call already finished.
+			return
+		}
+		if err := writeMessage(s.conn.c, &s.conn.wlock, cancelMessage, s.rpc.id, nil, nil, s.flatten); err != nil {
+			s.conn.shutdown("client send cancel", err)
+		}
+	})
+}
+
+// abortCall ends the provided in-progress call with the provided error. It
+// returns false if the call already ended.
+func (c *clientConnection) abortCall(rpc *call, err error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.removeCall(rpc) {
+		return false
+	}
+	rpc.err = err
+	atomic.StoreUint32(&rpc.done, 1)
+	close(rpc.doneSignal)
+	c.endIfDrained()
+	return true
+}
+
+// ServerStream is the server side of a streaming call. See StreamHandler.
+//
+// Send may be called concurrently with Recv, but neither may be called
+// concurrently with itself.
+type ServerStream struct {
+	ctx     context.Context
+	conn    *serverConnection
+	id      uint64
+	state   *streamState
+	flatten int
+}
+
+// Send sends a data message to the client. It blocks if the client hasn't yet
+// consumed previously sent messages.
+func (s *ServerStream) Send(data []byte) error {
+	if !s.state.acquireCredit(s.ctx, nil) {
+		return s.ctx.Err()
+	}
+	if err := writeCompressible(s.conn.c, &s.conn.wlock, streamDataMessage, s.id, nil, data, s.flatten, &s.conn.cmp); err != nil {
+		s.conn.shutdown("server send stream data", err)
+		return fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+	return nil
+}
+
+// Recv returns the next data message sent by the client, or io.EOF if the
+// client has no more data messages to send.
+func (s *ServerStream) Recv() ([]byte, error) {
+	select {
+	case data, ok := <-s.state.recv:
+		if !ok {
+			return nil, io.EOF
+		}
+		if n := s.state.consume(); n > 0 {
+			if err := writeCredits(s.conn.c, &s.conn.wlock, s.id, n, s.flatten); err != nil {
+				s.conn.shutdown("server send stream credits", err)
+			}
+		}
+		return data, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
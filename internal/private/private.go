@@ -26,7 +26,8 @@ import (
 
 // Run runs a Service Weaver application rooted at a component with
 // interface type rootType and app as the body of the application.
-var Run func(ctx context.Context, rootType reflect.Type, app func(context.Context, any) error) error
+// interceptors, if not nil, is a []weaver.Interceptor.
+var Run func(ctx context.Context, rootType reflect.Type, app func(context.Context, any) error, interceptors any) error
 
 // Get fetches the component with type t with root recorded as the requester.
 var Get func(root any, t reflect.Type) (any, error)
@@ -15,53 +15,539 @@
 package proxy
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
+	"io"
 	"math/rand"
 	"net/http"
 	"net/http/httputil"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/ServiceWeaver/weaver/metrics"
+	imetrics "github.com/ServiceWeaver/weaver/runtime/metrics"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"golang.org/x/exp/slices"
 	"golang.org/x/exp/slog"
 )
 
+// DefaultSet is the name of the backend set used by AddBackend.
+const DefaultSet = ""
+
+// The metrics are labeled by backend, so the metrics of a backend are deleted
+// when the backend is removed. The public metrics package doesn't support
+// deleting metrics, so the runtime metrics package is used instead.
+var (
+	requestCounts = imetrics.RegisterMap[backendLabels](
+		protos.MetricType_COUNTER,
+		"serviceweaver_proxy_request_count",
+		"Count of HTTP requests forwarded to a backend by a Service Weaver proxy",
+		nil,
+	)
+	requestErrorCounts = imetrics.RegisterMap[backendLabels](
+		protos.MetricType_COUNTER,
+		"serviceweaver_proxy_request_error_count",
+		"Count of HTTP requests forwarded to a backend by a Service Weaver proxy that failed",
+		nil,
+	)
+	requestLatencyMicros = imetrics.RegisterMap[backendLabels](
+		protos.MetricType_HISTOGRAM,
+		"serviceweaver_proxy_request_latency_micros",
+		"Duration, in microseconds, of HTTP requests forwarded to a backend by a Service Weaver proxy",
+		metrics.NonNegativeBuckets,
+	)
+	backendHealth = imetrics.RegisterMap[backendLabels](
+		protos.MetricType_GAUGE,
+		"serviceweaver_proxy_backend_healthy",
+		"Whether a backend of a Service Weaver proxy is healthy (1) or not (0)",
+		nil,
+	)
+)
+
+type backendLabels struct {
+	Set     string // backend set (e.g., a deployment id)
+	Backend string // backend address (e.g., "localhost:12345")
+}
+
 // Proxy is an HTTP proxy that forwards traffic to a set of backends.
+//
+// Backends are grouped into named backend sets. Traffic is split between the
+// sets in proportion to their weights, and spread uniformly across the
+// healthy backends of a set. If every set with a positive weight has no
+// healthy backends, traffic is spread uniformly across all healthy backends
+// instead, and if no backend is healthy, across all backends.
+//
+// Requests can also be routed to a backend set based on the value of a
+// header, regardless of the weights of the sets (see RouteHeader). Together,
+// weights and routes let a deployer perform blue/green and canary
+// deployments.
+//
+// A backend is an address (e.g., "localhost:12345"), to which requests are
+// forwarded over HTTP, or an address prefixed with "https://", to which
+// requests are forwarded over HTTPS.
 type Proxy struct {
-	logger   *slog.Logger          // logger
-	reverse  httputil.ReverseProxy // underlying proxy
-	mu       sync.Mutex            // guards backends
-	backends []string              // backend addresses
+	logger  *slog.Logger          // logger
+	reverse httputil.ReverseProxy // underlying proxy
+	client  http.Client           // client used to health check backends
+
+	mu     sync.Mutex             // guards the following
+	sets   map[string]*backendSet // backend sets, by name
+	routes []route                // header-based routes, in priority order
+}
+
+// Options configure a Proxy.
+type Options struct {
+	// TLSConfig is the TLS configuration used to connect to HTTPS backends.
+	// If nil, the default configuration is used.
+	TLSConfig *tls.Config
+}
+
+// backendSet is a named set of backends.
+type backendSet struct {
+	name     string     // name of the set
+	weight   float64    // relative share of traffic
+	backends []*backend // backends
+	requests int64      // number of requests forwarded to the set
+	errors   int64      // number of those requests that failed
+}
+
+// backend is a backend of a proxy.
+type backend struct {
+	set     *backendSet // set containing the backend
+	address string      // address, as passed to AddSetBackend
+	scheme  string      // "http" or "https"
+	host    string      // dialable address
+	labels  backendLabels
+
+	// Metrics. Once the backend is removed, they are no longer exported.
+	requestCount  *imetrics.Metric
+	errorCount    *imetrics.Metric
+	latencyMicros *imetrics.Metric
+	health        *imetrics.Metric
+
+	// The following fields are guarded by Proxy.mu.
+	healthy  bool  // did the latest health check succeed?
+	requests int64 // number of requests forwarded to the backend
+	errors   int64 // number of those requests that failed
+}
+
+// route forwards requests with a given header value to a backend set.
+type route struct {
+	header string // canonical header key
+	value  string // header value
+	set    string // backend set
+}
+
+// backendKey is the context key of the backend a request is forwarded to.
+type backendKey struct{}
+
+// BackendStats holds statistics about a backend of a proxy.
+type BackendStats struct {
+	Set      string // backend set
+	Address  string // backend address
+	Healthy  bool   // did the latest health check succeed?
+	Requests int64  // number of requests forwarded to the backend
+	Errors   int64  // number of those requests that failed
 }
 
 // NewProxy returns a new proxy.
-func NewProxy(logger *slog.Logger) *Proxy {
-	p := &Proxy{logger: logger}
-	p.reverse = httputil.ReverseProxy{Director: p.director}
+func NewProxy(logger *slog.Logger, opts Options) *Proxy {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = opts.TLSConfig
+	p := &Proxy{
+		logger: logger,
+		client: http.Client{Transport: transport},
+		sets:   map[string]*backendSet{},
+	}
+	p.reverse = httputil.ReverseProxy{
+		Director:       p.director,
+		Transport:      transport,
+		ModifyResponse: p.modifyResponse,
+		ErrorHandler:   p.errorHandler,
+	}
 	return p
 }
 
 // ServeHTTP implements the http.Handler interface.
 func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	p.reverse.ServeHTTP(w, r)
+	p.mu.Lock()
+	b := p.pick(r)
+	if b != nil {
+		b.requests++
+		b.set.requests++
+	}
+	p.mu.Unlock()
+	if b == nil {
+		p.logger.Error("proxy", "err", errors.New("no backends"), "url", r.URL)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b.requestCount.Add(1)
+	start := time.Now()
+	p.reverse.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), backendKey{}, b)))
+	b.latencyMicros.Put(float64(time.Since(start).Microseconds()))
 }
 
-// AddBackend adds a backend to the proxy. Note that backends cannot be
-// removed.
+// AddBackend adds a backend to the default backend set.
 func (p *Proxy) AddBackend(backend string) {
+	p.AddSetBackend(DefaultSet, backend)
+}
+
+// AddSetBackend adds a backend to the provided backend set. If the set
+// doesn't exist, it is created with weight 1. The backend is considered
+// healthy until a health check fails.
+func (p *Proxy) AddSetBackend(set, address string) {
+	b := &backend{
+		address: address,
+		scheme:  "http",
+		host:    address,
+		healthy: true,
+		labels:  backendLabels{Set: set, Backend: address},
+	}
+	if host, ok := strings.CutPrefix(address, "https://"); ok {
+		b.scheme, b.host = "https", host
+	} else if host, ok := strings.CutPrefix(address, "http://"); ok {
+		b.host = host
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b.requestCount = requestCounts.Get(b.labels)
+	b.errorCount = requestErrorCounts.Get(b.labels)
+	b.latencyMicros = requestLatencyMicros.Get(b.labels)
+	b.health = backendHealth.Get(b.labels)
+	b.health.Set(1)
+	s := p.set(set)
+	b.set = s
+	s.backends = append(s.backends, b)
+}
+
+// RemoveBackend removes a backend from the provided backend set. If the
+// backend was added to the set multiple times, only one instance of it is
+// removed.
+func (p *Proxy) RemoveBackend(set, address string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.sets[set]
+	if !ok {
+		return
+	}
+	i := slices.IndexFunc(s.backends, func(b *backend) bool { return b.address == address })
+	if i < 0 {
+		return
+	}
+	labels := s.backends[i].labels
+	s.backends = slices.Delete(s.backends, i, i+1)
+	if !slices.ContainsFunc(s.backends, func(b *backend) bool { return b.address == address }) {
+		deleteMetrics(labels)
+	}
+}
+
+// deleteMetrics deletes the metrics of the backend with the provided labels.
+//
+// REQUIRES: Proxy.mu is held, so that a backend with the same labels is not
+// concurrently added.
+func deleteMetrics(labels backendLabels) {
+	requestCounts.Delete(labels)
+	requestErrorCounts.Delete(labels)
+	requestLatencyMicros.Delete(labels)
+	backendHealth.Delete(labels)
+}
+
+// SetWeight sets the weight of the provided backend set, creating the set if
+// it doesn't exist. A set with weight 0 only receives traffic that is routed
+// to it by a header, or if every set with a positive weight is empty.
+func (p *Proxy) SetWeight(set string, weight float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set(set).weight = weight
+}
+
+// RemoveSet removes a backend set, along with all of its backends.
+func (p *Proxy) RemoveSet(set string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.sets[set]
+	if !ok {
+		return
+	}
+	for _, b := range s.backends {
+		deleteMetrics(b.labels)
+	}
+	delete(p.sets, set)
+}
+
+// RouteHeader forwards requests whose header has the provided value to the
+// provided backend set, regardless of the weights of the sets. For example, a
+// deployer can send a canary to a set with weight 0 by routing requests with
+// a "Canary: true" header to it. If a request matches multiple routes, the
+// route added first wins. If the set has no healthy backends, requests are
+// forwarded as if the route didn't exist.
+func (p *Proxy) RouteHeader(header, value, set string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.backends = append(p.backends, backend)
+	header = http.CanonicalHeaderKey(header)
+	for i, r := range p.routes {
+		if r.header == header && r.value == value {
+			p.routes[i].set = set
+			return
+		}
+	}
+	p.routes = append(p.routes, route{header: header, value: value, set: set})
+}
+
+// RemoveRoute removes a route added by RouteHeader.
+func (p *Proxy) RemoveRoute(header, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	header = http.CanonicalHeaderKey(header)
+	i := slices.IndexFunc(p.routes, func(r route) bool {
+		return r.header == header && r.value == value
+	})
+	if i >= 0 {
+		p.routes = slices.Delete(p.routes, i, i+1)
+	}
+}
+
+// Stats returns the number of requests forwarded to the backends in the
+// provided set, and the number of those requests that failed, either because
+// the backend was unreachable or because it returned a 5xx status code.
+func (p *Proxy) Stats(set string) (requests, errors int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok := p.sets[set]; ok {
+		return s.requests, s.errors
+	}
+	return 0, 0
+}
+
+// Backends returns statistics about every backend of the proxy, sorted by set
+// and address.
+func (p *Proxy) Backends() []BackendStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var stats []BackendStats
+	for _, s := range p.sets {
+		for _, b := range s.backends {
+			stats = append(stats, BackendStats{
+				Set:      s.name,
+				Address:  b.address,
+				Healthy:  b.healthy,
+				Requests: b.requests,
+				Errors:   b.errors,
+			})
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Set != stats[j].Set {
+			return stats[i].Set < stats[j].Set
+		}
+		return stats[i].Address < stats[j].Address
+	})
+	return stats
+}
+
+// CheckHealth health checks every backend of the proxy once per interval,
+// until the provided context is cancelled. A backend is healthy if a GET
+// request to the provided path on the backend returns a status code below
+// 500 within the interval.
+func (p *Proxy) CheckHealth(ctx context.Context, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.checkHealth(ctx, path, interval)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkHealth health checks every backend of the proxy once.
+func (p *Proxy) checkHealth(ctx context.Context, path string, timeout time.Duration) {
+	p.mu.Lock()
+	var backends []*backend
+	for _, s := range p.sets {
+		backends = append(backends, s.backends...)
+	}
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, b := range backends {
+		b := b
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := p.probe(ctx, b, path, timeout)
+			if ctx.Err() != nil {
+				// The proxy is shutting down.
+				return
+			}
+
+			p.mu.Lock()
+			changed := b.healthy != (err == nil)
+			b.healthy = err == nil
+			p.mu.Unlock()
+
+			if err == nil {
+				b.health.Set(1)
+			} else {
+				b.health.Set(0)
+			}
+			switch {
+			case changed && err == nil:
+				p.logger.Info("Backend healthy", "set", b.set.name, "backend", b.address)
+			case changed:
+				p.logger.Error("Backend unhealthy", "err", err, "set", b.set.name, "backend", b.address)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// probe sends a health check request to the provided backend.
+func (p *Proxy) probe(ctx context.Context, b *backend, path string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	url := b.scheme + "://" + b.host + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // allow connection reuse
+	if resp.StatusCode >= 500 {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
+// set returns the backend set with the provided name, creating it if needed.
+//
+// REQUIRES: p.mu is held.
+func (p *Proxy) set(name string) *backendSet {
+	s, ok := p.sets[name]
+	if !ok {
+		s = &backendSet{name: name, weight: 1}
+		p.sets[name] = s
+	}
+	return s
+}
+
+// pick picks the backend to forward the provided request to, or nil if there
+// are no backends.
+//
+// REQUIRES: p.mu is held.
+func (p *Proxy) pick(r *http.Request) *backend {
+	// Honor header-based routes.
+	for _, route := range p.routes {
+		if r.Header.Get(route.header) != route.value {
+			continue
+		}
+		if s, ok := p.sets[route.set]; ok {
+			if healthy := s.healthy(); len(healthy) > 0 {
+				return healthy[rand.Intn(len(healthy))]
+			}
+		}
+	}
+
+	// Pick a set in proportion to its weight.
+	var total float64
+	for _, s := range p.sets {
+		if s.weight > 0 && len(s.healthy()) > 0 {
+			total += s.weight
+		}
+	}
+	if total > 0 {
+		x := rand.Float64() * total
+		var last []*backend
+		for _, s := range p.sets {
+			healthy := s.healthy()
+			if s.weight <= 0 || len(healthy) == 0 {
+				continue
+			}
+			last = healthy
+			if x < s.weight {
+				return healthy[rand.Intn(len(healthy))]
+			}
+			x -= s.weight
+		}
+		return last[rand.Intn(len(last))] // guard against rounding errors
+	}
+
+	// Every set with a positive weight has no healthy backends. Fall back to
+	// any healthy backend, or any backend at all.
+	var healthy, all []*backend
+	for _, s := range p.sets {
+		healthy = append(healthy, s.healthy()...)
+		all = append(all, s.backends...)
+	}
+	switch {
+	case len(healthy) > 0:
+		return healthy[rand.Intn(len(healthy))]
+	case len(all) > 0:
+		return all[rand.Intn(len(all))]
+	default:
+		return nil
+	}
+}
+
+// healthy returns the healthy backends in the set.
+//
+// REQUIRES: Proxy.mu is held.
+func (s *backendSet) healthy() []*backend {
+	var healthy []*backend
+	for _, b := range s.backends {
+		if b.healthy {
+			healthy = append(healthy, b)
+		}
+	}
+	return healthy
 }
 
 // director implements a ReverseProxy.Director function [1].
 //
 // [1]: https://pkg.go.dev/net/http/httputil#ReverseProxy
 func (p *Proxy) director(r *http.Request) {
+	b := r.Context().Value(backendKey{}).(*backend)
+	r.URL.Scheme = b.scheme
+	r.URL.Host = b.host
+}
+
+// modifyResponse implements a ReverseProxy.ModifyResponse function [1].
+//
+// [1]: https://pkg.go.dev/net/http/httputil#ReverseProxy
+func (p *Proxy) modifyResponse(r *http.Response) error {
+	if r.StatusCode >= 500 {
+		p.recordError(r.Request)
+	}
+	return nil
+}
+
+// errorHandler implements a ReverseProxy.ErrorHandler function [1].
+//
+// [1]: https://pkg.go.dev/net/http/httputil#ReverseProxy
+func (p *Proxy) errorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	p.logger.Error("proxy", "err", err, "url", r.URL)
+	p.recordError(r)
+	w.WriteHeader(http.StatusBadGateway)
+}
+
+// recordError records that the provided request failed.
+func (p *Proxy) recordError(r *http.Request) {
+	b := r.Context().Value(backendKey{}).(*backend)
+	b.errorCount.Add(1)
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	if len(p.backends) == 0 {
-		p.logger.Error("director", errors.New("no backends"), "url", r.URL)
-		return
-	}
-	r.URL.Scheme = "http" // TODO(mwhittaker): Support HTTPS.
-	r.URL.Host = p.backends[rand.Intn(len(p.backends))]
+	b.errors++
+	b.set.errors++
 }
@@ -0,0 +1,282 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	imetrics "github.com/ServiceWeaver/weaver/runtime/metrics"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/slog"
+)
+
+// startBackend returns the address of an HTTP server that replies with the
+// provided name and status code.
+func startBackend(t *testing.T, name string, code int) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(code)
+		fmt.Fprint(w, name)
+	}))
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().String()
+}
+
+// newProxy returns a new proxy with the provided options.
+func newProxy(opts Options) *Proxy {
+	logger := slog.New(slog.HandlerOptions{Level: slog.LevelError + 1}.NewTextHandler(io.Discard))
+	return NewProxy(logger, opts)
+}
+
+// get sends a request with the provided headers to the proxy and returns the
+// status code and body of the reply.
+func get(t *testing.T, p *Proxy, headers ...string) (int, string) {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	for i := 0; i+1 < len(headers); i += 2 {
+		r.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, r)
+	return w.Code, w.Body.String()
+}
+
+// counts sends n requests to the proxy and returns the number of replies
+// with every body.
+func counts(t *testing.T, p *Proxy, n int, headers ...string) map[string]int {
+	t.Helper()
+	got := map[string]int{}
+	for i := 0; i < n; i++ {
+		_, body := get(t, p, headers...)
+		got[body]++
+	}
+	return got
+}
+
+func TestNoBackends(t *testing.T) {
+	p := newProxy(Options{})
+	if code, _ := get(t, p); code != http.StatusServiceUnavailable {
+		t.Fatalf("code: got %d, want %d", code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestWeights(t *testing.T) {
+	p := newProxy(Options{})
+	p.AddSetBackend("blue", startBackend(t, "blue", http.StatusOK))
+	p.AddSetBackend("green", startBackend(t, "green", http.StatusOK))
+
+	// Send all traffic to blue.
+	p.SetWeight("green", 0)
+	if got := counts(t, p, 100); got["blue"] != 100 {
+		t.Fatalf("weights blue=1, green=0: got %v, want all blue", got)
+	}
+
+	// Split traffic between blue and green.
+	p.SetWeight("green", 1)
+	if got := counts(t, p, 100); got["blue"] == 0 || got["green"] == 0 {
+		t.Fatalf("weights blue=1, green=1: got %v, want blue and green", got)
+	}
+
+	// Send all traffic to green.
+	p.SetWeight("blue", 0)
+	if got := counts(t, p, 100); got["green"] != 100 {
+		t.Fatalf("weights blue=0, green=1: got %v, want all green", got)
+	}
+
+	// Fall back to blue if green has no backends.
+	p.RemoveSet("green")
+	if got := counts(t, p, 100); got["blue"] != 100 {
+		t.Fatalf("green removed: got %v, want all blue", got)
+	}
+}
+
+func TestRemoveBackend(t *testing.T) {
+	p := newProxy(Options{})
+	a, b := startBackend(t, "a", http.StatusOK), startBackend(t, "b", http.StatusOK)
+	p.AddBackend(a)
+	p.AddBackend(b)
+	p.RemoveBackend(DefaultSet, a)
+	if got := counts(t, p, 100); got["b"] != 100 {
+		t.Fatalf("a removed: got %v, want all b", got)
+	}
+	p.RemoveBackend(DefaultSet, b)
+	if code, _ := get(t, p); code != http.StatusServiceUnavailable {
+		t.Fatalf("b removed: got %d, want %d", code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestRemoveBackendFromSet(t *testing.T) {
+	// Add the same backend to two sets, and remove it from one of them.
+	p := newProxy(Options{})
+	a := startBackend(t, "a", http.StatusOK)
+	p.AddSetBackend("blue", a)
+	p.AddSetBackend("green", a)
+	p.RemoveBackend("green", a)
+	want := []BackendStats{{Set: "blue", Address: a, Healthy: true}}
+	if diff := cmp.Diff(want, p.Backends()); diff != "" {
+		t.Fatalf("Backends (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemoveBackendMetrics(t *testing.T) {
+	// backends returns the backends of the provided set that have a request
+	// count metric.
+	backends := func(set string) []string {
+		var backends []string
+		for _, m := range imetrics.Snapshot() {
+			if m.Name == "serviceweaver_proxy_request_count" && m.Labels["set"] == set {
+				backends = append(backends, m.Labels["backend"])
+			}
+		}
+		sort.Strings(backends)
+		return backends
+	}
+
+	p := newProxy(Options{})
+	a, b := startBackend(t, "a", http.StatusOK), startBackend(t, "b", http.StatusOK)
+	const set = "TestRemoveBackendMetrics"
+	p.SetWeight(set, 1)
+	p.AddSetBackend(set, a)
+	p.AddSetBackend(set, a)
+	p.AddSetBackend(set, b)
+	want := []string{a, b}
+	sort.Strings(want)
+	if diff := cmp.Diff(want, backends(set)); diff != "" {
+		t.Fatalf("metrics (-want +got):\n%s", diff)
+	}
+
+	// a was added twice, so its metrics are kept until it is removed twice.
+	p.RemoveBackend(set, a)
+	if diff := cmp.Diff(want, backends(set)); diff != "" {
+		t.Fatalf("a removed once: metrics (-want +got):\n%s", diff)
+	}
+	p.RemoveBackend(set, a)
+	if diff := cmp.Diff([]string{b}, backends(set)); diff != "" {
+		t.Fatalf("a removed twice: metrics (-want +got):\n%s", diff)
+	}
+	p.RemoveSet(set)
+	if got := backends(set); len(got) != 0 {
+		t.Fatalf("set removed: got metrics for %v, want none", got)
+	}
+}
+
+func TestRouteHeader(t *testing.T) {
+	p := newProxy(Options{})
+	p.AddBackend(startBackend(t, "stable", http.StatusOK))
+	p.SetWeight("canary", 0)
+	p.AddSetBackend("canary", startBackend(t, "canary", http.StatusOK))
+	p.RouteHeader("x-canary", "true", "canary")
+
+	if got := counts(t, p, 100); got["stable"] != 100 {
+		t.Fatalf("no header: got %v, want all stable", got)
+	}
+	if got := counts(t, p, 100, "X-Canary", "true"); got["canary"] != 100 {
+		t.Fatalf("canary header: got %v, want all canary", got)
+	}
+	if got := counts(t, p, 100, "X-Canary", "false"); got["stable"] != 100 {
+		t.Fatalf("other header value: got %v, want all stable", got)
+	}
+
+	p.RemoveRoute("X-Canary", "true")
+	if got := counts(t, p, 100, "X-Canary", "true"); got["stable"] != 100 {
+		t.Fatalf("route removed: got %v, want all stable", got)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	p := newProxy(Options{})
+	healthy, unhealthy := startBackend(t, "healthy", http.StatusOK), startBackend(t, "unhealthy", http.StatusInternalServerError)
+	p.AddBackend(healthy)
+	p.AddBackend(unhealthy)
+	p.checkHealth(context.Background(), "/healthz", time.Second)
+
+	want := []BackendStats{
+		{Address: healthy, Healthy: true},
+		{Address: unhealthy, Healthy: false},
+	}
+	if healthy > unhealthy {
+		want[0], want[1] = want[1], want[0]
+	}
+	if diff := cmp.Diff(want, p.Backends()); diff != "" {
+		t.Fatalf("Backends (-want +got):\n%s", diff)
+	}
+	if got := counts(t, p, 100); got["healthy"] != 100 {
+		t.Fatalf("got %v, want all healthy", got)
+	}
+
+	// If every backend is unhealthy, traffic is sent to all of them.
+	p.RemoveBackend(DefaultSet, healthy)
+	if got := counts(t, p, 10); got["unhealthy"] != 10 {
+		t.Fatalf("only unhealthy backends: got %v, want all unhealthy", got)
+	}
+}
+
+func TestHTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.TLS != nil)
+	}))
+	defer server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	p := newProxy(Options{TLSConfig: &tls.Config{RootCAs: roots}})
+	p.AddBackend(server.URL)
+	for i := 0; i < 10; i++ {
+		if code, body := get(t, p); code != http.StatusOK || body != "true" {
+			t.Fatalf("got %d %q, want 200 \"true\"", code, body)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	p := newProxy(Options{})
+	ok, failing := startBackend(t, "ok", http.StatusOK), startBackend(t, "failing", http.StatusInternalServerError)
+	p.AddSetBackend("ok", ok)
+	p.AddSetBackend("failing", failing)
+	p.AddSetBackend("unreachable", "localhost:1")
+	for set, code := range map[string]int{
+		"ok":          http.StatusOK,
+		"failing":     http.StatusInternalServerError,
+		"unreachable": http.StatusBadGateway,
+	} {
+		p.RouteHeader("Set", set, set)
+		for i := 0; i < 3; i++ {
+			if got, _ := get(t, p, "Set", set); got != code {
+				t.Fatalf("set %s: got %d, want %d", set, got, code)
+			}
+		}
+	}
+
+	for set, want := range map[string][2]int64{
+		"ok":          {3, 0},
+		"failing":     {3, 3},
+		"unreachable": {3, 3},
+		"missing":     {0, 0},
+	} {
+		requests, errors := p.Stats(set)
+		if got := [2]int64{requests, errors}; got != want {
+			t.Errorf("Stats(%q): got %v, want %v", set, got, want)
+		}
+	}
+}
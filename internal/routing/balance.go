@@ -0,0 +1,346 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"golang.org/x/exp/slices"
+)
+
+// BalanceOptions configures BalanceByLoad. The zero value is a valid set of
+// options.
+type BalanceOptions struct {
+	// MaxSlicesPerReplica bounds the number of slices in a balanced
+	// assignment to MaxSlicesPerReplica times the number of replicas. Hot
+	// slices are not split beyond this bound. Defaults to 16.
+	MaxSlicesPerReplica int
+
+	// MaxChurn is the maximum fraction of the total load that a single call
+	// to BalanceByLoad is allowed to move between replicas. Defaults to 0.1.
+	MaxChurn float64
+
+	// Imbalance is the ratio between the load of the most loaded replica and
+	// the average replica load above which load is moved between replicas.
+	// Defaults to 1.2.
+	Imbalance float64
+}
+
+// loadedSlice is a slice [start, end) of an assignment, along with the load
+// reported for it.
+type loadedSlice struct {
+	start    uint64                            // start of slice, inclusive
+	end      uint64                            // end of slice, exclusive
+	replicas []string                          // replicas assigned to this slice
+	load     float64                           // total load on the slice
+	splits   []*protos.LoadReport_SubsliceLoad // load distribution, sorted by start
+}
+
+// owner returns the single replica hosting the slice, or "" if the slice is
+// hosted by zero or multiple replicas.
+func (s *loadedSlice) owner() string {
+	if len(s.replicas) != 1 {
+		return ""
+	}
+	return s.replicas[0]
+}
+
+// BalanceByLoad returns an assignment of the provided replicas that evens out
+// the load reported by the replicas for the current assignment curr. loads
+// contains the load reports of the replicas hosting the component, as
+// returned in a LoadReport. Reports that were not computed with respect to
+// curr (i.e., reports with a different version) are ignored.
+//
+// BalanceByLoad performs three steps:
+//
+//  1. Adjacent cold slices owned by the same replica are merged.
+//  2. Hot slices are split in two, using the reported subslice loads to pick
+//     a split point. A slice whose load is dominated by a single hot key
+//     cannot be split.
+//  3. Slices are moved from the most loaded replicas to the least loaded
+//     replicas until the load is balanced, but no more than opts.MaxChurn of
+//     the total load is moved.
+//
+// Slices that are assigned to more than one replica are never moved. If curr
+// does not assign slices to exactly the provided set of replicas, the
+// reported load is meaningless and BalanceByLoad falls back to EqualSlices.
+//
+// If the assignment doesn't need to change, curr is returned. Otherwise, the
+// returned assignment has version curr.Version + 1.
+func BalanceByLoad(curr *protos.Assignment, replicas []string, loads []*protos.LoadReport_ComponentLoad, opts BalanceOptions) *protos.Assignment {
+	if len(replicas) == 0 {
+		return curr
+	}
+	if !sameReplicas(curr, replicas) {
+		assignment := EqualSlices(replicas)
+		assignment.Version = curr.Version + 1
+		return assignment
+	}
+	if opts.MaxSlicesPerReplica <= 0 {
+		opts.MaxSlicesPerReplica = 16
+	}
+	if opts.MaxChurn <= 0 {
+		opts.MaxChurn = 0.1
+	}
+	if opts.Imbalance <= 1 {
+		opts.Imbalance = 1.2
+	}
+
+	slices := loadedSlices(curr, loads)
+	var total float64
+	for _, s := range slices {
+		total += s.load
+	}
+	if total == 0 {
+		// Without load, there is nothing to balance.
+		return curr
+	}
+	avg := total / float64(len(replicas))
+
+	// A slice is cold if it carries less than 10% of a replica's fair share
+	// of load, and hot if it carries more than 50%. Hot slices are split so
+	// that load can be moved between replicas at a finer granularity.
+	merged, slices := mergeCold(slices, avg/10)
+	split, slices := splitHot(slices, avg/2, opts.MaxSlicesPerReplica*len(replicas))
+	moved := moveLoad(slices, replicas, avg, opts.Imbalance, opts.MaxChurn*total)
+	if !merged && !split && !moved {
+		return curr
+	}
+
+	assignment := &protos.Assignment{Version: curr.Version + 1}
+	for _, s := range slices {
+		assignment.Slices = append(assignment.Slices, &protos.Assignment_Slice{
+			Start:    s.start,
+			Replicas: s.replicas,
+		})
+	}
+	return assignment
+}
+
+// sameReplicas returns whether the assignment assigns slices to exactly the
+// provided set of replicas.
+func sameReplicas(assignment *protos.Assignment, replicas []string) bool {
+	want := map[string]bool{}
+	for _, replica := range replicas {
+		want[replica] = true
+	}
+	got := map[string]bool{}
+	for _, slice := range assignment.Slices {
+		for _, replica := range slice.Replicas {
+			if !want[replica] {
+				return false
+			}
+			got[replica] = true
+		}
+	}
+	return len(got) == len(want)
+}
+
+// loadedSlices returns the slices of the provided assignment, annotated with
+// the load reported for the assignment.
+func loadedSlices(assignment *protos.Assignment, loads []*protos.LoadReport_ComponentLoad) []*loadedSlice {
+	n := len(assignment.Slices)
+	slices := make([]*loadedSlice, n)
+	for i, s := range assignment.Slices {
+		var end uint64 = math.MaxUint64
+		if i < n-1 {
+			end = assignment.Slices[i+1].Start
+		}
+		slices[i] = &loadedSlice{start: s.Start, end: end, replicas: s.Replicas}
+	}
+
+	for _, load := range loads {
+		if load.Version != assignment.Version {
+			// The load was collected for a different assignment.
+			continue
+		}
+		for _, l := range load.Load {
+			i := sort.Search(n, func(i int) bool { return slices[i].start >= l.Start })
+			if i == n || slices[i].start != l.Start || slices[i].end != l.End {
+				continue
+			}
+			slices[i].load += l.Load
+			slices[i].splits = append(slices[i].splits, l.Splits...)
+		}
+	}
+
+	// Different replicas may report subslices with the same start. Sort the
+	// subslices and combine the duplicates.
+	for _, s := range slices {
+		sort.SliceStable(s.splits, func(i, j int) bool {
+			return s.splits[i].Start < s.splits[j].Start
+		})
+		var splits []*protos.LoadReport_SubsliceLoad
+		for _, split := range s.splits {
+			if k := len(splits); k > 0 && splits[k-1].Start == split.Start {
+				splits[k-1].Load += split.Load
+				continue
+			}
+			splits = append(splits, &protos.LoadReport_SubsliceLoad{Start: split.Start, Load: split.Load})
+		}
+		s.splits = splits
+	}
+	return slices
+}
+
+// mergeCold merges adjacent slices that are owned by the same replica if
+// their combined load is less than the provided threshold. It returns whether
+// any slices were merged, along with the resulting slices.
+func mergeCold(slices []*loadedSlice, threshold float64) (bool, []*loadedSlice) {
+	merged := false
+	result := []*loadedSlice{slices[0]}
+	for _, s := range slices[1:] {
+		last := result[len(result)-1]
+		if last.owner() == "" || last.owner() != s.owner() || last.load+s.load >= threshold {
+			result = append(result, s)
+			continue
+		}
+		last.end = s.end
+		last.load += s.load
+		last.splits = append(last.splits, s.splits...)
+		merged = true
+	}
+	return merged, result
+}
+
+// splitHot repeatedly splits slices with a load larger than the provided
+// threshold in two, until no more hot slices can be split or there are
+// maxSlices slices. It returns whether any slices were split, along with the
+// resulting slices.
+func splitHot(ss []*loadedSlice, threshold float64, maxSlices int) (bool, []*loadedSlice) {
+	split := false
+	for len(ss) < maxSlices {
+		// Find the hottest slice that can be split.
+		hottest := -1
+		for i, s := range ss {
+			if s.load > threshold && len(s.splits) > 1 && (hottest == -1 || s.load > ss[hottest].load) {
+				hottest = i
+			}
+		}
+		if hottest == -1 {
+			break
+		}
+		left, right := halve(ss[hottest])
+		ss = slices.Insert(ss, hottest+1, right)
+		ss[hottest] = left
+		split = true
+	}
+	return split, ss
+}
+
+// halve splits the provided slice in two slices of roughly equal load, using
+// the slice's subslices to choose a split point.
+//
+// REQUIRES: len(s.splits) > 1
+func halve(s *loadedSlice) (*loadedSlice, *loadedSlice) {
+	var sum float64
+	for _, split := range s.splits {
+		sum += split.Load
+	}
+
+	// Pick the split point k that minimizes the difference between the load
+	// of the subslices [0, k) and [k, n).
+	k, best, prefix := 1, math.Inf(1), 0.0
+	for i := 1; i < len(s.splits); i++ {
+		prefix += s.splits[i-1].Load
+		if diff := math.Abs(sum - 2*prefix); diff < best {
+			k, best = i, diff
+		}
+	}
+
+	var leftLoad float64
+	for _, split := range s.splits[:k] {
+		leftLoad += split.Load
+	}
+
+	// The subslice loads are estimates and may not sum up to the load of the
+	// slice exactly, so we scale them.
+	ratio := 0.5
+	if sum > 0 {
+		ratio = leftLoad / sum
+	}
+	mid := s.splits[k].Start
+	left := &loadedSlice{
+		start:    s.start,
+		end:      mid,
+		replicas: s.replicas,
+		load:     s.load * ratio,
+		splits:   s.splits[:k],
+	}
+	right := &loadedSlice{
+		start:    mid,
+		end:      s.end,
+		replicas: s.replicas,
+		load:     s.load * (1 - ratio),
+		splits:   s.splits[k:],
+	}
+	return left, right
+}
+
+// moveLoad moves slices from the most loaded replicas to the least loaded
+// replicas, until the most loaded replica carries no more than imbalance
+// times the average load avg, or until budget load has been moved. It
+// returns whether any slices were moved.
+func moveLoad(ss []*loadedSlice, replicas []string, avg, imbalance, budget float64) bool {
+	// Sort the replicas to make the choice of replicas deterministic.
+	replicas = slices.Clone(replicas)
+	sort.Strings(replicas)
+	load := map[string]float64{}
+	for _, s := range ss {
+		if owner := s.owner(); owner != "" {
+			load[owner] += s.load
+		}
+	}
+
+	moved := false
+	for {
+		hi, lo := replicas[0], replicas[0]
+		for _, replica := range replicas {
+			if load[replica] > load[hi] {
+				hi = replica
+			}
+			if load[replica] < load[lo] {
+				lo = replica
+			}
+		}
+		if load[hi] <= imbalance*avg {
+			return moved
+		}
+
+		// Move the slice that best evens out the load of hi and lo. Moving a
+		// slice with load x leaves a difference of |gap - 2x| between them.
+		gap := load[hi] - load[lo]
+		var best *loadedSlice
+		for _, s := range ss {
+			if s.owner() != hi || s.load <= 0 || s.load > budget {
+				continue
+			}
+			if best == nil || math.Abs(gap-2*s.load) < math.Abs(gap-2*best.load) {
+				best = s
+			}
+		}
+		if best == nil || math.Abs(gap-2*best.load) >= gap {
+			// No slice improves the balance.
+			return moved
+		}
+		best.replicas = []string{lo}
+		load[hi] -= best.load
+		load[lo] += best.load
+		budget -= best.load
+		moved = true
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// twoReplicas is an assignment of four slices to replicas "a" and "b".
+func twoReplicas() *protos.Assignment {
+	return &protos.Assignment{
+		Slices: []*protos.Assignment_Slice{
+			{Start: 0, Replicas: []string{"a"}},
+			{Start: 100, Replicas: []string{"b"}},
+			{Start: 200, Replicas: []string{"a"}},
+			{Start: 300, Replicas: []string{"b"}},
+		},
+		Version: 1,
+	}
+}
+
+func sliceLoad(start, end uint64, load float64, splits ...uint64) *protos.LoadReport_SliceLoad {
+	l := &protos.LoadReport_SliceLoad{Start: start, End: end, Load: load}
+	for _, split := range splits {
+		l.Splits = append(l.Splits, &protos.LoadReport_SubsliceLoad{
+			Start: split,
+			Load:  load / float64(len(splits)),
+		})
+	}
+	return l
+}
+
+func TestBalanceByLoadNoLoad(t *testing.T) {
+	curr := twoReplicas()
+	got := BalanceByLoad(curr, []string{"a", "b"}, nil, BalanceOptions{})
+	if got != curr {
+		t.Fatalf("BalanceByLoad: got %v, want unchanged assignment", FormatAssignment(got))
+	}
+}
+
+func TestBalanceByLoadBalanced(t *testing.T) {
+	curr := twoReplicas()
+	loads := []*protos.LoadReport_ComponentLoad{
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(0, 100, 10, 0, 50),
+			sliceLoad(200, 300, 10, 200, 250),
+		}},
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(100, 200, 10, 100, 150),
+			sliceLoad(300, math.MaxUint64, 10, 300, 350),
+		}},
+	}
+	got := BalanceByLoad(curr, []string{"a", "b"}, loads, BalanceOptions{})
+	if got != curr {
+		t.Fatalf("BalanceByLoad: got %v, want unchanged assignment", FormatAssignment(got))
+	}
+}
+
+func TestBalanceByLoadStaleLoad(t *testing.T) {
+	curr := twoReplicas()
+	loads := []*protos.LoadReport_ComponentLoad{
+		{Version: 0, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(0, 100, 1000, 0, 50),
+		}},
+	}
+	got := BalanceByLoad(curr, []string{"a", "b"}, loads, BalanceOptions{})
+	if got != curr {
+		t.Fatalf("BalanceByLoad: got %v, want unchanged assignment", FormatAssignment(got))
+	}
+}
+
+func TestBalanceByLoadNewReplicas(t *testing.T) {
+	got := BalanceByLoad(twoReplicas(), []string{"a", "b", "c"}, nil, BalanceOptions{})
+	want := EqualSlices([]string{"a", "b", "c"})
+	want.Version = 2
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Fatalf("BalanceByLoad: (-want +got):\n%s", diff)
+	}
+}
+
+func TestBalanceByLoadSplitsAndMovesHotSlice(t *testing.T) {
+	// Replica "a" owns a hot slice [0, 100) with load evenly spread over
+	// [0, 50) and [50, 100). The slice is split at 50 and [0, 50) is moved to
+	// replica "b".
+	curr := twoReplicas()
+	loads := []*protos.LoadReport_ComponentLoad{
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(0, 100, 80, 0, 50),
+			sliceLoad(200, 300, 10, 200, 250),
+		}},
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(100, 200, 10, 100, 150),
+			sliceLoad(300, math.MaxUint64, 10, 300, 350),
+		}},
+	}
+	got := BalanceByLoad(curr, []string{"a", "b"}, loads, BalanceOptions{MaxChurn: 0.5})
+	want := &protos.Assignment{
+		Slices: []*protos.Assignment_Slice{
+			{Start: 0, Replicas: []string{"b"}},
+			{Start: 50, Replicas: []string{"a"}},
+			{Start: 100, Replicas: []string{"b"}},
+			{Start: 200, Replicas: []string{"a"}},
+			{Start: 300, Replicas: []string{"b"}},
+		},
+		Version: 2,
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Fatalf("BalanceByLoad: (-want +got):\n%s", diff)
+	}
+}
+
+func TestBalanceByLoadBoundedChurn(t *testing.T) {
+	// The hot slice is split, but moving either half of it would exceed the
+	// churn budget, so both halves stay on replica "a".
+	curr := twoReplicas()
+	loads := []*protos.LoadReport_ComponentLoad{
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(0, 100, 80, 0, 50),
+			sliceLoad(200, 300, 10, 200, 250),
+		}},
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(100, 200, 10, 100, 150),
+			sliceLoad(300, math.MaxUint64, 10, 300, 350),
+		}},
+	}
+	got := BalanceByLoad(curr, []string{"a", "b"}, loads, BalanceOptions{MaxChurn: 0.1})
+	for _, slice := range got.Slices {
+		if slice.Start < 100 && slice.Replicas[0] != "a" {
+			t.Fatalf("BalanceByLoad: hot slice moved despite churn budget:\n%s", FormatAssignment(got))
+		}
+	}
+}
+
+func TestBalanceByLoadHotKey(t *testing.T) {
+	// A single hot key can't be split. The hot slice is moved as a whole
+	// only if that improves the balance, which it doesn't.
+	curr := twoReplicas()
+	loads := []*protos.LoadReport_ComponentLoad{
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(0, 100, 80, 0),
+		}},
+	}
+	got := BalanceByLoad(curr, []string{"a", "b"}, loads, BalanceOptions{MaxChurn: 1})
+	if got != curr {
+		t.Fatalf("BalanceByLoad: got %v, want unchanged assignment", FormatAssignment(got))
+	}
+}
+
+func TestBalanceByLoadMergesColdSlices(t *testing.T) {
+	curr := &protos.Assignment{
+		Slices: []*protos.Assignment_Slice{
+			{Start: 0, Replicas: []string{"a"}},
+			{Start: 100, Replicas: []string{"a"}},
+			{Start: 200, Replicas: []string{"b"}},
+		},
+		Version: 1,
+	}
+	loads := []*protos.LoadReport_ComponentLoad{
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{
+			sliceLoad(0, 100, 0.1, 0),
+			sliceLoad(100, 200, 0.1, 100),
+			sliceLoad(200, math.MaxUint64, 4, 200),
+		}},
+	}
+	got := BalanceByLoad(curr, []string{"a", "b"}, loads, BalanceOptions{})
+	want := &protos.Assignment{
+		Slices: []*protos.Assignment_Slice{
+			{Start: 0, Replicas: []string{"a"}},
+			{Start: 200, Replicas: []string{"b"}},
+		},
+		Version: 2,
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Fatalf("BalanceByLoad: (-want +got):\n%s", diff)
+	}
+}
@@ -27,9 +27,11 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	imetrics "github.com/ServiceWeaver/weaver/internal/metrics"
+	"github.com/ServiceWeaver/weaver/runtime/callgraph"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
 	"github.com/ServiceWeaver/weaver/runtime/metrics"
@@ -120,7 +122,11 @@ Flags:
 			if err != nil {
 				return err
 			}
-			dashboard := &dashboard{spec, r}
+			dashboard := &dashboard{
+				spec:     spec,
+				registry: r,
+				graphs:   map[string]*callgraph.Graph{},
+			}
 			http.HandleFunc("/", dashboard.handleIndex)
 			http.HandleFunc("/favicon.ico", http.NotFound)
 			http.HandleFunc("/deployment", dashboard.handleDeployment)
@@ -151,6 +157,9 @@ Flags:
 type dashboard struct {
 	spec     *DashboardSpec // e.g., "weaver multi" or "weaver single"
 	registry *Registry      // registry of deployments
+
+	mu     sync.Mutex
+	graphs map[string]*callgraph.Graph // component graphs, by deployment id
 }
 
 // handleIndex handles requests to /
@@ -234,7 +243,7 @@ func (d *dashboard) handleDeployment(w http.ResponseWriter, r *http.Request) {
 	}{
 		Status:   status,
 		Tool:     d.spec.Tool,
-		Traffic:  computeTraffic(status, metrics.Metrics),
+		Traffic:  addStaticEdges(status, d.callgraph(id, status), computeTraffic(status, metrics.Metrics)),
 		Commands: d.spec.Commands(id),
 	}
 	if err := deploymentTemplate.Execute(w, content); err != nil {
@@ -281,6 +290,54 @@ func computeTraffic(status *Status, metrics []*protos.MetricSnapshot) []edge {
 	return edges
 }
 
+// callgraph returns the static component graph embedded in the binary of the
+// provided deployment, or nil if the binary is not available. A deployment's
+// binary doesn't change, so the graph is read once and cached.
+func (d *dashboard) callgraph(id string, status *Status) *callgraph.Graph {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if graph, ok := d.graphs[id]; ok {
+		return graph
+	}
+	var graph *callgraph.Graph
+	if status.Config != nil && status.Config.Binary != "" {
+		// Ignore errors. The binary may not be available on this machine.
+		graph, _ = callgraph.ReadBinary(status.Config.Binary)
+	}
+	d.graphs[id] = graph
+	return graph
+}
+
+// addStaticEdges adds to the provided traffic graph the edges of the static
+// component graph that have not received any traffic. These edges have a
+// value of zero.
+func addStaticEdges(status *Status, graph *callgraph.Graph, traffic []edge) []edge {
+	if graph == nil {
+		return traffic
+	}
+
+	// Only add edges between components that appear on the dashboard.
+	components := map[string]bool{}
+	for _, component := range status.Components {
+		components[component.Name] = true
+	}
+	type pair struct {
+		caller    string
+		component string
+	}
+	seen := map[pair]bool{}
+	for _, e := range traffic {
+		seen[pair{e.Source, e.Target}] = true
+	}
+	for _, e := range graph.Edges {
+		if !components[e.Caller] || !components[e.Callee] || seen[pair{e.Caller, e.Callee}] {
+			continue
+		}
+		traffic = append(traffic, edge{Source: e.Caller, Target: e.Callee})
+	}
+	return traffic
+}
+
 // handleMetrics handles requests to /metrics?id=<deployment id>
 func (d *dashboard) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	// TODO(mwhittaker): Change to /<deployment id>/metrics?
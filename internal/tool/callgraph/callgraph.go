@@ -0,0 +1,82 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package callgraph implements the "weaver callgraph" command.
+package callgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ServiceWeaver/weaver/runtime/callgraph"
+)
+
+const Usage = `Print the component graph of a Service Weaver binary.
+
+Usage:
+  weaver callgraph [-format=dot|json] <binary>
+
+Description:
+  "weaver callgraph" prints the static component graph that "weaver generate"
+  embeds in a Service Weaver binary. The graph has one node for every
+  component and an edge from component A to component B if the implementation
+  of A has a weaver.Ref[B] field. The graph is read from the binary, so the
+  application does not have to be running.
+
+  By default, the graph is printed in the Graphviz DOT format. With
+  -format=json, the graph is printed as JSON, including the methods of every
+  component.
+
+  Components that form a cycle of weaver.Ref fields are reported on stderr.
+
+Examples:
+  # Render the component graph of ./app as an SVG image.
+  weaver callgraph ./app | dot -Tsvg > app.svg
+
+  # Print the component graph of ./app as JSON.
+  weaver callgraph -format=json ./app`
+
+// Run prints the component graph of the provided binary to stdout in the
+// provided format ("dot" or "json"). Cycles are reported to stderr.
+func Run(stdout, stderr io.Writer, binary, format string) error {
+	graph, err := callgraph.ReadBinary(binary)
+	if err != nil {
+		return err
+	}
+	if len(graph.Components) == 0 {
+		return fmt.Errorf("no components found in %q. Did you run \"weaver generate\"?", binary)
+	}
+
+	switch format {
+	case "dot":
+		if _, err := fmt.Fprint(stdout, graph.DOT()); err != nil {
+			return err
+		}
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(graph); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format %q; want \"dot\" or \"json\"", format)
+	}
+
+	for _, cycle := range graph.Cycles() {
+		fmt.Fprintf(stderr, "cycle: %s\n", strings.Join(cycle, ", "))
+	}
+	return nil
+}
@@ -27,12 +27,15 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 	"unicode"
 
 	"github.com/ServiceWeaver/weaver/internal/files"
+	"github.com/ServiceWeaver/weaver/runtime/callgraph"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/colors"
 	"golang.org/x/exp/maps"
 	"golang.org/x/tools/go/packages"
@@ -49,7 +52,7 @@ const (
 	Usage = `Generate code for a Service Weaver application.
 
 Usage:
-  weaver generate [packages]
+  weaver generate [-fakes] [-schema=<file>] [packages]
 
 Description:
   "weaver generate" generates code for the Service Weaver applications in the
@@ -70,6 +73,16 @@ Description:
 
   and then use the normal "go generate" command.
 
+  If the -fakes flag is provided, "weaver generate" also generates a fake
+  implementation of every component interface Foo, called FakeFoo (or
+  fakeFoo if Foo is unexported), for use with weavertest.Fake. Fakes are not
+  generated by default, so that they don't end up in production binaries.
+
+  The generated code embeds the schema of every package's components and
+  weaver.AutoMarshal structs in the application binary. If the -schema flag
+  is provided, "weaver generate" also writes the schema of the provided
+  packages to the given file. Schemas are compared by "weaver check-compat".
+
 Examples:
   # Generate code for the package in the current directory.
   weaver generate
@@ -81,13 +94,24 @@ Examples:
   weaver generate ./foo
 
   # Generate code for all packages in all subdirectories of current directory.
-  weaver generate ./...`
+  weaver generate ./...
+
+  # Also write the schema of all components to schema.json.
+  weaver generate -schema=schema.json ./...`
 )
 
 // Options controls the operation of Generate.
 type Options struct {
 	// If non-nil, use the specified function to report warnings.
 	Warn func(error)
+
+	// If true, generate fake implementations of the components, for use with
+	// weavertest.Fake.
+	Fakes bool
+
+	// If non-empty, the schema of the components in the generated packages
+	// is written to the named file. See CheckCompat.
+	Schema string
 }
 
 // Generate generates Service Weaver code for the specified packages.
@@ -99,7 +123,7 @@ func Generate(dir string, pkgs []string, opt Options) error {
 	}
 	fset := token.NewFileSet()
 	cfg := &packages.Config{
-		Mode:       packages.NeedName | packages.NeedSyntax | packages.NeedImports | packages.NeedTypes | packages.NeedTypesInfo,
+		Mode:       packages.NeedName | packages.NeedSyntax | packages.NeedImports | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedModule,
 		Dir:        dir,
 		Fset:       fset,
 		ParseFile:  parseNonWeaverGenFile,
@@ -112,6 +136,8 @@ func Generate(dir string, pkgs []string, opt Options) error {
 
 	var automarshals typeutil.Map
 	var errs []error
+	var refData strings.Builder
+	schema := &Schema{Components: []ComponentSchema{}}
 	for _, pkg := range pkgList {
 		g, err := newGenerator(opt, pkg, fset, &automarshals)
 		if err != nil {
@@ -120,9 +146,31 @@ func Generate(dir string, pkgs []string, opt Options) error {
 		}
 		if err := g.generate(); err != nil {
 			errs = append(errs, err)
+			continue
+		}
+		for _, comp := range g.components {
+			refData.WriteString(comp.refData())
+		}
+		if opt.Schema != "" {
+			schema.Components = append(schema.Components, g.schema()...)
+			schema.Types = append(schema.Types, g.registeredTypes()...)
 		}
 	}
-	return errors.Join(errs...)
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	// Warn the user about component cycles. When the components in a cycle
+	// are co-located, constructing any one of them requires constructing
+	// itself, which deadlocks.
+	for _, cycle := range callgraph.Extract([]byte(refData.String())).Cycles() {
+		opt.Warn(fmt.Errorf("WARNING: Components %s form a cycle of weaver.Ref fields. Components in a cycle deadlock when they are constructed in the same process.", strings.Join(cycle, ", ")))
+	}
+
+	if opt.Schema != "" {
+		return writeSchema(opt.Schema, schema)
+	}
+	return nil
 }
 
 // parseNonWeaverGenFile parses a Go file, except for weaver_gen.go files whose
@@ -140,6 +188,7 @@ type generator struct {
 	tset           *typeSet
 	fileset        *token.FileSet
 	components     []*component
+	fakes          bool         // generate fake implementations?
 	sizeFuncNeeded typeutil.Map // types that need a serviceweaver_size_* function
 	generated      typeutil.Map // memo cache for generateEncDecMethodsFor
 }
@@ -206,6 +255,10 @@ func newGenerator(opt Options, pkg *packages.Package, fset *token.FileSet, autom
 			errs = append(errs, errorf(fset, n.Obj().Pos(), "type %v is not serializable\n%w", t, err))
 			continue
 		}
+		if _, err := fieldNumbers(n.Underlying().(*types.Struct)); err != nil {
+			errs = append(errs, errorf(fset, n.Obj().Pos(), "type %v has invalid field numbers\n%w", t, err))
+			continue
+		}
 		tset.automarshals.Set(t, struct{}{})
 	}
 	if err := errors.Join(errs...); err != nil {
@@ -247,11 +300,61 @@ func newGenerator(opt Options, pkg *packages.Package, fset *token.FileSet, autom
 		return nil, err
 	}
 
+	// Find all methods marked as retriable.
+	for _, file := range pkg.Syntax {
+		filename := fset.Position(file.Package).Filename
+		if filepath.Base(filename) == generatedCodeFile {
+			// Ignore weaver_gen.go files.
+			continue
+		}
+
+		retriables, err := findRetriables(pkg, file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, r := range retriables {
+			c, ok := components[componentName(r.intf)]
+			if !ok {
+				errs = append(errs, errorf(fset, r.pos,
+					"weaver.Retriable method %s.%s is not a method of a component interface in the current package.",
+					formatType(pkg, r.intf), r.method))
+				continue
+			}
+			if c.retriable == nil {
+				c.retriable = map[string]bool{}
+			}
+			c.retriable[r.method] = true
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	// Check that the names of the fakes are not already declared.
+	if opt.Fakes {
+		for _, c := range components {
+			name := fakeName(c)
+			if name == "" {
+				continue
+			}
+			if obj := pkg.Types.Scope().Lookup(name); obj != nil {
+				errs = append(errs, errorf(fset, obj.Pos(),
+					"cannot generate fake %s for component %s: %s is already declared",
+					name, c.fullIntfName(), name))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
 	return &generator{
 		pkg:        pkg,
 		tset:       tset,
 		fileset:    fset,
 		components: maps.Values(components),
+		fakes:      opt.Fakes,
 	}, nil
 }
 
@@ -344,25 +447,19 @@ func findAutoMarshals(pkg *packages.Package, f *ast.File) ([]*types.Named, error
 				continue
 			}
 
-			// Ignore generic types. Generic types don't play well with
-			// embedded AutoMarshals. For example, consider the following type
-			// declaration:
+			// Skip generic types. We cannot generate WeaverMarshal and
+			// WeaverUnmarshal methods for a generic type like the following:
 			//
 			//     type Register[A any] struct {
 			//         weaver.AutoMarshal
 			//         a A
 			//     }
 			//
-			// Is Register[A] serializable? It depends on A. Plus, we cannot
-			// really generate WeaverMarshal and WeaverUnmarshal methods for
-			// specific instantiations of Register[A]. Because of these
-			// complications, we ignore generic types.
-			//
-			// TODO(mwhittaker): Handle generics somehow?
+			// Is Register[A] serializable? It depends on A. Instead, every
+			// instantiation of Register (e.g., Register[int]) is checked
+			// separately and is encoded and decoded by generated functions
+			// (see isGenericAutoMarshal).
 			if n.TypeParams() != nil { // generics have non-nil TypeParams()
-				errs = append(errs, errorf(pkg.Fset, spec.Pos(),
-					"generic struct %v cannot embed weaver.AutoMarshal. See serviceweaver.dev/docs.html#serializable-types for more information.",
-					formatType(pkg, n)))
 				continue
 			}
 
@@ -372,6 +469,125 @@ func findAutoMarshals(pkg *packages.Package, f *ast.File) ([]*types.Named, error
 	return automarshals, errors.Join(errs...)
 }
 
+// fieldNumbers returns the field numbers of the fields of the provided struct,
+// indexed by field, if the struct embeds weaver.AutoMarshal and opts into
+// numbered fields. Otherwise, fieldNumbers returns nil. A struct opts into
+// numbered fields by tagging every field with a unique positive field number:
+//
+//	type Pair struct {
+//	    weaver.AutoMarshal
+//	    X int    `weaver:"1"`
+//	    Y string `weaver:"3"` // field 2 was deprecated
+//	}
+//
+// The fields of such a struct are serialized along with their field numbers,
+// which allows fields to be added and removed without breaking compatibility
+// with older versions of the struct. The embedded weaver.AutoMarshal has field
+// number 0.
+func fieldNumbers(s *types.Struct) ([]int, error) {
+	automarshal, tagged := false, false
+	for i := 0; i < s.NumFields(); i++ {
+		if s.Field(i).Embedded() && isWeaverAutoMarshal(s.Field(i).Type()) {
+			automarshal = true
+		} else if _, ok := reflect.StructTag(s.Tag(i)).Lookup("weaver"); ok {
+			tagged = true
+		}
+	}
+	if !automarshal || !tagged {
+		return nil, nil
+	}
+
+	var errs []error
+	nums := make([]int, s.NumFields())
+	fields := map[int]string{}
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if f.Embedded() && isWeaverAutoMarshal(f.Type()) {
+			continue
+		}
+		tag, ok := reflect.StructTag(s.Tag(i)).Lookup("weaver")
+		if !ok {
+			errs = append(errs, fmt.Errorf("field %s has no field number. Either every field or no field of a weaver.AutoMarshal struct must have a `weaver:\"<field number>\"` tag.", f.Name()))
+			continue
+		}
+		num, err := strconv.ParseUint(tag, 10, 32)
+		if err != nil || num == 0 {
+			errs = append(errs, fmt.Errorf("field %s has invalid field number %q. Field numbers must be positive integers.", f.Name(), tag))
+			continue
+		}
+		if other, ok := fields[int(num)]; ok {
+			errs = append(errs, fmt.Errorf("fields %s and %s have the same field number %d", other, f.Name(), num))
+			continue
+		}
+		fields[int(num)] = f.Name()
+		nums[i] = int(num)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return nums, nil
+}
+
+// retriable is a component method marked as retriable.
+type retriable struct {
+	intf   *types.Named // the component interface
+	method string       // the name of the retriable method
+	pos    token.Pos    // the position of the method expression
+}
+
+// findRetriables returns the methods in the provided file that are marked as
+// retriable. For example, findRetriables will find and return Foo.M in the
+// following declaration.
+//
+//	var _ weaver.Retriable = Foo.M
+func findRetriables(pkg *packages.Package, f *ast.File) ([]retriable, error) {
+	var retriables []retriable
+	var errs []error
+	for _, decl := range f.Decls {
+		gendecl, ok := decl.(*ast.GenDecl)
+		if !ok || gendecl.Tok != token.VAR {
+			// This is not a var declaration.
+			continue
+		}
+		for _, spec := range gendecl.Specs {
+			valuespec, ok := spec.(*ast.ValueSpec)
+			if !ok || valuespec.Type == nil {
+				continue
+			}
+			if !isWeaverRetriable(pkg.TypesInfo.TypeOf(valuespec.Type)) {
+				continue
+			}
+			for _, value := range valuespec.Values {
+				// Check that the value is a method expression like Foo.M.
+				sel, ok := value.(*ast.SelectorExpr)
+				var selection *types.Selection
+				if ok {
+					selection, ok = pkg.TypesInfo.Selections[sel]
+				}
+				if !ok || selection.Kind() != types.MethodExpr {
+					errs = append(errs, errorf(pkg.Fset, value.Pos(),
+						"weaver.Retriable value %s is not a method expression of the form Foo.M.",
+						types.ExprString(value)))
+					continue
+				}
+				intf, ok := selection.Recv().(*types.Named)
+				if !ok {
+					errs = append(errs, errorf(pkg.Fset, value.Pos(),
+						"weaver.Retriable method %s is not a method of a named interface type.",
+						types.ExprString(value)))
+					continue
+				}
+				retriables = append(retriables, retriable{
+					intf:   intf,
+					method: selection.Obj().Name(),
+					pos:    value.Pos(),
+				})
+			}
+		}
+	}
+	return retriables, errors.Join(errs...)
+}
+
 // extractComponent attempts to extract a component from the provided TypeSpec.
 // It returns a nil component if the TypeSpec doesn't define a component.
 func extractComponent(opt Options, pkg *packages.Package, file *ast.File, tset *typeSet, spec *ast.TypeSpec) (*component, error) {
@@ -502,6 +718,7 @@ func extractComponent(opt Options, pkg *packages.Package, file *ast.File, tset *
 		router:    router,
 		isMain:    isMain,
 		hasConfig: hasConfig,
+		refs:      findRefs(impl),
 	}
 
 	// Find routing information if needed.
@@ -516,6 +733,26 @@ func extractComponent(opt Options, pkg *packages.Package, file *ast.File, tset *
 	return comp, nil
 }
 
+// findRefs returns the component interfaces T of the weaver.Ref[T] fields of
+// the provided component implementation, in field order.
+func findRefs(impl *types.Named) []*types.Named {
+	s, ok := impl.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	var refs []*types.Named
+	for i := 0; i < s.NumFields(); i++ {
+		t := s.Field(i).Type()
+		if !isWeaverRef(t) {
+			continue
+		}
+		if named, ok := t.(*types.Named).TypeArgs().At(0).(*types.Named); ok {
+			refs = append(refs, named)
+		}
+	}
+	return refs
+}
+
 // component represents a Service Weaver component.
 //
 // A component is divided into an interface and implementation. For example, in
@@ -536,10 +773,17 @@ type component struct {
 	routedMethods map[string]bool // the set of methods with a routing function
 	isMain        bool            // intf is weaver.Main
 	hasConfig     bool            // implementation embeds weaver.WithConfig?
+	retriable     map[string]bool // the set of methods marked as retriable
+	refs          []*types.Named  // components referenced by weaver.Ref[T] fields
 }
 
-// intfName returns the component interface name.
+// intfName returns the component interface name. If the component interface
+// is an instantiation of a generic interface, intfName returns a sanitized
+// name that includes the type arguments (e.g., Store_string_int_4a1e9f21).
 func (c *component) intfName() string {
+	if c.intf.TypeArgs().Len() > 0 {
+		return sanitize(c.intf)
+	}
 	return c.intf.Obj().Name()
 }
 
@@ -550,7 +794,40 @@ func (c *component) implName() string {
 
 // fullIntfName returns the full package-prefixed component interface name.
 func (c *component) fullIntfName() string {
-	return filepath.Join(c.intf.Obj().Pkg().Path(), c.intfName())
+	return componentName(c.intf)
+}
+
+// componentName returns the full package-prefixed name of the component with
+// the provided interface (e.g., github.com/foo/bar/Cache). The name of a
+// component whose interface is an instantiation of a generic interface
+// includes the fully qualified type arguments (e.g.,
+// github.com/foo/bar/Store[string, github.com/foo/bar.Item]).
+func componentName(intf *types.Named) string {
+	name := filepath.Join(intf.Obj().Pkg().Path(), intf.Obj().Name())
+	n := intf.TypeArgs().Len()
+	if n == 0 {
+		return name
+	}
+	args := make([]string, n)
+	for i := 0; i < n; i++ {
+		args[i] = types.TypeString(intf.TypeArgs().At(i), nil)
+	}
+	return fmt.Sprintf("%s[%s]", name, strings.Join(args, ", "))
+}
+
+// refData returns the RefData of the component's registration. See
+// codegen.MakeComponentString and codegen.MakeEdgeString.
+func (c *component) refData() string {
+	methods := make([]string, 0, len(c.methods()))
+	for _, m := range c.methods() {
+		methods = append(methods, m.Name())
+	}
+	var b strings.Builder
+	b.WriteString(codegen.MakeComponentString(c.fullIntfName(), methods))
+	for _, ref := range c.refs {
+		b.WriteString(codegen.MakeEdgeString(c.fullIntfName(), componentName(ref)))
+	}
+	return b.String()
 }
 
 // methods returns the component interface's methods.
@@ -569,6 +846,40 @@ func (c *component) methods() []*types.Func {
 	return methods
 }
 
+// streamArg returns T if the last argument of the provided method signature
+// has type *weaver.Stream[T], or nil otherwise.
+func streamArg(sig *types.Signature) types.Type {
+	if sig.Params().Len() < 2 || sig.Variadic() {
+		return nil
+	}
+	return streamElem(sig.Params().At(sig.Params().Len() - 1).Type())
+}
+
+// streamResult returns T if the provided method signature has type
+// func(...) (*weaver.Stream[T], error), or nil otherwise.
+func streamResult(sig *types.Signature) types.Type {
+	if sig.Results().Len() != 2 {
+		return nil
+	}
+	return streamElem(sig.Results().At(0).Type())
+}
+
+// isStreaming returns whether the provided method signature streams any of
+// its arguments or results.
+func isStreaming(sig *types.Signature) bool {
+	return streamArg(sig) != nil || streamResult(sig) != nil
+}
+
+// hasStreams returns whether any of the component's methods is streaming.
+func (c *component) hasStreams() bool {
+	for _, m := range c.methods() {
+		if isStreaming(m.Type().(*types.Signature)) {
+			return true
+		}
+	}
+	return false
+}
+
 // validateMethods validates that the provided component's methods are all
 // valid component methods.
 func validateMethods(pkg *packages.Package, tset *typeSet, intf *types.Named) error {
@@ -603,9 +914,22 @@ func validateMethods(pkg *packages.Package, tset *typeSet, intf *types.Named) er
 			errs = append(errs, bad("argument", "The first argument must have type context.Context."))
 		}
 
-		// All arguments but context.Context must be serializable.
+		// All arguments but context.Context must be serializable. The last
+		// argument may be a stream of serializable values.
 		for i := 1; i < t.Params().Len(); i++ {
 			arg := t.Params().At(i)
+			if elem := streamElem(arg.Type()); elem != nil {
+				if i != t.Params().Len()-1 {
+					errs = append(errs, bad("argument",
+						"Argument %d has type %s, but only the last argument can be a stream.",
+						i, formatType(pkg, arg.Type())))
+				} else if err := errors.Join(tset.checkSerializable(elem)...); err != nil {
+					errs = append(errs, bad("argument",
+						"Argument %d is a stream of %s, which is not serializable. Streams must contain serializable values.\n%w",
+						i, formatType(pkg, elem), err))
+				}
+				continue
+			}
 			if err := errors.Join(tset.checkSerializable(arg.Type())...); err != nil {
 				// TODO(mwhittaker): Print a link to documentation on which types are serializable.
 				errs = append(errs, bad("argument",
@@ -621,9 +945,22 @@ func validateMethods(pkg *packages.Package, tset *typeSet, intf *types.Named) er
 			errs = append(errs, bad("return", "The last return must have type error."))
 		}
 
-		// All results but error must be serializable.
+		// All results but error must be serializable. A stream of
+		// serializable values must be the only result besides the error.
 		for i := 0; i < t.Results().Len()-1; i++ {
 			res := t.Results().At(i)
+			if elem := streamElem(res.Type()); elem != nil {
+				if t.Results().Len() != 2 {
+					errs = append(errs, bad("return",
+						"Return %d has type %s, but a stream must be the only return besides the final error.",
+						i, formatType(pkg, res.Type())))
+				} else if err := errors.Join(tset.checkSerializable(elem)...); err != nil {
+					errs = append(errs, bad("return",
+						"Return %d is a stream of %s, which is not serializable. Streams must contain serializable values.\n%w",
+						i, formatType(pkg, elem), err))
+				}
+				continue
+			}
 			if err := errors.Join(tset.checkSerializable(res.Type())...); err != nil {
 				// TODO(mwhittaker): Print a link to documentation on which types are serializable.
 				errs = append(errs, bad("return",
@@ -704,6 +1041,14 @@ func routerMethods(pkg *packages.Package, intf, router *types.Named) (types.Type
 		}
 		mt := m.Type().(*types.Signature)
 
+		// The arguments of a routed method must be available when the call
+		// is routed, so they can't be streamed.
+		if streamArg(componentMethod) != nil {
+			return nil, nil, errorf(pkg.Fset, pos,
+				"Routing function %q routes a method with a stream argument. Methods with a stream argument cannot be routed.",
+				m.Name())
+		}
+
 		// Router method args must match component method args.
 		if !types.Identical(mt.Params(), componentMethod.Params()) {
 			return nil, nil, errorf(pkg.Fset, pos,
@@ -762,9 +1107,13 @@ func (g *generator) generate() error {
 			fmt.Fprintln(&body, fmt.Sprintf(format, args...))
 		}
 		g.generateRegisteredComponents(fn)
+		if err := g.generateSchema(fn); err != nil {
+			return err
+		}
 		g.generateLocalStubs(fn)
 		g.generateClientStubs(fn)
 		g.generateServerStubs(fn)
+		g.generateFakes(fn)
 		g.generateAutoMarshalMethods(fn)
 		g.generateRouterMethods(fn)
 		g.generateEncDecMethods(fn)
@@ -838,7 +1187,8 @@ func (g *generator) componentRef(comp *component) string {
 	if comp.isMain {
 		return g.weaver().qualify("Main")
 	}
-	return comp.intfName() // We already checked that interface is in the same package.
+	// We already checked that interface is in the same package.
+	return g.tset.genTypeString(comp.intf)
 }
 
 // generateImports generates code to import all the dependencies.
@@ -872,27 +1222,27 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 		name := comp.intfName()
 
 		// E.g.,
-		//   func(impl any, caller string, tracer trace.Tracer) any {
+		//   func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
 		//       return foo_local_stub{imple: impl.(Foo), tracer: tracer, ...}
 		//   }
-		localStubFn := fmt.Sprintf(`func(impl any, tracer %v) any { return %s_local_stub{impl: impl.(%s), tracer: tracer } }`, g.trace().qualify("Tracer"), notExported(name), g.componentRef(comp))
+		localStubFn := fmt.Sprintf(`func(impl any, tracer %v, interceptor %s) any { return %s_local_stub{impl: impl.(%s), tracer: tracer, interceptor: interceptor } }`, g.trace().qualify("Tracer"), g.codegen().qualify("Interceptor"), notExported(name), g.componentRef(comp))
 
 		// E.g.,
-		//   func(stub *codegen.Stub, caller string) any {
-		//       return Foo_stub{stub: stub, ...}
+		//   func(stub *codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+		//       return Foo_stub{stub: stub, interceptor: interceptor, ...}
 		//   }
 		var b strings.Builder
 		for _, m := range comp.methods() {
 			fmt.Fprintf(&b, ", %sMetrics: %s(%s{Caller: caller, Component: %q, Method: %q})", notExported(m.Name()), g.codegen().qualify("MethodMetricsFor"), g.codegen().qualify("MethodLabels"), comp.fullIntfName(), m.Name())
 		}
-		clientStubFn := fmt.Sprintf(`func(stub %s, caller string) any { return %s_client_stub{stub: stub %s } }`,
-			g.codegen().qualify("Stub"), notExported(name), b.String())
+		clientStubFn := fmt.Sprintf(`func(stub %s, caller string, interceptor %s) any { return %s_client_stub{stub: stub, interceptor: interceptor %s } }`,
+			g.codegen().qualify("Stub"), g.codegen().qualify("Interceptor"), notExported(name), b.String())
 
 		// E.g.,
-		//   func(impl any, addLoad func(uint64, float64)) codegen.Server {
-		//       return foo_server_stub{impl: impl.(Foo), addLoad: addLoad}
+		//   func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+		//       return foo_server_stub{impl: impl.(Foo), addLoad: addLoad, interceptor: interceptor}
 		//   }
-		serverStubFn := fmt.Sprintf(`func(impl any, addLoad func(uint64, float64)) %s { return %s_server_stub{impl: impl.(%s), addLoad: addLoad } }`, g.codegen().qualify("Server"), notExported(name), g.componentRef(comp))
+		serverStubFn := fmt.Sprintf(`func(impl any, addLoad func(uint64, float64), interceptor %s) %s { return %s_server_stub{impl: impl.(%s), addLoad: addLoad, interceptor: interceptor } }`, g.codegen().qualify("Interceptor"), g.codegen().qualify("Server"), notExported(name), g.componentRef(comp))
 
 		// E.g.,
 		//	weaver.Register(weaver.Registration{
@@ -913,6 +1263,25 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 		if comp.router != nil {
 			p(`		Routed: true,`)
 		}
+		if len(comp.retriable) > 0 {
+			var indices []string
+			for i, m := range comp.methods() {
+				if comp.retriable[m.Name()] {
+					indices = append(indices, strconv.Itoa(i))
+				}
+			}
+			p(`		Retriable: []int{%s},`, strings.Join(indices, ", "))
+		}
+		if comp.hasStreams() {
+			var indices []string
+			for i, m := range comp.methods() {
+				if isStreaming(m.Type().(*types.Signature)) {
+					indices = append(indices, strconv.Itoa(i))
+				}
+			}
+			p(`		Streams: []int{%s},`, strings.Join(indices, ", "))
+		}
+		p(`		RefData: %s,`, strconv.Quote(comp.refData()))
 		p(`		LocalStubFn: %s,`, localStubFn)
 		p(`		ClientStubFn: %s,`, clientStubFn)
 		p(`		ServerStubFn: %s,`, serverStubFn)
@@ -921,6 +1290,20 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 	p(`}`)
 }
 
+// generateSchema generates code that embeds the schema of the package's
+// components and registered types in the binary. See "weaver check-compat".
+func (g *generator) generateSchema(p printFn) error {
+	schema, err := g.embeddedSchema()
+	if err != nil || schema == "" {
+		return err
+	}
+	p(``)
+	p(`func init() {`)
+	p(`	%s(%s)`, g.codegen().qualify("EmbedSchema"), strconv.Quote(schema))
+	p(`}`)
+	return nil
+}
+
 // generateLocalStubs generates code that creates stubs for the local components.
 func (g *generator) generateLocalStubs(p printFn) {
 	p(``)
@@ -934,17 +1317,19 @@ func (g *generator) generateLocalStubs(p printFn) {
 		p(`type %s struct{`, stub)
 		p(`	impl %s`, g.componentRef(comp))
 		p(`	tracer %s`, g.trace().qualify("Tracer"))
+		p(`	interceptor %s`, g.codegen().qualify("Interceptor"))
 		p(`}`)
 		for _, m := range comp.methods() {
 			mt := m.Type().(*types.Signature)
 			p(``)
 			p(`func (s %s) %s(%s) (%s) {`, stub, m.Name(), g.args(mt), g.returns(mt))
+			g.generateInterceptedCall(p, m)
 
 			// Create a child span iff tracing is enabled in ctx.
 			p(`	span := %s(ctx)`, g.trace().qualify("SpanFromContext"))
 			p(`	if span.SpanContext().IsValid() {`)
 			p(`		// Create a child span for this method.`)
-			p(`		ctx, span = s.tracer.Start(ctx, "%s.%s.%s", trace.WithSpanKind(trace.SpanKindInternal))`, g.pkg.Name, comp.intfName(), m.Name())
+			p(`		ctx, span = s.tracer.Start(ctx, "%s.%s.%s", trace.WithSpanKind(trace.SpanKindInternal))`, g.pkg.Name, formatType(g.pkg, comp.intf), m.Name())
 			p(`		defer func() {`)
 			p(`			if err != nil {`)
 			p(`				span.RecordError(err)`)
@@ -972,6 +1357,118 @@ func (g *generator) generateLocalStubs(p printFn) {
 	}
 }
 
+// generateInterceptedCall generates code that runs a call of method m of a
+// local or client stub through the stub's interceptor, if any. The call is
+// made on a copy of the stub without an interceptor, so that the call is
+// intercepted only once.
+func (g *generator) generateInterceptedCall(p printFn, m *types.Func) {
+	mt := m.Type().(*types.Signature)
+	var b strings.Builder
+	fmt.Fprintf(&b, "ctx")
+	for i := 1; i < mt.Params().Len(); i++ {
+		if mt.Variadic() && i == mt.Params().Len()-1 {
+			fmt.Fprintf(&b, ", a%d...", i-1)
+		} else {
+			fmt.Fprintf(&b, ", a%d", i-1)
+		}
+	}
+	argList := b.String()
+
+	p(`	if s.interceptor != nil {`)
+	p(`		// Run the call through the interceptor. The arguments and results`)
+	p(`		// are copied, so that only the copies escape to the heap.`)
+	p(`		intercept := s.interceptor`)
+	p(`		s := s`)
+	p(`		s.interceptor = nil`)
+	for i := 1; i < mt.Params().Len(); i++ {
+		p(`		a%d := a%d`, i-1, i-1)
+	}
+	for i := 0; i < mt.Results().Len()-1; i++ {
+		p(`		var r%d %s`, i, g.tset.genTypeString(mt.Results().At(i).Type()))
+	}
+	p(`		err := intercept(ctx, %q, func(ctx context.Context) (err error) {`, m.Name())
+	p(`			%s = s.%s(%s)`, resultList(mt, "r", "err"), m.Name(), argList)
+	p(`			return`)
+	p(`		})`)
+	p(`		return %s`, resultList(mt, "r", "err"))
+	p(`	}`)
+	p(``)
+}
+
+// fakeName returns the name of the fake implementation generated for the
+// provided component, or "" if no fake is generated. Fakes are not generated
+// for the main component, for components without methods, and for
+// instantiations of generic component interfaces.
+func fakeName(comp *component) string {
+	if comp.isMain || len(comp.methods()) == 0 || comp.intf.TypeArgs().Len() > 0 {
+		return ""
+	}
+	if !comp.intf.Obj().Exported() {
+		return "fake" + exported(comp.intfName())
+	}
+	return "Fake" + comp.intfName()
+}
+
+// generateFakes generates fake implementations of the components, for use
+// with weavertest.Fake, if fakes are enabled.
+func (g *generator) generateFakes(p printFn) {
+	if !g.fakes {
+		return
+	}
+	var fakes []*component
+	for _, comp := range g.components {
+		if fakeName(comp) != "" {
+			fakes = append(fakes, comp)
+		}
+	}
+	if len(fakes) == 0 {
+		return
+	}
+
+	p(``)
+	p(``)
+	p(`// Fake implementations.`)
+
+	var b strings.Builder
+	for _, comp := range fakes {
+		fake := fakeName(comp)
+		p(``)
+		p(`// %s is a fake implementation of the %s component.`, fake, comp.intfName())
+		p(`// It is meant to be used with weavertest.Fake. Every method calls the`)
+		p(`// corresponding function field. Methods whose function field is nil return`)
+		p(`// zero values.`)
+		p(`type %s struct{`, fake)
+		for _, m := range comp.methods() {
+			mt := m.Type().(*types.Signature)
+			p(`	%sFn func(%s) (%s)`, m.Name(), g.args(mt), g.returns(mt))
+		}
+		p(`}`)
+		p(``)
+		p(`var _ %s = %s{}`, g.componentRef(comp), fake)
+
+		for _, m := range comp.methods() {
+			mt := m.Type().(*types.Signature)
+			p(``)
+			p(`func (f %s) %s(%s) (%s) {`, fake, m.Name(), g.args(mt), g.returns(mt))
+			p(`	if f.%sFn == nil {`, m.Name())
+			p(`		return`)
+			p(`	}`)
+
+			b.Reset()
+			fmt.Fprintf(&b, "ctx")
+			for i := 1; i < mt.Params().Len(); i++ {
+				if mt.Variadic() && i == mt.Params().Len()-1 {
+					fmt.Fprintf(&b, ", a%d...", i-1)
+				} else {
+					fmt.Fprintf(&b, ", a%d", i-1)
+				}
+			}
+			p(`	return f.%sFn(%s)`, m.Name(), b.String())
+			p(`}`)
+		}
+	}
+}
+
 // generateClientStubs generates code that creates client stubs for the registered components.
 func (g *generator) generateClientStubs(p printFn) {
 	p(``)
@@ -984,6 +1481,7 @@ func (g *generator) generateClientStubs(p printFn) {
 		p(``)
 		p(`type %s struct{`, stub)
 		p(`	stub %s`, g.codegen().qualify("Stub"))
+		p(`	interceptor %s`, g.codegen().qualify("Interceptor"))
 		for _, m := range comp.methods() {
 			p(`	%sMetrics *%s`, notExported(m.Name()), g.codegen().qualify("MethodMetrics"))
 		}
@@ -1004,6 +1502,7 @@ func (g *generator) generateClientStubs(p printFn) {
 			mt := m.Type().(*types.Signature)
 			p(``)
 			p(`func (s %s) %s(%s) (%s) {`, stub, m.Name(), g.args(mt), g.returns(mt))
+			g.generateInterceptedCall(p, m)
 
 			// Update metrics.
 			p(`	// Update metrics.`)
@@ -1015,7 +1514,7 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(`	span := %s(ctx)`, g.trace().qualify("SpanFromContext"))
 			p(`	if span.SpanContext().IsValid() {`)
 			p(`		// Create a child span for this method.`)
-			p(`		ctx, span = s.stub.Tracer().Start(ctx, "%s.%s.%s", trace.WithSpanKind(trace.SpanKindClient))`, g.pkg.Name, comp.intfName(), m.Name())
+			p(`		ctx, span = s.stub.Tracer().Start(ctx, "%s.%s.%s", trace.WithSpanKind(trace.SpanKindClient))`, g.pkg.Name, formatType(g.pkg, comp.intf), m.Name())
 			p(`	}`)
 
 			// Handle cleanup.
@@ -1040,11 +1539,18 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(`	}()`)
 			p(``)
 
+			// A stream argument is sent separately from the other arguments.
+			nparams := mt.Params().Len()
+			argStream, resultStream := streamArg(mt), streamResult(mt)
+			if argStream != nil {
+				nparams--
+			}
+
 			preallocated := false
-			if mt.Params().Len() > 1 {
+			if nparams > 1 {
 				// Preallocate a perfectly sized buffer if possible.
 				canPreallocate := true
-				for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+				for i := 1; i < nparams; i++ { // Skip initial context.Context
 					if !g.preallocatable(mt.Params().At(i).Type()) {
 						canPreallocate = false
 						break
@@ -1054,26 +1560,29 @@ func (g *generator) generateClientStubs(p printFn) {
 					p("")
 					p("	// Preallocate a buffer of the right size.")
 					p("	size := 0")
-					for i := 1; i < mt.Params().Len(); i++ {
+					for i := 1; i < nparams; i++ {
 						at := mt.Params().At(i).Type()
 						p("	size += %s", g.size(fmt.Sprintf("a%d", i-1), at))
 					}
-					p("	enc := %s", g.codegen().qualify("NewEncoder()"))
-					p("	enc.Reset(size)")
+					// The request is sent before s.stub.Run returns, so
+					// the encoder can be returned to the pool afterwards.
+					p("	enc := %s(size)", g.codegen().qualify("GetEncoder"))
+					p("	defer %s(enc)", g.codegen().qualify("PutEncoder"))
 					preallocated = true
 				}
 			}
 
 			// Invoke call.Encode.
 			b.Reset()
-			if mt.Params().Len() > 1 {
+			if nparams > 1 {
 				p(``)
 				p(`	// Encode arguments.`)
 				if !preallocated {
-					p("	enc := %s", g.codegen().qualify("NewEncoder()"))
+					p("	enc := %s(0)", g.codegen().qualify("GetEncoder"))
+					p("	defer %s(enc)", g.codegen().qualify("PutEncoder"))
 				}
 			}
-			for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+			for i := 1; i < nparams; i++ { // Skip initial context.Context
 				at := mt.Params().At(i).Type()
 				arg := fmt.Sprintf("a%d", i-1)
 				p(`	%s`, g.encode("enc", arg, at))
@@ -1099,25 +1608,89 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(``)
 			p(`	// Call the remote method.`)
 			data := "nil"
-			if mt.Params().Len() > 1 {
+			if nparams > 1 {
 				data = "enc.Data()"
 				p(`	s.%sMetrics.BytesRequest.Put(float64(len(enc.Data())))`, notExported(m.Name()))
 			} else {
 				p(`	s.%sMetrics.BytesRequest.Put(0)`, notExported(m.Name()))
 			}
-			p(`	var results []byte`)
-			p(`	results, err = s.stub.Run(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+			if isStreaming(mt) {
+				p(`	var stream %s`, g.codegen().qualify("ClientStream"))
+				p(`	stream, err = s.stub.Stream(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+			} else {
+				p(`	var results []byte`)
+				p(`	results, err = s.stub.Run(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+			}
 			p(`	if err != nil {`)
+			if argStream != nil {
+				// The remote method is the consumer of the stream argument,
+				// and it won't get to close it.
+				p(`		a%d.Close()`, nparams-1)
+			}
 			p(`		err = %s(%s, err)`, g.errorsPackage().qualify("Join"), g.weaver().qualify("RemoteCallError"))
 			p(`		return`)
 			p(`	}`)
+
+			if argStream != nil {
+				arg := fmt.Sprintf("a%d", nparams-1)
+				p(``)
+				p(`	// Send the stream argument.`)
+				send := fmt.Sprintf("%s(stream, %s.Next, %s.Close, %s)", g.codegen().qualify("SendArgs"), arg, arg, g.encodeFunc(argStream))
+				if resultStream != nil {
+					p(`	%s`, send)
+				} else {
+					p(`	argErr := %s`, send)
+				}
+			}
+
+			if resultStream != nil {
+				// The first value sent on the stream holds the error returned
+				// by the method. The remaining values are the values of the
+				// returned stream.
+				p(``)
+				p(`	// Decode the results.`)
+				p(`	var header []byte`)
+				p(`	header, err = stream.Recv()`)
+				p(`	if err == %s {`, g.io().qualify("EOF"))
+				p(`		err = %s(%s, %s)`, g.errorsPackage().qualify("Join"), g.weaver().qualify("RemoteCallError"), g.io().qualify("ErrUnexpectedEOF"))
+				p(`	}`)
+				p(`	if err != nil {`)
+				p(`		stream.Close()`)
+				p(`		return`)
+				p(`	}`)
+				p(`	s.%sMetrics.BytesReply.Put(float64(len(header)))`, notExported(m.Name()))
+				p(`	if err = %s(header).Error(); err != nil {`, g.codegen().qualify("NewDecoder"))
+				p(`		stream.Close()`)
+				p(`		return`)
+				p(`	}`)
+				p(`	r0 = %s(%s(%s(stream), %s), stream.Close)`, g.weaver().qualify("NewStream"), g.codegen().qualify("RecvStream"), g.codegen().qualify("RecvResults"), g.decodeFunc(resultStream))
+				p(`	return`)
+				p(`}`)
+				continue
+			}
+
+			if argStream != nil {
+				p(``)
+				p(`	// Wait for the results.`)
+				p(`	var results []byte`)
+				p(`	results, err = stream.Result()`)
+				p(`	if err != nil {`)
+				p(`		if argErr := argErr(); argErr != nil {`)
+				p(`			// The call was canceled because the stream argument failed.`)
+				p(`			err = argErr`)
+				p(`		}`)
+				p(`		return`)
+				p(`	}`)
+			}
 			p(`	s.%sMetrics.BytesReply.Put(float64(len(results)))`, notExported(m.Name()))
 
 			// Invoke call.Decode.
 			b.Reset()
 			p(``)
 			p(`	// Decode the results.`)
-			p(`	dec := %s(results)`, g.codegen().qualify("NewDecoder"))
+			// The results are read from the network into a fresh buffer
+			// that is never reused, so decoded values can alias it.
+			p(`	dec := %s(results)`, g.codegen().qualify("NewAliasingDecoder"))
 			for i := 0; i < mt.Results().Len()-1; i++ { // Skip final error
 				rt := mt.Results().At(i).Type()
 				res := fmt.Sprintf("r%d", i)
@@ -1142,6 +1715,24 @@ func (g *generator) generateClientStubs(p printFn) {
 	}
 }
 
+// encodeFunc returns a function literal of type func(*codegen.Encoder, t) that
+// encodes a value of type t.
+func (g *generator) encodeFunc(t types.Type) string {
+	return fmt.Sprintf("func(enc *%s, v %s) {\n%s\n}", g.codegen().qualify("Encoder"), g.tset.genTypeString(t), g.encode("enc", "v", t))
+}
+
+// decodeFunc returns a function literal of type func(*codegen.Decoder) t that
+// decodes a value of type t.
+func (g *generator) decodeFunc(t types.Type) string {
+	if x, ok := t.(*types.Pointer); ok && (g.tset.isProto(x) || g.tset.hasMarshalBinary(x)) {
+		// See the comment in generateClientStubs on decoding pointers to
+		// protos and BinaryUnmarshalers.
+		return fmt.Sprintf("func(dec *%s) %s {\nvar tmp %s\n%s\nreturn %s\n}",
+			g.codegen().qualify("Decoder"), g.tset.genTypeString(t), g.tset.genTypeString(x.Elem()), g.decode("dec", ref("tmp"), x.Elem()), ref("tmp"))
+	}
+	return fmt.Sprintf("func(dec *%s) (v %s) {\n%s\nreturn\n}", g.codegen().qualify("Decoder"), g.tset.genTypeString(t), g.decode("dec", ref("v"), t))
+}
+
 // args returns a textual representation of the arguments of the provided
 // signature. The first argument must be a context.Context. The returned code
 // names the first argument ctx and all subsequent arguments a0, a1, and so on.
@@ -1173,6 +1764,17 @@ func (g *generator) returns(sig *types.Signature) string {
 	return fmt.Sprintf("%serr error", returns.String())
 }
 
+// resultList returns a comma-separated list of variables that hold the
+// results of a method with signature sig. Every non-error result i is held in
+// variable <prefix><i>, and the final error is held in variable err.
+func resultList(sig *types.Signature, prefix, err string) string {
+	var results []string
+	for i := 0; i < sig.Results().Len()-1; i++ { // Skip final error
+		results = append(results, fmt.Sprintf("%s%d", prefix, i))
+	}
+	return strings.Join(append(results, err), ", ")
+}
+
 // preallocatable returns whether we can preallocate a buffer of the right size
 // to encode the provided type.
 func (g *generator) preallocatable(t types.Type) bool {
@@ -1235,6 +1837,9 @@ func (g *generator) isWeaverEncoded(t types.Type) bool {
 			}
 			return true
 		}
+		if _, ok := x.Underlying().(*types.Interface); ok {
+			return true
+		}
 		return g.isWeaverEncoded(x.Underlying())
 
 	default:
@@ -1422,12 +2027,24 @@ func (g *generator) generateSizeFunction(p printFn, t types.Type) {
 		//         size += len(x.y)
 		//         return size
 		//     }
+		//
+		// If A has numbered fields, every field is preceded by an 8 byte
+		// header, and the fields are followed by a 4 byte trailer.
 		s := x.Underlying().(*types.Struct)
+		nums, _ := fieldNumbers(s)
 		p("func serviceweaver_size_%s(x *%s) int {", sanitize(t), g.tset.genTypeString(t))
-		p("	size := 0")
+		if nums != nil {
+			p("	size := 4")
+		} else {
+			p("	size := 0")
+		}
 		for i := 0; i < s.NumFields(); i++ {
 			f := s.Field(i)
-			p("	size += %s", g.size(fmt.Sprintf("x.%s", f.Name()), f.Type()))
+			if nums != nil && nums[i] != 0 {
+				p("	size += 8 + %s", g.size(fmt.Sprintf("x.%s", f.Name()), f.Type()))
+			} else {
+				p("	size += %s", g.size(fmt.Sprintf("x.%s", f.Name()), f.Type()))
+			}
 		}
 		p("	return size")
 		p("}")
@@ -1462,12 +2079,16 @@ func (g *generator) generateServerStubs(p printFn) {
 		p(`type %s struct{`, stub)
 		p(`	impl %s`, g.componentRef(comp))
 		p(`	addLoad func(key uint64, load float64)`)
+		p(`	interceptor %s`, g.codegen().qualify("Interceptor"))
 		p(`}`)
 		p(``)
 		p(`// GetStubFn implements the stub.Server interface.`)
 		p(`func (s %s) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {`, stub)
 		p(`	switch method {`)
 		for _, m := range comp.methods() {
+			if isStreaming(m.Type().(*types.Signature)) {
+				continue
+			}
 			p(`	case "%s":`, m.Name())
 			p(`		return s.%s`, notExported(m.Name()))
 		}
@@ -1476,13 +2097,44 @@ func (g *generator) generateServerStubs(p printFn) {
 		p(`	}`)
 		p(`}`)
 
+		if comp.hasStreams() {
+			p(``)
+			p(`// GetStreamStubFn implements the codegen.StreamServer interface.`)
+			p(`func (s %s) GetStreamStubFn(method string) func(ctx context.Context, args []byte, stream %s) ([]byte, error) {`, stub, g.codegen().qualify("ServerStream"))
+			p(`	switch method {`)
+			for _, m := range comp.methods() {
+				if !isStreaming(m.Type().(*types.Signature)) {
+					continue
+				}
+				p(`	case "%s":`, m.Name())
+				p(`		return s.%s`, notExported(m.Name()))
+			}
+			p(`	default:`)
+			p(`		return nil`)
+			p(`	}`)
+			p(`}`)
+		}
+
 		// Generate server stub implementation for the methods exported by the component.
 		for _, m := range comp.methods() {
 			mt := m.Type().(*types.Signature)
 
+			// A stream argument is received separately from the other
+			// arguments.
+			nparams := mt.Params().Len()
+			argStream, resultStream := streamArg(mt), streamResult(mt)
+			if argStream != nil {
+				nparams--
+			}
+
 			p(``)
-			p(`func (s %s) %s(ctx context.Context, args []byte) (res []byte, err error) {`,
-				stub, notExported(m.Name()))
+			if isStreaming(mt) {
+				p(`func (s %s) %s(ctx context.Context, args []byte, stream %s) (res []byte, err error) {`,
+					stub, notExported(m.Name()), g.codegen().qualify("ServerStream"))
+			} else {
+				p(`func (s %s) %s(ctx context.Context, args []byte) (res []byte, err error) {`,
+					stub, notExported(m.Name()))
+			}
 
 			// Handle errors triggered during execution.
 			p(`	// Catch and return any panics detected during encoding/decoding/rpc.`)
@@ -1492,13 +2144,15 @@ func (g *generator) generateServerStubs(p printFn) {
 			p(`		}`)
 			p(`	}()`)
 
-			if mt.Params().Len() > 1 {
+			if nparams > 1 {
 				p(``)
 				p(`	// Decode arguments.`)
-				p(`	dec := %s(args)`, g.codegen().qualify("NewDecoder"))
+				// Like results, args are never reused. See
+				// generateClientStubs.
+				p(`	dec := %s(args)`, g.codegen().qualify("NewAliasingDecoder"))
 			}
 			b.Reset()
-			for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+			for i := 1; i < nparams; i++ { // Skip initial context.Context
 				at := mt.Params().At(i).Type()
 				arg := fmt.Sprintf("a%d", i-1)
 				if x, ok := at.(*types.Pointer); ok && (g.tset.isProto(x) || g.tset.hasMarshalBinary(x)) {
@@ -1516,6 +2170,12 @@ func (g *generator) generateServerStubs(p printFn) {
 				}
 			}
 
+			if argStream != nil {
+				p(``)
+				p(`	// Receive the stream argument.`)
+				p(`	a%d := %s(%s(stream.Recv, %s), nil)`, nparams-1, g.weaver().qualify("NewStream"), g.codegen().qualify("RecvStream"), g.decodeFunc(argStream))
+			}
+
 			b.Reset()
 			fmt.Fprintf(&b, "ctx")
 			for i := 1; i < mt.Params().Len(); i++ {
@@ -1539,21 +2199,51 @@ func (g *generator) generateServerStubs(p printFn) {
 			p(`	// user code: fix this.`)
 			p(`	// Call the local method.`)
 			for i := 0; i < mt.Results().Len()-1; i++ { // Skip final error
-				if b.Len() == 0 {
-					fmt.Fprintf(&b, "r%d", i)
-				} else {
-					fmt.Fprintf(&b, ", r%d", i)
-				}
+				p(`	var r%d %s`, i, g.tset.genTypeString(mt.Results().At(i).Type()))
 			}
-
-			var res string
-			if b.Len() == 0 {
-				res = "appErr"
-			} else {
-				res = fmt.Sprintf("%s, appErr", b.String())
+			p(`	var appErr error`)
+			p(`	if s.interceptor == nil {`)
+			p(`		%s = s.impl.%s(%s)`, resultList(mt, "r", "appErr"), m.Name(), argList)
+			p(`	} else {`)
+			p(`		// Run the call through the interceptor. The arguments and`)
+			p(`		// results are copied, so that only the copies escape to the heap.`)
+			for i := 1; i < mt.Params().Len(); i++ {
+				p(`		a%d := a%d`, i-1, i-1)
+			}
+			for i := 0; i < mt.Results().Len()-1; i++ {
+				p(`		var ir%d %s`, i, g.tset.genTypeString(mt.Results().At(i).Type()))
 			}
+			p(`		appErr = s.interceptor(ctx, %q, func(ctx context.Context) (err error) {`, m.Name())
+			p(`			%s = s.impl.%s(%s)`, resultList(mt, "ir", "err"), m.Name(), argList)
+			p(`			return`)
+			p(`		})`)
+			for i := 0; i < mt.Results().Len()-1; i++ {
+				p(`		r%d = ir%d`, i, i)
+			}
+			p(`	}`)
 
-			p(`	%s := s.impl.%s(%s)`, res, m.Name(), argList)
+			if resultStream != nil {
+				// The first value sent on the stream holds the error returned
+				// by the method. The error of the returned stream, if any, is
+				// encoded in the results.
+				p(`	defer r0.Close()`)
+				p(``)
+				p(`	// Send the results.`)
+				p(`	enc := %s()`, g.codegen().qualify("NewEncoder"))
+				p(`	enc.Error(appErr)`)
+				p(`	if err := stream.Send(enc.Data()); err != nil || appErr != nil {`)
+				p(`		return nil, err`)
+				p(`	}`)
+				p(`	streamErr, err := %s(stream.Send, r0.Next, %s)`, g.codegen().qualify("SendStream"), g.encodeFunc(resultStream))
+				p(`	if err != nil {`)
+				p(`		return nil, err`)
+				p(`	}`)
+				p(`	enc = %s()`, g.codegen().qualify("NewEncoder"))
+				p(`	enc.Error(streamErr)`)
+				p(`	return enc.Data(), nil`)
+				p(`}`)
+				continue
+			}
 
 			p(``)
 			p(`	// Encode the results.`)
@@ -1589,7 +2279,6 @@ func (g *generator) generateAutoMarshalMethods(p printFn) {
 
 	ts := g.tset.genTypeString
 	for _, t := range sorted {
-		var innerTypes []types.Type
 		s := t.Underlying().(*types.Struct)
 
 		// Generate AutoMarshal assertion.
@@ -1603,13 +2292,7 @@ func (g *generator) generateAutoMarshalMethods(p printFn) {
 		p(`	if x == nil {`)
 		p(`		panic(%s("%s.WeaverMarshal: nil receiver"))`, fmt.qualify("Errorf"), ts(t))
 		p(`	}`)
-		for i := 0; i < s.NumFields(); i++ {
-			fi := s.Field(i)
-			if !isWeaverAutoMarshal(fi.Type()) {
-				p(`	%s`, g.encode("enc", "x."+fi.Name(), fi.Type()))
-				innerTypes = append(innerTypes, fi.Type())
-			}
-		}
+		g.encodeFields(p, s)
 		p(`}`)
 
 		// Generate WeaverUnmarshal method.
@@ -1618,19 +2301,93 @@ func (g *generator) generateAutoMarshalMethods(p printFn) {
 		p(`	if x == nil {`)
 		p(`		panic(%s("%s.WeaverUnmarshal: nil receiver"))`, fmt.qualify("Errorf"), ts(t))
 		p(`	}`)
+		g.decodeFields(p, t, s)
+		p(`}`)
+
+		// Generate encoding/decoding methods for any inner types.
+		for i := 0; i < s.NumFields(); i++ {
+			if fi := s.Field(i); !isWeaverAutoMarshal(fi.Type()) {
+				g.generateEncDecMethodsFor(p, fi.Type())
+			}
+		}
+	}
+
+	// Register AutoMarshal types, so that they can be encoded in
+	// interface-typed values. Register AutoMarshal error types, so that errors
+	// of these types keep their types when returned by a component method.
+	if len(sorted) > 0 {
+		p(``)
+		p(`func init() {`)
+		for _, t := range sorted {
+			p(`	%s[%s]()`, g.codegen().qualify("RegisterType"), ts(t))
+			if implementsError(t) {
+				p(`	%s[%s]()`, g.codegen().qualify("RegisterSerializableError"), ts(t))
+			}
+		}
+		p(`}`)
+	}
+}
+
+// encodeFields prints, using p, statements that encode the fields of struct
+// x of type s into enc. Structs with numbered fields encode every field along
+// with its field number, so that readers can skip unknown fields.
+//
+// REQUIRES: s embeds weaver.AutoMarshal and its field numbers, if any, are
+// valid.
+func (g *generator) encodeFields(p printFn, s *types.Struct) {
+	nums, _ := fieldNumbers(s)
+	if nums != nil {
+		p(`	var start int`)
+	}
+	for i := 0; i < s.NumFields(); i++ {
+		fi := s.Field(i)
+		if isWeaverAutoMarshal(fi.Type()) {
+			continue
+		}
+		if nums != nil {
+			p(`	start = enc.BeginField(%d)`, nums[i])
+		}
+		p(`	%s`, g.encode("enc", "x."+fi.Name(), fi.Type()))
+		if nums != nil {
+			p(`	enc.EndField(start)`)
+		}
+	}
+	if nums != nil {
+		p(`	enc.EndFields()`)
+	}
+}
+
+// decodeFields prints, using p, statements that decode the fields of struct
+// x of type t, with underlying type s, from dec. See encodeFields.
+func (g *generator) decodeFields(p printFn, t types.Type, s *types.Struct) {
+	nums, _ := fieldNumbers(s)
+	if nums == nil {
 		for i := 0; i < s.NumFields(); i++ {
 			fi := s.Field(i)
 			if !isWeaverAutoMarshal(fi.Type()) {
 				p(`	%s`, g.decode("dec", "&x."+fi.Name(), fi.Type()))
 			}
 		}
-		p(`}`)
+		return
+	}
 
-		// Generate encoding/decoding methods for any inner types.
-		for _, inner := range innerTypes {
-			g.generateEncDecMethodsFor(p, inner)
+	// Fields missing from the encoding keep their zero values, and fields
+	// with unknown field numbers are skipped.
+	p(`	*x = %s{}`, g.tset.genTypeString(t))
+	p(`	for {`)
+	p(`		num, field := dec.Field()`)
+	p(`		switch num {`)
+	p(`		case 0:`)
+	p(`			return`)
+	for i := 0; i < s.NumFields(); i++ {
+		fi := s.Field(i)
+		if !isWeaverAutoMarshal(fi.Type()) {
+			p(`		case %d:`, nums[i])
+			p(`			%s`, g.decode("field", "&x."+fi.Name(), fi.Type()))
 		}
 	}
+	p(`		}`)
+	p(`	}`)
 }
 
 // generateRouterMethods generates methods for router types.
@@ -1728,6 +2485,7 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 	// enc(stub, e: type t u) = stub.EncodeProto(&e)           // t implements proto.Message
 	// enc(stub, e: type t u) = (e).WeaverMarshal(stub)         // t implements AutoMarshal
 	// enc(stub, e: type t u) = stub.EncodeBinaryMarshaler(&e) // t implements BinaryMarshaler
+	// enc(stub, e: type t u) = stub.EncodeInterface(e)          // under(u) = interface{...}
 	// enc(stub, e: type t u) = serviceweaver_enc_[t](&stub, &e)       // under(u) = struct{...}
 	// enc(stub, e: type t u) = enc(&stub, under(t)(e))        // otherwise
 	switch x := t.(type) {
@@ -1770,6 +2528,9 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 			return fmt.Sprintf("%s.EncodeBinaryMarshaler(%s)", stub, ref(e))
 		}
 		under := x.Underlying()
+		if _, ok := under.(*types.Interface); ok {
+			return fmt.Sprintf("%s.EncodeInterface(%s)", stub, e)
+		}
 		if _, ok := under.(*types.Struct); ok {
 			return fmt.Sprintf("%s(%s, %s)", f(x), stub, ref(e))
 		}
@@ -1801,6 +2562,7 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 	// dec(stub, v: type t u) = stub.DecodeProto(v)             // t implements proto.Message
 	// dec(stub, v: type t u) = (v).WeaverUnmarshal(stub)        // t implements AutoMarshal
 	// dec(stub, v: type t u) = stub.DecodeBinaryUnmarshaler(v) // t implements BinaryUnmarshaler
+	// dec(stub, v: type t u) = *v = codegen.DecodeInterface[t](stub) // under(u) = interface{...}
 	// dec(stub, v: type t u) = serviceweaver_dec_[t](stub, v)          // under(u) = struct{...}
 	// dec(stub, v: type t u) = dec(stub, (*under(t))(v))       // otherwise
 	switch x := t.(type) {
@@ -1843,6 +2605,9 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 			return fmt.Sprintf("%s.DecodeBinaryUnmarshaler(%s)", stub, v)
 		}
 		under := x.Underlying()
+		if _, ok := under.(*types.Interface); ok {
+			return fmt.Sprintf("%s = %s[%s](%s)", deref(v), g.codegen().qualify("DecodeInterface"), g.tset.genTypeString(x), stub)
+		}
 		if _, ok := under.(*types.Struct); ok {
 			return fmt.Sprintf("%s(%s, %s)", f(x), stub, v)
 		}
@@ -1867,14 +2632,23 @@ func (g *generator) generateEncDecMethods(p printFn) {
 		for _, method := range component.methods() {
 			sig := method.Type().(*types.Signature)
 
-			// Generate for argument types, skipping the context.Context.
+			// Generate for argument types, skipping the context.Context. For
+			// streams, generate for the type of the streamed values.
 			for j := 1; j < sig.Params().Len(); j++ {
-				g.generateEncDecMethodsFor(printer, sig.Params().At(j).Type())
+				t := sig.Params().At(j).Type()
+				if elem := streamElem(t); elem != nil {
+					t = elem
+				}
+				g.generateEncDecMethodsFor(printer, t)
 			}
 
 			// Generate for result types, skipping the error.
 			for j := 0; j < sig.Results().Len()-1; j++ {
-				g.generateEncDecMethodsFor(printer, sig.Results().At(j).Type())
+				t := sig.Results().At(j).Type()
+				if elem := streamElem(t); elem != nil {
+					t = elem
+				}
+				g.generateEncDecMethodsFor(printer, t)
 			}
 		}
 	}
@@ -2023,6 +2797,33 @@ func (g *generator) generateEncDecMethodsFor(p printFn, t types.Type) {
 			// enc.EncodeProto(x), dec.DecodeBinaryUnmarshaler(x)).
 			return
 		}
+		if _, ok := x.Underlying().(*types.Interface); ok {
+			// Interfaces are encoded and decoded by the codegen package
+			// (e.g., enc.EncodeInterface(x)).
+			return
+		}
+		if isGenericAutoMarshal(x) {
+			// We cannot generate WeaverMarshal and WeaverUnmarshal methods
+			// for generic structs, so every instantiation is encoded and
+			// decoded by its own pair of functions.
+			s := x.Underlying().(*types.Struct)
+			p(``)
+			p(`func serviceweaver_enc_%s(enc *%s, x *%s) {`, sanitize(x), g.codegen().qualify("Encoder"), ts(x))
+			g.encodeFields(p, s)
+			p(`}`)
+
+			p(``)
+			p(`func serviceweaver_dec_%s(dec *%s, x *%s) {`, sanitize(x), g.codegen().qualify("Decoder"), ts(x))
+			g.decodeFields(p, x, s)
+			p(`}`)
+
+			for i := 0; i < s.NumFields(); i++ {
+				if fi := s.Field(i); !isWeaverAutoMarshal(fi.Type()) {
+					g.generateEncDecMethodsFor(p, fi.Type())
+				}
+			}
+			return
+		}
 		// If a named type t is not a struct, e.g. `type t int`, then we
 		// encode and decode values of type by casting it to its underlying
 		// type (e.g., enc.Int(int(x)) where x has type t).
@@ -2059,6 +2860,11 @@ func (g *generator) codes() importPkg {
 	return g.tset.importPackage("go.opentelemetry.io/otel/codes", "codes")
 }
 
+// io imports and returns the io package.
+func (g *generator) io() importPkg {
+	return g.tset.importPackage("io", "io")
+}
+
 // errors imports and returns the errors package.
 func (g *generator) errorsPackage() importPkg {
 	return g.tset.importPackage("errors", "errors")
@@ -98,9 +98,11 @@ func runGenerator(t *testing.T, directory, filename, contents string, subdirs []
 		t.Fatalf("go mod tidy: %v", err)
 	}
 
-	// Run "weaver generate".
+	// Run "weaver generate". Fakes are enabled, so that they are compiled
+	// for every test file.
 	opt := Options{
-		Warn: func(err error) { t.Log(err) },
+		Warn:  func(err error) { t.Log(err) },
+		Fakes: true,
 	}
 	if err := Generate(tmp, []string{tmp}, opt); err != nil {
 		return "", err
@@ -216,7 +218,7 @@ func TestGenerator(t *testing.T) {
 			}
 
 			// Run "weaver generate".
-			output, err := runGenerator(t, dir, filename, contents, []string{"sub1", "sub2"})
+			output, err := runGenerator(t, dir, filename, contents, []string{"sub1", "sub2", "sub3"})
 			if err != nil {
 				t.Fatalf("error running generator: %v", err)
 			}
@@ -0,0 +1,535 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+const CheckCompatUsage = `Check that two versions of a Service Weaver application are wire compatible.
+
+Usage:
+  weaver check-compat <old> <new>
+
+Description:
+  "weaver check-compat" compares the schemas of two versions of a Service
+  Weaver application and reports the changes that prevent the two versions
+  from calling each other's components, for example during a rolling upgrade.
+  A schema records the methods of every component, the serialization layout
+  of their arguments and results, and the weaver.AutoMarshal structs that can
+  be sent in interface-typed values. The code generated by "weaver generate"
+  embeds the schema in the application binary, and
+  "weaver generate -schema=<file>" also writes it to a file. Both arguments
+  can be either binaries or schema files.
+
+  Values are serialized positionally, so adding, removing, or reordering the
+  fields of a weaver.AutoMarshal struct, or the arguments of a method, is an
+  incompatible change. Adding components and methods is a compatible change.
+  Fields can be added to and removed from weaver.AutoMarshal structs whose
+  fields are numbered with weaver:"<field number>" struct tags, as long as
+  field numbers are not reused.
+
+  "weaver check-compat" exits with a non-zero exit code if the two versions
+  are not compatible.
+
+Examples:
+  # Compare two binaries.
+  weaver check-compat ./v1/app ./v2/app
+
+  # Compare the application at two commits, without building it.
+  git checkout v1 && weaver generate -schema=/tmp/v1.json ./...
+  git checkout v2 && weaver generate -schema=/tmp/v2.json ./...
+  weaver check-compat /tmp/v1.json /tmp/v2.json`
+
+// Schema describes the components of an application, how the arguments and
+// results of their methods are serialized, and the types registered to be
+// sent in interface-typed values (see codegen.RegisterType).
+type Schema struct {
+	Components []ComponentSchema `json:"components"`
+	Types      []TypeSchema      `json:"types,omitempty"`
+}
+
+// ComponentSchema describes a component.
+type ComponentSchema struct {
+	Name    string         `json:"name"` // full component name
+	Methods []MethodSchema `json:"methods"`
+}
+
+// MethodSchema describes a component method. The context.Context argument
+// and the error result are omitted.
+type MethodSchema struct {
+	Name    string       `json:"name"`
+	Args    []TypeSchema `json:"args"`
+	Results []TypeSchema `json:"results"`
+}
+
+// TypeSchema describes how a type is serialized.
+type TypeSchema struct {
+	// Kind is one of:
+	//
+	//   - the name of a basic type (e.g., "int", "string");
+	//   - "pointer", "array", "slice", "map", or "stream";
+	//   - "struct", for structs that embed weaver.AutoMarshal;
+	//   - "interface", for named interfaces; or
+	//   - "proto", "binary", or "custom", for types that serialize themselves
+	//     using protocol buffers, MarshalBinary, or hand-written
+	//     WeaverMarshal methods respectively.
+	Kind string `json:"kind"`
+
+	// Name is the full name of a named type.
+	Name string `json:"name,omitempty"`
+
+	Len    int64         `json:"len,omitempty"`    // length of an array
+	Key    *TypeSchema   `json:"key,omitempty"`    // key type of a map
+	Elem   *TypeSchema   `json:"elem,omitempty"`   // element type of a pointer, array, slice, map, or stream
+	Fields []FieldSchema `json:"fields,omitempty"` // fields of a struct, in order
+}
+
+// FieldSchema describes a struct field.
+type FieldSchema struct {
+	Name   string     `json:"name"`
+	Number int        `json:"number,omitempty"` // field number, if the struct has numbered fields
+	Type   TypeSchema `json:"type"`
+}
+
+// schema returns the schemas of the components in the generator's package.
+//
+// REQUIRES: g.components have been validated.
+func (g *generator) schema() []ComponentSchema {
+	var components []ComponentSchema
+	for _, comp := range g.components {
+		c := ComponentSchema{Name: comp.fullIntfName(), Methods: []MethodSchema{}}
+		for _, m := range comp.methods() {
+			sig := m.Type().(*types.Signature)
+			method := MethodSchema{Name: m.Name(), Args: []TypeSchema{}, Results: []TypeSchema{}}
+			for i := 1; i < sig.Params().Len(); i++ {
+				method.Args = append(method.Args, g.typeSchema(sig.Params().At(i).Type()))
+			}
+			for i := 0; i < sig.Results().Len()-1; i++ {
+				method.Results = append(method.Results, g.typeSchema(sig.Results().At(i).Type()))
+			}
+			c.Methods = append(c.Methods, method)
+		}
+		components = append(components, c)
+	}
+	return components
+}
+
+// registeredTypes returns the schemas of the weaver.AutoMarshal structs
+// declared in the generator's package, which the generated code registers
+// with codegen.RegisterType.
+func (g *generator) registeredTypes() []TypeSchema {
+	var schemas []TypeSchema
+	for _, t := range g.tset.automarshalCandidates.Keys() {
+		if n, ok := t.(*types.Named); ok && n.TypeParams().Len() > 0 {
+			// Generic types are only registered when instantiated.
+			continue
+		}
+		schemas = append(schemas, g.typeSchema(t))
+	}
+	return schemas
+}
+
+// embeddedSchema returns the schema of the generator's package, encoded with
+// codegen.MakeSchemaString, or the empty string if the package doesn't have
+// any components or registered types.
+//
+// REQUIRES: g.components have been validated.
+func (g *generator) embeddedSchema() (string, error) {
+	schema := &Schema{Components: g.schema(), Types: g.registeredTypes()}
+	if len(schema.Components) == 0 && len(schema.Types) == 0 {
+		return "", nil
+	}
+	sortSchema(schema)
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+	return codegen.MakeSchemaString(string(data)), nil
+}
+
+// typeSchema returns the schema of the provided type.
+//
+// REQUIRES: t is serializable or a weaver.Stream of a serializable type.
+func (g *generator) typeSchema(t types.Type) TypeSchema {
+	if elem := streamElem(t); elem != nil {
+		e := g.typeSchema(elem)
+		return TypeSchema{Kind: "stream", Elem: &e}
+	}
+
+	switch x := t.(type) {
+	case *types.Basic:
+		return TypeSchema{Kind: x.Name()}
+
+	case *types.Pointer:
+		e := g.typeSchema(x.Elem())
+		return TypeSchema{Kind: "pointer", Elem: &e}
+
+	case *types.Array:
+		e := g.typeSchema(x.Elem())
+		return TypeSchema{Kind: "array", Len: x.Len(), Elem: &e}
+
+	case *types.Slice:
+		e := g.typeSchema(x.Elem())
+		return TypeSchema{Kind: "slice", Elem: &e}
+
+	case *types.Map:
+		k, e := g.typeSchema(x.Key()), g.typeSchema(x.Elem())
+		return TypeSchema{Kind: "map", Key: &k, Elem: &e}
+
+	case *types.Named:
+		// The name includes the type arguments of instantiated generic
+		// types (e.g., github.com/foo/bar.Page[github.com/foo/bar.Item]).
+		name := types.TypeString(x, nil)
+		switch {
+		case g.tset.isProto(x):
+			return TypeSchema{Kind: "proto", Name: name}
+		case g.tset.hasMarshalBinary(x):
+			return TypeSchema{Kind: "binary", Name: name}
+		}
+		switch u := x.Underlying().(type) {
+		case *types.Interface:
+			return TypeSchema{Kind: "interface", Name: name}
+		case *types.Struct:
+			if g.tset.automarshalCandidates.At(x) == nil && !isGenericAutoMarshal(x) {
+				return TypeSchema{Kind: "custom", Name: name}
+			}
+			s := TypeSchema{Kind: "struct", Name: name, Fields: []FieldSchema{}}
+			nums, _ := fieldNumbers(u)
+			for i := 0; i < u.NumFields(); i++ {
+				f := u.Field(i)
+				if isWeaverAutoMarshal(f.Type()) {
+					continue
+				}
+				field := FieldSchema{Name: f.Name(), Type: g.typeSchema(f.Type())}
+				if nums != nil {
+					field.Number = nums[i]
+				}
+				s.Fields = append(s.Fields, field)
+			}
+			return s
+		default:
+			// Named non-struct types are serialized like their underlying
+			// types.
+			s := g.typeSchema(u)
+			s.Name = name
+			return s
+		}
+
+	default:
+		panic(fmt.Sprintf("typeSchema: unexpected type %v", t))
+	}
+}
+
+// sortSchema sorts the components, methods, and types of the provided schema
+// by name.
+func sortSchema(schema *Schema) {
+	sort.Slice(schema.Components, func(i, j int) bool {
+		return schema.Components[i].Name < schema.Components[j].Name
+	})
+	for _, c := range schema.Components {
+		sort.Slice(c.Methods, func(i, j int) bool {
+			return c.Methods[i].Name < c.Methods[j].Name
+		})
+	}
+	sort.Slice(schema.Types, func(i, j int) bool {
+		return schema.Types[i].Name < schema.Types[j].Name
+	})
+}
+
+// writeSchema writes the provided schema to the provided file, as JSON,
+// sorted by name.
+func writeSchema(filename string, schema *Schema) error {
+	sortSchema(schema)
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0644)
+}
+
+// readSchema reads a schema from a file written by "weaver generate -schema",
+// or from a binary that embeds the schemas of its packages.
+func readSchema(filename string) (*Schema, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var schema Schema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, fmt.Errorf("parse schema %q: %w", filename, err)
+		}
+		return &schema, nil
+	}
+
+	// Merge the schemas of the packages linked into the binary.
+	embedded := codegen.ExtractSchemas(data)
+	if len(embedded) == 0 {
+		return nil, fmt.Errorf("%q is neither a schema file nor a binary built with code generated by \"weaver generate\"", filename)
+	}
+	schema := &Schema{Components: []ComponentSchema{}}
+	components, registered := map[string]bool{}, map[string]bool{}
+	for _, e := range embedded {
+		var pkg Schema
+		if err := json.Unmarshal([]byte(e), &pkg); err != nil {
+			return nil, fmt.Errorf("parse schema embedded in %q: %w", filename, err)
+		}
+		for _, c := range pkg.Components {
+			if !components[c.Name] {
+				components[c.Name] = true
+				schema.Components = append(schema.Components, c)
+			}
+		}
+		for _, t := range pkg.Types {
+			if !registered[t.Name] {
+				registered[t.Name] = true
+				schema.Types = append(schema.Types, t)
+			}
+		}
+	}
+	sortSchema(schema)
+	return schema, nil
+}
+
+// CheckCompatFiles checks that the schemas stored in the provided files are
+// wire compatible. Every file is either a schema file or a binary. See
+// CheckCompat.
+func CheckCompatFiles(oldFile, newFile string) error {
+	old, err := readSchema(oldFile)
+	if err != nil {
+		return err
+	}
+	new, err := readSchema(newFile)
+	if err != nil {
+		return err
+	}
+	if problems := CheckCompat(old, new); len(problems) > 0 {
+		return fmt.Errorf("%s", describeProblems(problems))
+	}
+	return nil
+}
+
+// CheckCompat returns the changes from the old schema to the new schema that
+// are not wire compatible. A nil result means that the two versions of the
+// application can call each other's components.
+func CheckCompat(old, new *Schema) []string {
+	var problems []string
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	components := map[string]ComponentSchema{}
+	for _, c := range new.Components {
+		components[c.Name] = c
+	}
+	for _, oldComp := range old.Components {
+		newComp, ok := components[oldComp.Name]
+		if !ok {
+			report("component %s was removed", oldComp.Name)
+			continue
+		}
+		methods := map[string]MethodSchema{}
+		for _, m := range newComp.Methods {
+			methods[m.Name] = m
+		}
+		for _, oldMethod := range oldComp.Methods {
+			newMethod, ok := methods[oldMethod.Name]
+			name := oldComp.Name + "." + oldMethod.Name
+			if !ok {
+				report("method %s was removed", name)
+				continue
+			}
+			checkTuple := func(what string, old, new []TypeSchema) {
+				if len(old) != len(new) {
+					report("method %s: number of %ss changed from %d to %d", name, what, len(old), len(new))
+					return
+				}
+				for i := range old {
+					path := fmt.Sprintf("method %s: %s %d", name, what, i)
+					for _, p := range compareTypes(path, &old[i], &new[i]) {
+						report("%s", p)
+					}
+				}
+			}
+			checkTuple("argument", oldMethod.Args, newMethod.Args)
+			checkTuple("result", oldMethod.Results, newMethod.Results)
+		}
+	}
+
+	// Values of registered types can be sent in any interface-typed value,
+	// so a type registered by both versions must be serialized the same way.
+	registered := map[string]*TypeSchema{}
+	for i := range new.Types {
+		registered[new.Types[i].Name] = &new.Types[i]
+	}
+	for i := range old.Types {
+		if t, ok := registered[old.Types[i].Name]; ok {
+			for _, p := range compareTypes("type "+old.Types[i].Name, &old.Types[i], t) {
+				report("%s", p)
+			}
+		}
+	}
+	return problems
+}
+
+// compareTypes returns the wire incompatible differences between two types,
+// found at the provided path.
+func compareTypes(path string, old, new *TypeSchema) []string {
+	if old.Kind != new.Kind {
+		return []string{fmt.Sprintf("%s: type changed from %s to %s", path, old, new)}
+	}
+
+	switch old.Kind {
+	case "pointer", "slice", "stream":
+		return compareTypes(path+"[elem]", old.Elem, new.Elem)
+
+	case "array":
+		if old.Len != new.Len {
+			return []string{fmt.Sprintf("%s: type changed from %s to %s", path, old, new)}
+		}
+		return compareTypes(path+"[elem]", old.Elem, new.Elem)
+
+	case "map":
+		problems := compareTypes(path+"[key]", old.Key, new.Key)
+		return append(problems, compareTypes(path+"[value]", old.Elem, new.Elem)...)
+
+	case "struct":
+		if old.numbered() != new.numbered() {
+			return []string{fmt.Sprintf("%s: layout of %s changed from %s to %s", path, old.Name, old.layout(), new.layout())}
+		}
+		if old.numbered() {
+			// Fields are serialized along with their field numbers. Readers
+			// skip unknown fields and leave missing fields zero, so fields
+			// can be added, removed, and renamed.
+			fields := map[int]*FieldSchema{}
+			for i := range new.Fields {
+				fields[new.Fields[i].Number] = &new.Fields[i]
+			}
+			var problems []string
+			for i := range old.Fields {
+				if f, ok := fields[old.Fields[i].Number]; ok {
+					fieldPath := fmt.Sprintf("%s.%s", path, old.Fields[i].Name)
+					problems = append(problems, compareTypes(fieldPath, &old.Fields[i].Type, &f.Type)...)
+				}
+			}
+			return problems
+		}
+
+		// Fields are serialized in order, without their names. Renaming a
+		// field is compatible, as long as the layout stays the same.
+		n := min(len(old.Fields), len(new.Fields))
+		var problems []string
+		renamed := false
+		for i := 0; i < n; i++ {
+			fieldPath := fmt.Sprintf("%s.%s", path, old.Fields[i].Name)
+			problems = append(problems, compareTypes(fieldPath, &old.Fields[i].Type, &new.Fields[i].Type)...)
+			renamed = renamed || old.Fields[i].Name != new.Fields[i].Name
+		}
+		if len(old.Fields) == len(new.Fields) && len(problems) == 0 {
+			return nil
+		}
+		if renamed {
+			// Fields were likely inserted, removed, or reordered. Reporting
+			// every misaligned field would be confusing.
+			return []string{fmt.Sprintf("%s: layout of %s changed from %s to %s", path, old.Name, old.layout(), new.layout())}
+		}
+		for _, f := range old.Fields[n:] {
+			problems = append(problems, fmt.Sprintf("%s: field %s of %s was removed", path, f.Name, old.Name))
+		}
+		for _, f := range new.Fields[n:] {
+			problems = append(problems, fmt.Sprintf("%s: field %s was added to %s", path, f.Name, new.Name))
+		}
+		return problems
+
+	case "proto", "binary", "custom", "interface":
+		// These types serialize themselves, and values of interface types
+		// are serialized along with their concrete types (which are compared
+		// separately), so we can only check that the type didn't change.
+		if old.Name != new.Name {
+			return []string{fmt.Sprintf("%s: type changed from %s to %s", path, old, new)}
+		}
+		return nil
+
+	default:
+		// Basic types.
+		return nil
+	}
+}
+
+// String returns a short description of a type.
+func (t *TypeSchema) String() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	switch t.Kind {
+	case "pointer":
+		return "*" + t.Elem.String()
+	case "array":
+		return fmt.Sprintf("[%d]%s", t.Len, t.Elem)
+	case "slice":
+		return "[]" + t.Elem.String()
+	case "map":
+		return fmt.Sprintf("map[%s]%s", t.Key, t.Elem)
+	case "stream":
+		return fmt.Sprintf("weaver.Stream[%s]", t.Elem)
+	default:
+		return t.Kind
+	}
+}
+
+// numbered returns whether a struct has numbered fields.
+func (t *TypeSchema) numbered() bool {
+	return len(t.Fields) > 0 && t.Fields[0].Number != 0
+}
+
+// layout returns a short description of the fields of a struct.
+func (t *TypeSchema) layout() string {
+	fields := make([]string, len(t.Fields))
+	for i, f := range t.Fields {
+		fields[i] = fmt.Sprintf("%s %s", f.Name, &f.Type)
+		if f.Number != 0 {
+			fields[i] += fmt.Sprintf(" `weaver:\"%d\"`", f.Number)
+		}
+	}
+	return "{" + strings.Join(fields, "; ") + "}"
+}
+
+// min returns the minimum of a and b.
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// describeProblems returns a human readable description of compatibility
+// problems.
+func describeProblems(problems []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "found %d incompatible change(s):", len(problems))
+	for _, p := range problems {
+		fmt.Fprintf(&b, "\n  - %s", p)
+	}
+	return b.String()
+}
@@ -0,0 +1,252 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckCompat(t *testing.T) {
+	basic := func(kind string) TypeSchema { return TypeSchema{Kind: kind} }
+	slice := func(elem TypeSchema) TypeSchema { return TypeSchema{Kind: "slice", Elem: &elem} }
+	pair := func(fields ...string) TypeSchema {
+		s := TypeSchema{Kind: "struct", Name: "foo.Pair"}
+		for i := 0; i < len(fields); i += 2 {
+			s.Fields = append(s.Fields, FieldSchema{Name: fields[i], Type: basic(fields[i+1])})
+		}
+		return s
+	}
+	numbered := func(fields ...string) TypeSchema {
+		s := TypeSchema{Kind: "struct", Name: "foo.Pair"}
+		for i := 0; i < len(fields); i += 3 {
+			num, err := strconv.Atoi(fields[i+2])
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.Fields = append(s.Fields, FieldSchema{Name: fields[i], Number: num, Type: basic(fields[i+1])})
+		}
+		return s
+	}
+	schema := func(methods ...MethodSchema) *Schema {
+		return &Schema{Components: []ComponentSchema{{Name: "foo/Foo", Methods: methods}}}
+	}
+	method := func(name string, args ...TypeSchema) MethodSchema {
+		return MethodSchema{Name: name, Args: args}
+	}
+
+	for _, test := range []struct {
+		name     string
+		old, new *Schema
+		want     []string // substrings of the expected problems
+	}{
+		{
+			name: "Unchanged",
+			old:  schema(method("M", basic("int"), pair("X", "int", "Y", "string"))),
+			new:  schema(method("M", basic("int"), pair("X", "int", "Y", "string"))),
+		},
+		{
+			name: "AddMethod",
+			old:  schema(method("M", basic("int"))),
+			new:  schema(method("M", basic("int")), method("N")),
+		},
+		{
+			name: "AddComponent",
+			old:  schema(),
+			new: &Schema{Components: []ComponentSchema{
+				{Name: "foo/Foo"},
+				{Name: "foo/Bar"},
+			}},
+		},
+		{
+			name: "RenameField",
+			old:  schema(method("M", pair("X", "int"))),
+			new:  schema(method("M", pair("Y", "int"))),
+		},
+		{
+			name: "NamedBasicType",
+			old:  schema(method("M", basic("string"))),
+			new:  schema(method("M", TypeSchema{Kind: "string", Name: "foo.ID"})),
+		},
+		{
+			name: "RemoveComponent",
+			old:  schema(),
+			new:  &Schema{},
+			want: []string{"component foo/Foo was removed"},
+		},
+		{
+			name: "RemoveMethod",
+			old:  schema(method("M"), method("N")),
+			new:  schema(method("M")),
+			want: []string{"method foo/Foo.N was removed"},
+		},
+		{
+			name: "AddArgument",
+			old:  schema(method("M", basic("int"))),
+			new:  schema(method("M", basic("int"), basic("bool"))),
+			want: []string{"number of arguments changed from 1 to 2"},
+		},
+		{
+			name: "ChangeArgument",
+			old:  schema(method("M", basic("int"))),
+			new:  schema(method("M", basic("string"))),
+			want: []string{"argument 0: type changed from int to string"},
+		},
+		{
+			name: "AddField",
+			old:  schema(method("M", slice(pair("X", "int")))),
+			new:  schema(method("M", slice(pair("X", "int", "Y", "int")))),
+			want: []string{"argument 0[elem]: field Y was added to foo.Pair"},
+		},
+		{
+			name: "InsertField",
+			old:  schema(method("M", pair("X", "int", "Y", "string"))),
+			new:  schema(method("M", pair("W", "bool", "X", "int", "Y", "string"))),
+			want: []string{"layout of foo.Pair changed from {X int; Y string} to {W bool; X int; Y string}"},
+		},
+		{
+			name: "ChangeField",
+			old:  schema(method("M", pair("X", "int", "Y", "string"))),
+			new:  schema(method("M", pair("X", "int", "Y", "int"))),
+			want: []string{"argument 0.Y: type changed from string to int"},
+		},
+		{
+			name: "RemoveField",
+			old:  schema(method("M", pair("X", "int", "Y", "int"))),
+			new:  schema(method("M", pair("X", "int"))),
+			want: []string{"field Y of foo.Pair was removed"},
+		},
+		{
+			name: "ReorderFields",
+			old:  schema(method("M", pair("X", "int", "Y", "string"))),
+			new:  schema(method("M", pair("Y", "string", "X", "int"))),
+			want: []string{"layout of foo.Pair changed from {X int; Y string} to {Y string; X int}"},
+		},
+		{
+			name: "ChangeProto",
+			old:  schema(method("M", TypeSchema{Kind: "proto", Name: "foo.A"})),
+			new:  schema(method("M", TypeSchema{Kind: "proto", Name: "foo.B"})),
+			want: []string{"type changed from foo.A to foo.B"},
+		},
+		{
+			name: "ChangeInterface",
+			old:  schema(method("M", TypeSchema{Kind: "interface", Name: "foo.Event"})),
+			new:  schema(method("M", TypeSchema{Kind: "interface", Name: "foo.Message"})),
+			want: []string{"argument 0: type changed from foo.Event to foo.Message"},
+		},
+		{
+			name: "AddRegisteredType",
+			old:  &Schema{Types: []TypeSchema{pair("X", "int")}},
+			new:  &Schema{Types: []TypeSchema{pair("X", "int"), {Kind: "struct", Name: "foo.Click"}}},
+		},
+		{
+			name: "ChangeRegisteredType",
+			old:  &Schema{Types: []TypeSchema{pair("X", "int", "Y", "string")}},
+			new:  &Schema{Types: []TypeSchema{pair("X", "int", "Y", "int")}},
+			want: []string{"type foo.Pair.Y: type changed from string to int"},
+		},
+		{
+			name: "AddNumberedField",
+			old:  schema(method("M", numbered("X", "int", "1"))),
+			new:  schema(method("M", numbered("X", "int", "1", "Y", "string", "2"))),
+		},
+		{
+			name: "RemoveNumberedField",
+			old:  schema(method("M", numbered("X", "int", "1", "Y", "string", "2"))),
+			new:  schema(method("M", numbered("Y", "string", "2"))),
+		},
+		{
+			name: "ReorderNumberedFields",
+			old:  schema(method("M", numbered("X", "int", "1", "Y", "string", "2"))),
+			new:  schema(method("M", numbered("Y", "string", "2", "Z", "bool", "3", "X", "int", "1"))),
+		},
+		{
+			name: "ChangeNumberedField",
+			old:  schema(method("M", numbered("X", "int", "1", "Y", "string", "2"))),
+			new:  schema(method("M", numbered("X", "int", "1", "Z", "bool", "2"))),
+			want: []string{"argument 0.Y: type changed from string to bool"},
+		},
+		{
+			name: "NumberFields",
+			old:  schema(method("M", pair("X", "int"))),
+			new:  schema(method("M", numbered("X", "int", "1"))),
+			want: []string{"layout of foo.Pair changed from {X int} to {X int `weaver:\"1\"`}"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := CheckCompat(test.old, test.new)
+			if len(got) != len(test.want) {
+				t.Fatalf("CheckCompat: got %q, want %d problems matching %q", got, len(test.want), test.want)
+			}
+			for i, want := range test.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("problem %d: got %q, want %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadSchemaFromBinary(t *testing.T) {
+	// Simulate a binary that embeds the schemas of two packages, one of
+	// which is linked in twice.
+	foo := Schema{
+		Components: []ComponentSchema{{Name: "foo/Foo", Methods: []MethodSchema{{Name: "M"}}}},
+		Types:      []TypeSchema{{Kind: "struct", Name: "foo.Pair"}},
+	}
+	bar := Schema{Types: []TypeSchema{{Kind: "struct", Name: "bar.Click"}}}
+	var binary []byte
+	for _, schema := range []Schema{foo, bar, foo} {
+		data, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		binary = append(binary, "\x00\x01garbage"...)
+		binary = append(binary, codegen.MakeSchemaString(string(data))...)
+	}
+	file := filepath.Join(t.TempDir(), "binary")
+	if err := os.WriteFile(file, binary, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readSchema(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Schema{
+		Components: foo.Components,
+		Types:      []TypeSchema{bar.Types[0], foo.Types[0]},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("readSchema (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadSchemaNotABinary(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "binary")
+	if err := os.WriteFile(file, []byte("\x7fELF"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readSchema(file); err == nil {
+		t.Fatal("readSchema: unexpected success")
+	}
+}
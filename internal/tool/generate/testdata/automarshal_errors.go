@@ -0,0 +1,57 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// codegen.RegisterSerializableError[notFoundError]()
+// codegen.RegisterSerializableError[timeoutError]()
+
+// UNEXPECTED
+// codegen.RegisterSerializableError[pair]()
+
+// AutoMarshal types that implement error are registered as serializable errors.
+package foo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type notFoundError struct {
+	weaver.AutoMarshal
+	Key string
+}
+
+func (e notFoundError) Error() string { return fmt.Sprintf("%q not found", e.Key) }
+
+type timeoutError struct {
+	weaver.AutoMarshal
+	Millis int
+}
+
+func (e *timeoutError) Error() string { return fmt.Sprintf("timed out after %dms", e.Millis) }
+
+type pair struct {
+	weaver.AutoMarshal
+	A, B int
+}
+
+type foo interface {
+	M(context.Context, pair) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) M(context.Context, pair) error { return notFoundError{Key: "x"} }
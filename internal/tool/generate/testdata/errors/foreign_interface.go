@@ -0,0 +1,36 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// ERROR: interfaces declared outside of the application's module
+
+// Interfaces declared in the standard library or in other modules are not
+// serializable.
+package foo
+
+import (
+	"context"
+	"io"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type foo interface {
+	A(context.Context, io.Reader) error
+}
+
+type impl struct {
+	weaver.Implements[foo]
+}
+
+func (impl) A(context.Context, io.Reader) error { return nil }
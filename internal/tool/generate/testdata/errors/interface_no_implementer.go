@@ -0,0 +1,44 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// ERROR: interfaces not implemented by any weaver.AutoMarshal struct
+
+// Interfaces that no weaver.AutoMarshal struct implements are not serializable.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type shape interface {
+	Area() float64
+}
+
+type square struct {
+	side float64
+}
+
+func (s square) Area() float64 { return s.side * s.side }
+
+type foo interface {
+	A(context.Context, shape) error
+}
+
+type impl struct {
+	weaver.Implements[foo]
+}
+
+func (impl) A(context.Context, shape) error { return nil }
@@ -1,4 +1,4 @@
-// Copyright 2022 Google LLC
+// Copyright 2023 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -12,13 +12,13 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// ERROR: cannot embed weaver.AutoMarshal
+// ERROR: field Y has no field number
 package foo
 
 import "github.com/ServiceWeaver/weaver"
 
-type option[T any] struct {
-	x int
+type pair struct {
 	weaver.AutoMarshal
-	y bool
+	X int `weaver:"1"`
+	Y int
 }
@@ -0,0 +1,53 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// type FakeFoo struct {
+// MFn func(ctx context.Context, a0 int, a1 ...string) (r0 int, err error)
+// var _ Foo = FakeFoo{}
+// func (f FakeFoo) M(ctx context.Context, a0 int, a1 ...string) (r0 int, err error) {
+// return f.MFn(ctx, a0, a1...)
+// type fakeBar struct {
+
+// UNEXPECTED
+// type FakeEmpty struct
+// type FakeMain struct
+
+// Fake implementations of components.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type Foo interface {
+	M(context.Context, int, ...string) (int, error)
+}
+
+type bar interface {
+	N(context.Context) error
+}
+
+// Components without methods don't get a fake.
+type Empty interface{}
+
+type foo struct{ weaver.Implements[Foo] }
+type barImpl struct{ weaver.Implements[bar] }
+type empty struct{ weaver.Implements[Empty] }
+type app struct{ weaver.Implements[weaver.Main] }
+
+func (foo) M(context.Context, int, ...string) (int, error) { return 0, nil }
+func (barImpl) N(context.Context) error                    { return nil }
@@ -0,0 +1,107 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// "foo/store[string, foo.item]",
+// reflect.TypeOf((*store[string, item])(nil)).Elem(),
+// "foo/store[int, foo.page[foo.item]]",
+// type store_string_item_
+// "foo.store[string, item].Get"
+// func serviceweaver_enc_page_item_
+// func serviceweaver_dec_page_item_
+// func serviceweaver_enc_pair_string_int_
+// func serviceweaver_dec_pair_string_int_
+// func serviceweaver_size_pair_string_int_
+// enc.String(x.First)
+// enc.Int(x.Second)
+// x.Items = serviceweaver_dec_slice_item_
+// x.Next = dec.String()
+// start = enc.BeginField(2)
+// func serviceweaver_enc_slice_int_
+// Retriable: []int{0},
+
+// UNEXPECTED
+// func (x *page[T]) WeaverMarshal
+// var _ codegen.AutoMarshal = &page
+
+// Generic component interfaces can be instantiated by component
+// implementations, and instantiations of generic structs that embed
+// weaver.AutoMarshal are serializable.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type item struct {
+	weaver.AutoMarshal
+	Name string
+}
+
+type page[T any] struct {
+	weaver.AutoMarshal
+	Items []T
+	Next  string
+}
+
+type pair[A, B any] struct {
+	weaver.AutoMarshal
+	First  A
+	Second B
+}
+
+type versioned[T any] struct {
+	weaver.AutoMarshal
+	Value   T   `weaver:"2"`
+	Version int `weaver:"1"`
+}
+
+type list[T any] []T
+
+type store[K comparable, V any] interface {
+	Get(context.Context, K) (V, error)
+	Put(context.Context, K, V) error
+}
+
+type lister interface {
+	List(context.Context, string) (page[item], error)
+	Pair(context.Context, pair[string, int]) (versioned[*item], error)
+	Ints(context.Context, list[int]) error
+}
+
+var _ weaver.Retriable = store[string, item].Get
+
+type itemStore struct {
+	weaver.Implements[store[string, item]]
+}
+
+func (itemStore) Get(context.Context, string) (item, error) { return item{}, nil }
+func (itemStore) Put(context.Context, string, item) error   { return nil }
+
+type pageStore struct {
+	weaver.Implements[store[int, page[item]]]
+}
+
+func (pageStore) Get(context.Context, int) (page[item], error) { return page[item]{}, nil }
+func (pageStore) Put(context.Context, int, page[item]) error   { return nil }
+
+type listerImpl struct{ weaver.Implements[lister] }
+
+func (listerImpl) List(context.Context, string) (page[item], error) { return page[item]{}, nil }
+func (listerImpl) Pair(context.Context, pair[string, int]) (versioned[*item], error) {
+	return versioned[*item]{}, nil
+}
+func (listerImpl) Ints(context.Context, list[int]) error { return nil }
@@ -0,0 +1,54 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// return foo_local_stub{impl: impl.(foo), tracer: tracer, interceptor: interceptor}
+// return foo_client_stub{stub: stub, interceptor: interceptor,
+// return foo_server_stub{impl: impl.(foo), addLoad: addLoad, interceptor: interceptor}
+// interceptor codegen.Interceptor
+// err := intercept(ctx, "A", func(ctx context.Context) (err error) {
+// r0, r1, err = s.A(ctx, a0, a1...)
+// return r0, r1, err
+// err := intercept(ctx, "B", func(ctx context.Context) (err error) {
+// err = s.B(ctx)
+// return err
+// if s.interceptor == nil {
+// r0, r1, appErr = s.impl.A(ctx, a0, a1...)
+// appErr = s.interceptor(ctx, "A", func(ctx context.Context) (err error) {
+// ir0, ir1, err = s.impl.A(ctx, a0, a1...)
+// r0 = ir0
+// r1 = ir1
+// appErr = s.interceptor(ctx, "B", func(ctx context.Context) (err error) {
+// err = s.impl.B(ctx)
+
+// Generated stubs run method calls through their interceptor.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type foo interface {
+	A(context.Context, string, ...int) (int, []string, error)
+	B(context.Context) error
+}
+
+type impl struct {
+	weaver.Implements[foo]
+}
+
+func (impl) A(context.Context, string, ...int) (int, []string, error) { return 0, nil, nil }
+func (impl) B(context.Context) error                                  { return nil }
@@ -0,0 +1,72 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// enc.EncodeInterface(a0)
+// r0 = codegen.DecodeInterface[event](dec)
+// enc.EncodeInterface(x.Event)
+// codegen.DecodeInterface[event](dec)
+// func serviceweaver_enc_slice_event_
+// codegen.RegisterType[click]()
+// codegen.RegisterType[envelope]()
+// codegen.RegisterType[scroll]()
+
+// UNEXPECTED
+// serviceweaver_enc_event
+// Preallocate
+
+// Interface-typed arguments, results, and fields are encoded along with the
+// registered concrete types of their values.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type event interface {
+	isEvent()
+}
+
+type click struct {
+	weaver.AutoMarshal
+	X, Y int
+}
+
+func (click) isEvent() {}
+
+type scroll struct {
+	weaver.AutoMarshal
+	Delta int
+}
+
+func (*scroll) isEvent() {}
+
+type envelope struct {
+	weaver.AutoMarshal
+	Event event
+}
+
+type foo interface {
+	Handle(context.Context, event) (event, error)
+	HandleAll(context.Context, []event) error
+	Wrap(context.Context, envelope) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) Handle(_ context.Context, e event) (event, error) { return e, nil }
+func (impl) HandleAll(context.Context, []event) error         { return nil }
+func (impl) Wrap(context.Context, envelope) error             { return nil }
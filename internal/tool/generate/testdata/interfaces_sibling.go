@@ -0,0 +1,43 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// enc.EncodeInterface(a0)
+// r0 = codegen.DecodeInterface[events.Event](dec)
+// func serviceweaver_enc_slice_Event_
+
+// UNEXPECTED
+// codegen.RegisterType[events.Click]()
+// codegen.RegisterType[events.Scroll]()
+
+// Interfaces declared in another package of the module are serializable, even
+// if that package is generated separately.
+package foo
+
+import (
+	"context"
+
+	"foo/sub3"
+	"github.com/ServiceWeaver/weaver"
+)
+
+type foo interface {
+	Handle(context.Context, events.Event) (events.Event, error)
+	HandleAll(context.Context, []events.Event) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) Handle(_ context.Context, e events.Event) (events.Event, error) { return e, nil }
+func (impl) HandleAll(context.Context, []events.Event) error                { return nil }
@@ -18,7 +18,7 @@
 // var a0 [3][5]int
 // var a1 [2][2][2]float64
 // var a1 [12]int
-// r0, appErr := s.impl.A
+// r0, appErr = s.impl.A
 // serviceweaver_enc_array_9123_X
 // serviceweaver_enc_array_12_int
 // serviceweaver_dec_array_2048_string
@@ -16,7 +16,7 @@
 // var a0 map[int][]X
 // var a1 map[int]bool
 // var a2 map[[10]int]int
-// r0, appErr := s.impl.A
+// r0, appErr = s.impl.A
 // serviceweaver_enc_map_int_slice_X
 // serviceweaver_enc_map_int_bool
 // serviceweaver_dec_map_array_10_int_int
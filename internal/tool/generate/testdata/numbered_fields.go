@@ -0,0 +1,55 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// start = enc.BeginField(1)
+// start = enc.BeginField(3)
+// enc.EndField(start)
+// enc.EndFields()
+// *x = pair{}
+// num, field := dec.Field()
+// case 3:
+// x.Y = field.String()
+// size := 4
+// size += 8 + 8
+
+// Fields of AutoMarshal structs with numbered fields are encoded along with
+// their field numbers.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type pair struct {
+	weaver.AutoMarshal
+	X int    `weaver:"1"`
+	Y string `weaver:"3"`
+}
+
+type point struct {
+	weaver.AutoMarshal
+	X int `weaver:"1" json:"x"`
+	Y int `weaver:"2" json:"y"`
+}
+
+type foo interface {
+	M(context.Context, pair, point) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) M(context.Context, pair, point) error { return nil }
@@ -0,0 +1,62 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// RefData:
+// :wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/Main:⟧
+// :wEaVeRcOmPoNeNt:foo/A:Get,Put⟧
+// :wEaVeRcOmPoNeNt:foo/B:⟧
+// :wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→foo/A⟧
+// :wEaVeReDgE:foo/A→foo/B⟧
+// :wEaVeReDgE:foo/B→foo/A⟧
+
+// UNEXPECTED
+// :wEaVeReDgE:foo/A→foo/A⟧
+// :wEaVeReDgE:github.com/ServiceWeaver/weaver/Main→foo/B⟧
+
+// The component graph, including the weaver.Ref fields of every component
+// implementation, is embedded in the generated registrations.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type A interface {
+	Get(context.Context) error
+	Put(context.Context) error
+}
+
+type B interface{}
+
+type app struct {
+	weaver.Implements[weaver.Main]
+	a weaver.Ref[A]
+}
+
+type a struct {
+	weaver.Implements[A]
+	b weaver.Ref[B]
+}
+
+func (a) Get(context.Context) error { return nil }
+func (a) Put(context.Context) error { return nil }
+
+// A and B form a cycle.
+type b struct {
+	weaver.Implements[B]
+	a weaver.Ref[A]
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+// EXPECTED
+// Retriable:
+// []int{0, 2},
+
+// Retriable component methods.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type foo interface {
+	A(context.Context) error
+	B(context.Context) error
+	C(context.Context) error
+}
+
+var (
+	_ weaver.Retriable = foo.A
+	_ weaver.Retriable = foo.C
+)
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) A(context.Context) error { return nil }
+func (impl) B(context.Context) error { return nil }
+func (impl) C(context.Context) error { return nil }
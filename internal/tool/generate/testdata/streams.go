@@ -0,0 +1,62 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// Streams:
+// []int{0, 1, 2},
+// func (s foo_server_stub) GetStreamStubFn(method string)
+// stream, err = s.stub.Stream(ctx, 0, enc.Data(), shardKey)
+// codegen.SendArgs(stream, a1.Next, a1.Close
+// codegen.RecvResults(stream)
+// codegen.SendStream(stream.Send, r0.Next
+
+// Streaming component methods.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type entry struct {
+	weaver.AutoMarshal
+	Key   string
+	Value []byte
+}
+
+type foo interface {
+	Export(ctx context.Context, prefix string) (*weaver.Stream[entry], error)
+	Ingest(ctx context.Context, prefix string, entries *weaver.Stream[entry]) (int, error)
+	Transform(ctx context.Context, xs *weaver.Stream[int]) (*weaver.Stream[string], error)
+	Unary(ctx context.Context, x int) (int, error)
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) Export(context.Context, string) (*weaver.Stream[entry], error) {
+	return weaver.StreamOf[entry](), nil
+}
+
+func (impl) Ingest(context.Context, string, *weaver.Stream[entry]) (int, error) {
+	return 0, nil
+}
+
+func (impl) Transform(context.Context, *weaver.Stream[int]) (*weaver.Stream[string], error) {
+	return nil, nil
+}
+
+func (impl) Unary(context.Context, int) (int, error) {
+	return 0, nil
+}
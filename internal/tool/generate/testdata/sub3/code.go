@@ -0,0 +1,37 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events declares a sum type used by the components in another
+// package. It is not passed to the same invocation of "weaver generate".
+package events
+
+import "github.com/ServiceWeaver/weaver"
+
+type Event interface {
+	isEvent()
+}
+
+type Click struct {
+	weaver.AutoMarshal
+	X, Y int
+}
+
+func (Click) isEvent() {}
+
+type Scroll struct {
+	weaver.AutoMarshal
+	Delta int
+}
+
+func (*Scroll) isEvent() {}
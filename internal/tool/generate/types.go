@@ -295,6 +295,34 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 				break
 			}
 
+			// A named interface declared in the application's module is
+			// serializable if some struct that embeds weaver.AutoMarshal
+			// implements it. Its values are encoded along with their
+			// concrete types, which must be registered (see
+			// codegen.RegisterType). The values of interfaces declared in
+			// the standard library or in other modules, like io.Reader, are
+			// rarely registered types.
+			if isError(x) {
+				addError(fmt.Errorf("serialization of errors not currently supported"))
+				tset.checked.Set(t, false)
+				break
+			}
+			if iface, ok := x.Underlying().(*types.Interface); ok {
+				pkg := x.Obj().Pkg()
+				if pkg == nil || !tset.inModule(pkg.Path()) {
+					addError(fmt.Errorf("serialization of interfaces declared outside of the application's module not currently supported"))
+					tset.checked.Set(t, false)
+					break
+				}
+				if !hasAutoMarshalImplementer(iface, pkg, tset.pkg.Types) {
+					addError(fmt.Errorf("serialization of interfaces not implemented by any weaver.AutoMarshal struct not currently supported"))
+					tset.checked.Set(t, false)
+					break
+				}
+				tset.checked.Set(t, true)
+				break
+			}
+
 			// If the underlying type is not a struct, then we simply recurse
 			// on the underlying type.
 			s, ok := x.Underlying().(*types.Struct)
@@ -306,7 +334,8 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 			// If the underlying type is a struct that has not been declared to
 			// implement the AutoMarshal interface, then it is not
 			// serializable.
-			if tset.automarshalCandidates.At(t) == nil {
+			generic := isGenericAutoMarshal(x)
+			if tset.automarshalCandidates.At(t) == nil && !generic {
 				// TODO(mwhittaker): Print out a link to documentation on
 				// weaver.AutoMarshal.
 				addError(fmt.Errorf("named structs are not serializable by default. Consider using weaver.AutoMarshal."))
@@ -314,10 +343,28 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 				break
 			}
 
+			// An instantiation of a generic struct that embeds
+			// weaver.AutoMarshal is serialized by generated code in the
+			// current package, so its fields must be accessible. The field
+			// numbers of other AutoMarshal structs are checked in
+			// newGenerator.
+			serializable := true
+			if generic {
+				if _, err := fieldNumbers(s); err != nil {
+					addError(err)
+					serializable = false
+				}
+				for i := 0; i < s.NumFields(); i++ {
+					if f := s.Field(i); !f.Exported() && x.Obj().Pkg() != tset.pkg.Types {
+						addError(fmt.Errorf("field %s of generic struct %s is unexported. Generic structs declared in other packages must have exported fields to be serializable.", f.Name(), x.Obj().Name()))
+						serializable = false
+					}
+				}
+			}
+
 			// If the underlying type is a struct that has been declared to
 			// implement the AutoMarshal interface but hasn't yet been checked,
 			// then we need to recurse to detect cycles.
-			serializable := true
 			for i := 0; i < s.NumFields(); i++ {
 				f := s.Field(i)
 				// We store the result of calling check in b rather than
@@ -329,9 +376,7 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 			tset.checked.Set(t, serializable)
 
 		case *types.Interface:
-			// TODO(sanjay): Support types.Interface only if we can figure out
-			// a way to instantiate the type.
-			addError(fmt.Errorf("serialization of interfaces not currently supported"))
+			addError(fmt.Errorf("serialization of unnamed interfaces not currently supported. Consider declaring a named interface type."))
 			tset.checked.Set(t, false)
 
 		case *types.Struct:
@@ -404,6 +449,7 @@ func (tset *typeSet) sizeOfType(t types.Type) int {
 	//   s(basic) = size of basic
 	//   s([N]t) = N * s(t), if t is fixed size
 	//   s(struct{..., fi:ti, ...}) = sum of s(ti), if every ti is fixed size
+	//   s(type t struct{...}) = 4 + sum of (8 + s(ti)), if t has numbered fields
 	//   s(type t u) = s(u)
 	//   s(_) = -1
 	if size := tset.sizes.At(t); size != nil {
@@ -452,6 +498,18 @@ func (tset *typeSet) sizeOfType(t types.Type) int {
 
 	case *types.Named:
 		size := tset.sizeOfType(x.Underlying())
+		if s, ok := x.Underlying().(*types.Struct); ok && size >= 0 {
+			// Every numbered field is preceded by its field number and
+			// length, and the fields are followed by a zero field number.
+			if nums, _ := fieldNumbers(s); nums != nil {
+				size += 4
+				for _, num := range nums {
+					if num != 0 {
+						size += 8
+					}
+				}
+			}
+		}
 		tset.sizes.Set(t, size)
 		return size
 
@@ -650,6 +708,49 @@ func (tset *typeSet) implementsAutoMarshal(t types.Type) bool {
 	return isWeaverMarshal(t, marshal) && isWeaverUnmarshal(t, unmarshal)
 }
 
+// inModule returns whether the package with the provided path belongs to the
+// module of the package being generated.
+func (tset *typeSet) inModule(path string) bool {
+	if tset.pkg.Module == nil {
+		return path == tset.pkg.PkgPath
+	}
+	module := tset.pkg.Module.Path
+	return path == module || strings.HasPrefix(path, module+"/")
+}
+
+// hasAutoMarshalImplementer returns whether a struct declared in one of the
+// provided packages embeds weaver.AutoMarshal and implements iface, either
+// directly or through a pointer.
+func hasAutoMarshalImplementer(iface *types.Interface, pkgs ...*types.Package) bool {
+	for _, pkg := range pkgs {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			tn, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+			n, ok := tn.Type().(*types.Named)
+			if !ok || n.TypeParams().Len() > 0 {
+				continue
+			}
+			s, ok := n.Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+			for i := 0; i < s.NumFields(); i++ {
+				f := s.Field(i)
+				if f.Embedded() && isWeaverAutoMarshal(f.Type()) {
+					if types.Implements(n, iface) || types.Implements(types.NewPointer(n), iface) {
+						return true
+					}
+					break
+				}
+			}
+		}
+	}
+	return false
+}
+
 // isWeaverMarshal returns true if m is WeaverMarshal(*codegen.Encoder).
 func isWeaverMarshal(t types.Type, m *types.Func) bool {
 	if m.Name() != "WeaverMarshal" {
@@ -849,6 +950,10 @@ func isWeaverMain(t types.Type) bool {
 	return isWeaverType(t, "Main", 0)
 }
 
+func isWeaverRef(t types.Type) bool {
+	return isWeaverType(t, "Ref", 1)
+}
+
 func isWeaverWithConfig(t types.Type) bool {
 	return isWeaverType(t, "WithConfig", 1)
 }
@@ -857,10 +962,47 @@ func isWeaverWithRouter(t types.Type) bool {
 	return isWeaverType(t, "WithRouter", 1)
 }
 
+func isWeaverRetriable(t types.Type) bool {
+	return isWeaverType(t, "Retriable", 0)
+}
+
+func isWeaverStream(t types.Type) bool {
+	return isWeaverType(t, "Stream", 1)
+}
+
+// streamElem returns T if t is *weaver.Stream[T], or nil otherwise.
+func streamElem(t types.Type) types.Type {
+	p, ok := t.(*types.Pointer)
+	if !ok || !isWeaverStream(p.Elem()) {
+		return nil
+	}
+	return p.Elem().(*types.Named).TypeArgs().At(0)
+}
+
 func isWeaverAutoMarshal(t types.Type) bool {
 	return isWeaverType(t, "AutoMarshal", 0)
 }
 
+// isGenericAutoMarshal returns whether the provided type is an instantiation
+// of a generic struct that embeds weaver.AutoMarshal (e.g., Page[int], where
+// Page[T] embeds weaver.AutoMarshal).
+func isGenericAutoMarshal(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok || n.TypeArgs().Len() == 0 {
+		return false
+	}
+	s, ok := n.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < s.NumFields(); i++ {
+		if f := s.Field(i); f.Embedded() && isWeaverAutoMarshal(f.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
 func isContext(t types.Type) bool {
 	n, ok := t.(*types.Named)
 	if !ok {
@@ -869,6 +1011,12 @@ func isContext(t types.Type) bool {
 	return n.Obj().Pkg().Path() == "context" && n.Obj().Name() == "Context"
 }
 
+// implementsError returns whether t or *t implements the error interface.
+func implementsError(t types.Type) bool {
+	errorIntf := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return types.Implements(t, errorIntf) || types.Implements(types.NewPointer(t), errorIntf)
+}
+
 func isError(t types.Type) bool {
 	n, ok := t.(*types.Named)
 	if !ok {
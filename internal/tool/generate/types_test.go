@@ -20,6 +20,7 @@ import (
 	"go/token"
 	"go/types"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -75,6 +76,29 @@ func (t target) UnmarshalBinary([]byte) error { return nil }
 type target struct{}
 func (t target) MarshalBinary() ([]byte, error) { return nil, nil }
 func (t *target) UnmarshalBinary([]byte) error { return nil }
+`, ""},
+		{"interface", `
+import "github.com/ServiceWeaver/weaver"
+
+type target interface{
+	Area() float64
+}
+type square struct{
+	weaver.AutoMarshal
+	side float64
+}
+func (s square) Area() float64 { return s.side * s.side }
+`, ""},
+		{"interface slice", `
+import "github.com/ServiceWeaver/weaver"
+
+type shape interface{ Area() float64 }
+type target []shape
+type circle struct{
+	weaver.AutoMarshal
+	radius float64
+}
+func (c *circle) Area() float64 { return 3 * c.radius * c.radius }
 `, ""},
 		{"BinaryMarshaler recursive", `
 type target struct { next *target }
@@ -99,12 +123,25 @@ type target struct{
 }
 func (t *target) UnmarshalBinary([]byte) error { return nil }
 `, "not serializable"},
+		{"unnamed interface", "type target []interface{ Area() float64 }", "unnamed interfaces not currently supported"},
+		{"error", "type target []error", "errors not currently supported"},
+		{"interface without implementer", `
+type target interface{ Area() float64 }
+type square struct{ side float64 }
+func (s square) Area() float64 { return s.side * s.side }
+`, "interfaces not implemented by any weaver.AutoMarshal struct"},
 		{"interface", `
-type target interface{
-	MarshalBinary() ([]byte, error)
-	UnmarshalBinary([]byte) error
-}
-`, "not currently supported"},
+import "encoding"
+type target []encoding.BinaryMarshaler
+`, "interfaces declared outside of the application's module"},
+		{"io.Reader", `
+import "io"
+type target map[string]io.Reader
+`, "interfaces declared outside of the application's module"},
+		{"context.Context", `
+import "context"
+type target []context.Context
+`, "interfaces declared outside of the application's module"},
 		{"simple recursive", `
 type target *target
 `, "not currently supported"},
@@ -274,15 +311,28 @@ func compile(t *testing.T, contents string) (*typeSet, types.Type) {
 			t.Fatalf("error writing %s: %v", f, err)
 		}
 	}
-	save("go.mod", "module foo\ngo 1.16\n")
 	save("contents.go", "package foo\n"+contents)
+	if strings.Contains(contents, weaverPackagePath) {
+		// Resolve the weaver module, as in runGenerator.
+		save("go.mod", goModFile)
+		tidy := exec.Command("go", "mod", "tidy")
+		tidy.Dir = tmp
+		tidy.Stdout = os.Stdout
+		tidy.Stderr = os.Stderr
+		if err := tidy.Run(); err != nil {
+			t.Fatalf("go mod tidy: %v", err)
+		}
+	} else {
+		save("go.mod", "module foo\ngo 1.16\n")
+	}
 	fset := token.NewFileSet()
 	cfg := &packages.Config{
 		Mode: packages.NeedName |
 			packages.NeedSyntax |
 			packages.NeedImports |
 			packages.NeedTypes |
-			packages.NeedTypesInfo,
+			packages.NeedTypesInfo |
+			packages.NeedModule,
 		Dir:  tmp,
 		Fset: fset,
 	}
@@ -0,0 +1,95 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"math"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/metrics"
+)
+
+// The interval at which the deployer autoscales co-location groups.
+const autoscaleInterval = 10 * time.Second
+
+// The amount of time a weavelet removed by the autoscaler has to finish the
+// method calls in progress before it is stopped.
+const drainTimeout = 10 * time.Second
+
+// A loadTracker computes the load of the components of a deployment from the
+// method latency metrics reported by the weavelets. The load of a component
+// over an interval is the average number of method calls to the component in
+// progress. By Little's law, it is equal to the total latency of the calls
+// completed during the interval divided by the length of the interval.
+//
+// Note that only remote method calls are measured.
+type loadTracker struct {
+	last     map[metricKey]float64 // latest latency sums
+	lastTime time.Time             // time of the latest update
+}
+
+// metricKey uniquely identifies a metric across weavelets.
+type metricKey struct {
+	node string // weavelet id
+	id   uint64 // metric id, unique within a weavelet
+}
+
+// update updates the tracker with a new set of metric snapshots, taken at the
+// provided time, and returns the load of every component since the previous
+// update, keyed by component. The first update returns nil.
+func (t *loadTracker) update(now time.Time, snapshots []*metrics.MetricSnapshot) map[string]float64 {
+	latencies := map[metricKey]float64{}
+	var loads map[string]float64
+	elapsed := now.Sub(t.lastTime).Seconds()
+	if t.last != nil && elapsed > 0 {
+		loads = map[string]float64{}
+	}
+	for _, m := range snapshots {
+		if m.Name != codegen.MethodLatencies.Name() {
+			continue
+		}
+		key := metricKey{node: m.Labels["serviceweaver_node"], id: m.Id}
+		latencies[key] = m.Value
+		if loads == nil {
+			continue
+		}
+		// A metric missing from the previous update, e.g., the metric of
+		// a weavelet that just started, was zero at the time.
+		delta := m.Value - t.last[key]
+		loads[m.Labels["component"]] += delta / 1e6 / elapsed // µs to s
+	}
+	t.last = latencies
+	t.lastTime = now
+	return loads
+}
+
+// desiredReplicas returns the number of replicas a co-location group with the
+// provided number of replicas and load should have, aiming for the provided
+// load per replica. The group is scaled up as much as needed, but scaled down
+// by a single replica at a time, to avoid overreacting to a dip in load.
+func desiredReplicas(replicas int, load, target float64, bounds replicaConfig) int {
+	want := int(math.Ceil(load / target))
+	if want < replicas-1 {
+		want = replicas - 1
+	}
+	if want < bounds.MinReplicas {
+		want = bounds.MinReplicas
+	}
+	if want > bounds.MaxReplicas {
+		want = bounds.MaxReplicas
+	}
+	return want
+}
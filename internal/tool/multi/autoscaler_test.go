@@ -0,0 +1,80 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/metrics"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDesiredReplicas(t *testing.T) {
+	bounds := replicaConfig{MinReplicas: 2, MaxReplicas: 6}
+	for _, test := range []struct {
+		replicas int
+		load     float64
+		want     int
+	}{
+		{2, 0, 2},   // at the minimum
+		{2, 20, 2},  // at the target
+		{2, 21, 3},  // above the target
+		{2, 45, 5},  // far above the target
+		{2, 100, 6}, // at the maximum
+		{5, 0, 4},   // scale down one replica at a time
+		{3, 15, 2},  // below the target
+		{8, 100, 6}, // above the maximum
+	} {
+		if got := desiredReplicas(test.replicas, test.load, 10, bounds); got != test.want {
+			t.Errorf("desiredReplicas(%d, %v): got %d, want %d", test.replicas, test.load, got, test.want)
+		}
+	}
+}
+
+func TestLoadTracker(t *testing.T) {
+	latency := func(node string, id uint64, component string, micros float64) *metrics.MetricSnapshot {
+		return &metrics.MetricSnapshot{
+			Id:   id,
+			Name: codegen.MethodLatencies.Name(),
+			Labels: map[string]string{
+				"serviceweaver_node": node,
+				"component":          component,
+			},
+			Value: micros,
+		}
+	}
+
+	var tracker loadTracker
+	now := time.Now()
+	if got := tracker.update(now, []*metrics.MetricSnapshot{latency("a", 1, "Foo", 1e6)}); got != nil {
+		t.Fatalf("first update: got %v, want nil", got)
+	}
+
+	// Over 10 seconds, Foo spends 20 seconds serving calls on node a and
+	// another 10 seconds on node b, which just started. Bar spends 5 seconds.
+	now = now.Add(10 * time.Second)
+	got := tracker.update(now, []*metrics.MetricSnapshot{
+		latency("a", 1, "Foo", 21e6),
+		latency("b", 1, "Foo", 10e6),
+		latency("b", 2, "Bar", 5e6),
+		{Id: 3, Name: "other", Labels: map[string]string{"component": "Bar"}, Value: 1e9},
+	})
+	want := map[string]float64{"Foo": 3, "Bar": 0.5}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("(-want +got):\n%s", diff)
+	}
+}
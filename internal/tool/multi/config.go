@@ -0,0 +1,197 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+// Restart policies for weavelets that exit.
+const (
+	// restartAlways restarts weavelets whenever they exit.
+	restartAlways = "always"
+
+	// restartOnFailure restarts weavelets that exit with a non-zero exit
+	// code or are terminated by a signal.
+	restartOnFailure = "on-failure"
+
+	// restartNever never restarts weavelets.
+	restartNever = "never"
+)
+
+// multiConfig holds the multi section of a config file. For example:
+//
+//	[multi]
+//	restart_policy = "on-failure"
+//	max_restarts = 5
+//	restart_window = "1m"
+//	min_replicas = 2
+//	max_replicas = 4
+//	rollback_error_rate = 0.05
+//
+//	[multi.groups."github.com/my/project/package/Cache"]
+//	min_replicas = 1
+//	max_replicas = 8
+type multiConfig struct {
+	// RestartPolicy determines which weavelets are restarted when they
+	// exit. It is one of restartAlways, restartOnFailure, and restartNever.
+	// Defaults to restartOnFailure.
+	RestartPolicy string `toml:"restart_policy"`
+
+	// MaxRestarts is the maximum number of times the weavelets of a
+	// co-location group are restarted within RestartWindow. Once the limit
+	// is exceeded, the deployment is stopped. Defaults to 5.
+	MaxRestarts int `toml:"max_restarts"`
+
+	// RestartWindow is the window of time over which MaxRestarts applies.
+	// Defaults to one minute.
+	RestartWindow time.Duration `toml:"restart_window"`
+
+	// MinReplicas and MaxReplicas bound the number of replicas of every
+	// co-location group. A group with fewer MinReplicas than MaxReplicas is
+	// autoscaled based on its load. MinReplicas defaults to 2, and
+	// MaxReplicas defaults to MinReplicas.
+	MinReplicas int `toml:"min_replicas"`
+	MaxReplicas int `toml:"max_replicas"`
+
+	// TargetLoad is the load per replica the autoscaler aims for. The load
+	// of a group is the average number of method calls to its components in
+	// progress. Defaults to 10.
+	TargetLoad float64 `toml:"target_load"`
+
+	// RollbackErrorRate is the largest increase in error rate, relative to
+	// the version being replaced, that a version being rolled out may have.
+	// A rollout that exceeds it is rolled back. Defaults to 0.05.
+	RollbackErrorRate float64 `toml:"rollback_error_rate"`
+
+	// Groups overrides the replica bounds of individual co-location groups,
+	// keyed by the name of any component in the group.
+	Groups map[string]*replicaConfig `toml:"groups"`
+}
+
+// replicaConfig holds the replica bounds of a co-location group. Zero values
+// default to the bounds in the enclosing multiConfig.
+type replicaConfig struct {
+	MinReplicas int `toml:"min_replicas"`
+	MaxReplicas int `toml:"max_replicas"`
+}
+
+// Validate validates and fills in the defaults of a multiConfig.
+func (c *multiConfig) Validate() error {
+	switch c.RestartPolicy {
+	case "":
+		c.RestartPolicy = restartOnFailure
+	case restartAlways, restartOnFailure, restartNever:
+	default:
+		return fmt.Errorf("unknown restart_policy %q; want %q, %q, or %q", c.RestartPolicy, restartAlways, restartOnFailure, restartNever)
+	}
+	if c.MaxRestarts < 0 {
+		return fmt.Errorf("negative max_restarts %d", c.MaxRestarts)
+	}
+	if c.MaxRestarts == 0 {
+		c.MaxRestarts = 5
+	}
+	if c.RestartWindow < 0 {
+		return fmt.Errorf("negative restart_window %v", c.RestartWindow)
+	}
+	if c.RestartWindow == 0 {
+		c.RestartWindow = time.Minute
+	}
+
+	if c.MinReplicas == 0 {
+		c.MinReplicas = defaultReplication
+	}
+	if c.MaxReplicas == 0 {
+		c.MaxReplicas = c.MinReplicas
+	}
+	if err := validateReplicas(c.MinReplicas, c.MaxReplicas); err != nil {
+		return err
+	}
+	if c.TargetLoad < 0 {
+		return fmt.Errorf("negative target_load %v", c.TargetLoad)
+	}
+	if c.TargetLoad == 0 {
+		c.TargetLoad = 10
+	}
+	if c.RollbackErrorRate < 0 || c.RollbackErrorRate > 1 {
+		return fmt.Errorf("rollback_error_rate %v not in [0, 1]", c.RollbackErrorRate)
+	}
+	if c.RollbackErrorRate == 0 {
+		c.RollbackErrorRate = 0.05
+	}
+	for component, g := range c.Groups {
+		if g.MinReplicas == 0 {
+			g.MinReplicas = c.MinReplicas
+		}
+		if g.MaxReplicas == 0 {
+			g.MaxReplicas = c.MaxReplicas
+			if g.MaxReplicas < g.MinReplicas {
+				g.MaxReplicas = g.MinReplicas
+			}
+		}
+		if err := validateReplicas(g.MinReplicas, g.MaxReplicas); err != nil {
+			return fmt.Errorf("group of %q: %w", component, err)
+		}
+	}
+	return nil
+}
+
+// validateReplicas validates replica bounds.
+func validateReplicas(min, max int) error {
+	if min < 1 {
+		return fmt.Errorf("min_replicas %d is less than 1", min)
+	}
+	if max < min {
+		return fmt.Errorf("max_replicas %d is less than min_replicas %d", max, min)
+	}
+	return nil
+}
+
+// replicas returns the replica bounds of the co-location group with the
+// provided name, given the mapping from components to the names of their
+// co-location groups.
+func (c *multiConfig) replicas(group string, colocation map[string]string) replicaConfig {
+	for component, g := range c.Groups {
+		name, ok := colocation[component]
+		if !ok {
+			name = component
+		}
+		if name == group {
+			return *g
+		}
+	}
+	return replicaConfig{MinReplicas: c.MinReplicas, MaxReplicas: c.MaxReplicas}
+}
+
+// parseMultiConfig parses the multi section of the provided config.
+func parseMultiConfig(app *protos.AppConfig) (*multiConfig, error) {
+	const multiKey = "github.com/ServiceWeaver/weaver/multi"
+	const shortMultiKey = "multi"
+
+	config := &multiConfig{}
+	if err := runtime.ParseConfigSection(multiKey, shortMultiKey, app.Sections, config); err != nil {
+		return nil, fmt.Errorf("parse multi config: %w", err)
+	}
+	// Validate is only called by ParseConfigSection if the section is
+	// present, so we call it again to fill in the defaults.
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("parse multi config: %w", err)
+	}
+	return config, nil
+}
@@ -20,22 +20,19 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"net"
-	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/files"
 	"github.com/ServiceWeaver/weaver/internal/status"
-	"github.com/ServiceWeaver/weaver/runtime"
-	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/colors"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
-	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"github.com/ServiceWeaver/weaver/runtime/tool"
 	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
 )
 
 var deployCmd = tool.Command{
@@ -58,95 +55,58 @@ func deploy(ctx context.Context, args []string) error {
 	}
 
 	// Load the config file.
-	configFile := args[0]
-	bytes, err := os.ReadFile(configFile)
+	config, err := loadConfig(args[0])
 	if err != nil {
-		return fmt.Errorf("load config file %q: %w\n", configFile, err)
-	}
-	config, err := runtime.ParseConfig(configFile, string(bytes), codegen.ComponentConfigValidator)
-	if err != nil {
-		return fmt.Errorf("load config file %q: %w\n", configFile, err)
-	}
-
-	// Sanity check the config.
-	if _, err := os.Stat(config.Binary); errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("binary %q doesn't exist", config.Binary)
-	}
-
-	// Create the deployer.
-	deploymentId := uuid.New().String()
-	d, err := newDeployer(ctx, deploymentId, config)
-	if err != nil {
-		return fmt.Errorf("create deployer: %w", err)
+		return err
 	}
 
-	// Run a status server.
-	lis, err := net.Listen("tcp", "localhost:0")
+	// Create the proxies, which are shared by all the versions of the app.
+	logsDB, err := logging.NewFileStore(logdir)
 	if err != nil {
-		return fmt.Errorf("listen: %w", err)
-	}
-	mux := http.NewServeMux()
-	status.RegisterServer(mux, d, d.logger)
-	go func() {
-		if err := serveHTTP(ctx, lis, mux); err != nil {
-			fmt.Fprintf(os.Stderr, "status server: %v\n", err)
-		}
-	}()
-
-	// Deploy main.
-	if err := d.startMain(); err != nil {
-		return fmt.Errorf("start main process: %w", err)
-	}
-
-	// Wait for the status server to become active.
-	client := status.NewClient(lis.Addr().String())
-	for r := retry.Begin(); r.Continue(ctx); {
-		_, err := client.Status(ctx)
-		if err == nil {
-			break
-		}
-		fmt.Fprintf(os.Stderr, "status server %q unavailable: %#v\n", lis.Addr(), err)
-	}
-
-	// Register the deployment.
+		return fmt.Errorf("cannot create log storage: %w", err)
+	}
+	logger := slog.New(&logging.LogHandler{
+		Opts: logging.Options{
+			App:       config.Name,
+			Component: "proxy",
+			Weavelet:  uuid.NewString(),
+			Attrs:     []string{"serviceweaver/system", ""},
+		},
+		Write: logsDB.Add,
+	})
+	proxies := newProxies(ctx, logger)
+
+	// Deploy the app.
 	registry, err := defaultRegistry(ctx)
 	if err != nil {
 		return fmt.Errorf("create registry: %w", err)
 	}
-	reg := status.Registration{
-		DeploymentId: deploymentId,
-		App:          config.Name,
-		Addr:         lis.Addr().String(),
-	}
-	fmt.Fprint(os.Stderr, reg.Rolodex())
-	if err := registry.Register(ctx, reg); err != nil {
-		return fmt.Errorf("register deployment: %w", err)
+	c := newController(ctx, config.Name, registry, proxies)
+	started := time.Now()
+	if _, err := c.start(config); err != nil {
+		return err
 	}
 
 	userDone := make(chan os.Signal, 1)
-	deployerDone := make(chan error, 1)
-	go func() {
-		err := d.wait()
-		deployerDone <- err
-	}()
 	signal.Notify(userDone, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		// Wait for the user to kill the app or the app to return an error.
 		select {
 		case <-userDone:
 			fmt.Fprintf(os.Stderr, "Application %s terminated by the user\n", config.Name)
-		case err := <-deployerDone:
+		case err := <-c.failed:
 			fmt.Fprintf(os.Stderr, "Application %s error: %v\n", config.Name, err)
 		}
-		if err := registry.Unregister(ctx, deploymentId); err != nil {
-			fmt.Fprintf(os.Stderr, "unregister deployment: %v\n", err)
-		}
+		c.unregister()
 		os.Exit(1)
 	}()
 
-	// Follow the logs.
+	// Follow the logs of all the versions of the app rolled out by this
+	// deployment, not just the first one. The versions aren't known in
+	// advance, so the query selects the logs of every version of the app,
+	// and the logs of versions started by other deployments are skipped.
 	source := logging.FileSource(logdir)
-	query := fmt.Sprintf(`full_version == %q && !("serviceweaver/system" in attrs)`, deploymentId)
+	query := fmt.Sprintf(`app == %q && time >= timestamp(%q) && !("serviceweaver/system" in attrs)`, config.Name, started.Format(time.RFC3339Nano))
 	r, err := source.Query(ctx, query, true)
 	if err != nil {
 		return err
@@ -159,6 +119,9 @@ func deploy(ctx context.Context, args []string) error {
 		} else if err != nil {
 			return err
 		}
+		if !c.isStarted(entry.Version) {
+			continue
+		}
 		fmt.Println(pp.Format(entry))
 	}
 }
@@ -18,16 +18,13 @@ import (
 	"context"
 	"crypto"
 	"crypto/x509"
-	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"sync"
-	"syscall"
 	"time"
 
 	imetrics "github.com/ServiceWeaver/weaver/internal/metrics"
-	"github.com/ServiceWeaver/weaver/internal/proxy"
 	"github.com/ServiceWeaver/weaver/internal/routing"
 	"github.com/ServiceWeaver/weaver/internal/status"
 	"github.com/ServiceWeaver/weaver/internal/tool/certs"
@@ -38,6 +35,7 @@ import (
 	"github.com/ServiceWeaver/weaver/runtime/perfetto"
 	"github.com/ServiceWeaver/weaver/runtime/profiling"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/exp/maps"
@@ -50,6 +48,10 @@ import (
 // The default number of times a component is replicated.
 const defaultReplication = 2
 
+// The interval at which the deployer rebalances routed components based on
+// the load reported by the weavelets hosting them.
+const rebalanceInterval = 30 * time.Second
+
 // A deployer manages an application deployment.
 type deployer struct {
 	ctx          context.Context
@@ -63,6 +65,8 @@ type deployer struct {
 	running      errgroup.Group
 	logsDB       *logging.FileStore
 	traceDB      *perfetto.DB
+	multi        *multiConfig
+	proxies      *proxies // proxies, shared with other versions
 
 	// statsProcessor tracks and computes stats to be rendered on the /statusz page.
 	statsProcessor *imetrics.StatsProcessor
@@ -84,17 +88,19 @@ type deployer struct {
 	// their group.
 	colocation map[string]string
 
-	mu      sync.Mutex            // guards the following
-	err     error                 // error that stopped the babysitter
-	groups  map[string]*group     // groups, by group name
-	proxies map[string]*proxyInfo // proxies, by listener name
-
+	mu       sync.Mutex        // guards the following
+	err      error             // error that stopped the babysitter
+	stopping bool              // is the deployer shutting down?
+	groups   map[string]*group // groups, by group name
 }
 
 // A group contains information about a co-location group.
 type group struct {
 	name        string                          // group name
-	envelopes   []*envelope.Envelope            // envelopes, one per weavelet
+	started     bool                            // has the group been started?
+	bounds      replicaConfig                   // bounds on the number of replicas
+	restarter   *restarter                      // restarts exited weavelets
+	replicas    []*handler                      // replicas, one per weavelet
 	pids        []int64                         // weavelet pids
 	components  map[string]bool                 // started components
 	addresses   map[string]bool                 // weavelet addresses
@@ -102,26 +108,22 @@ type group struct {
 	subscribers map[string][]*envelope.Envelope // routing info subscribers, by component
 }
 
-// A proxyInfo contains information about a proxy.
-type proxyInfo struct {
-	listener string       // listener associated with the proxy
-	proxy    *proxy.Proxy // the proxy
-	addr     string       // dialable address of the proxy
-}
-
 // handler handles a connection to a weavelet.
 type handler struct {
 	*deployer
 	g          *group
 	envelope   *envelope.Envelope
-	subscribed map[string]bool // routing info subscriptions, by component
+	subscribed map[string]bool   // routing info subscriptions, by component
+	exported   map[string]string // exported listener addresses, by listener
+	stopped    bool              // stopped by the autoscaler? guarded by mu
 }
 
 var _ envelope.EnvelopeHandler = &handler{}
 
-// newDeployer creates a new deployer. The deployer can be stopped at any
-// time by canceling the passed-in context.
-func newDeployer(ctx context.Context, deploymentId string, config *protos.AppConfig) (*deployer, error) {
+// newDeployer creates a new deployer that exports listeners through the
+// provided proxies. The deployer can be stopped at any time by canceling the
+// passed-in context.
+func newDeployer(ctx context.Context, deploymentId string, config *protos.AppConfig, proxies *proxies) (*deployer, error) {
 	// Create the log saver.
 	logsDB, err := logging.NewFileStore(logdir)
 	if err != nil {
@@ -141,6 +143,12 @@ func newDeployer(ctx context.Context, deploymentId string, config *protos.AppCon
 		return nil, fmt.Errorf("cannot generate signing certificate: %w", err)
 	}
 
+	// Parse the multi config.
+	multi, err := parseMultiConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the trace saver.
 	traceDB, err := perfetto.Open(ctx, "multi")
 	if err != nil {
@@ -155,6 +163,19 @@ func newDeployer(ctx context.Context, deploymentId string, config *protos.AppCon
 		}
 	}
 
+	// Check that every co-location group has at most one replica override.
+	overrides := map[string]string{}
+	for component := range multi.Groups {
+		group, ok := colocation[component]
+		if !ok {
+			group = component
+		}
+		if other, ok := overrides[group]; ok {
+			return nil, fmt.Errorf("parse multi config: components %q and %q are in the same co-location group, but have separate replica overrides", other, component)
+		}
+		overrides[group] = component
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	d := &deployer{
 		ctx:            ctx,
@@ -164,13 +185,14 @@ func newDeployer(ctx context.Context, deploymentId string, config *protos.AppCon
 		caKey:          caKey,
 		logsDB:         logsDB,
 		traceDB:        traceDB,
+		multi:          multi,
 		statsProcessor: imetrics.NewStatsProcessor(),
 		deploymentId:   deploymentId,
 		config:         config,
 		started:        time.Now(),
 		colocation:     colocation,
 		groups:         map[string]*group{},
-		proxies:        map[string]*proxyInfo{},
+		proxies:        proxies,
 	}
 
 	// Start a goroutine that collects metrics.
@@ -180,6 +202,20 @@ func newDeployer(ctx context.Context, deploymentId string, config *protos.AppCon
 		return err
 	})
 
+	// Start a goroutine that rebalances routed components.
+	d.running.Go(func() error {
+		err := d.rebalance(d.ctx)
+		d.stop(err)
+		return err
+	})
+
+	// Start a goroutine that autoscales co-location groups.
+	d.running.Go(func() error {
+		err := d.autoscale(d.ctx)
+		d.stop(err)
+		return err
+	})
+
 	// Start a goroutine that watches for context cancelation.
 	d.running.Go(func() error {
 		<-d.ctx.Done()
@@ -217,6 +253,40 @@ func (d *deployer) stop(err error) {
 	d.ctxCancel()
 }
 
+// shutdown gracefully stops the deployer. Every weavelet is given until the
+// provided context is done to finish the method calls in progress, after which
+// the deployer is stopped with the provided error.
+//
+// REQUIRES: err != nil
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) shutdown(ctx context.Context, err error) {
+	// Stop supervising the weavelets, so they aren't restarted.
+	d.mu.Lock()
+	d.stopping = true
+	var replicas []*handler
+	for _, g := range d.groups {
+		for _, h := range g.replicas {
+			h.stopped = true
+			replicas = append(replicas, h)
+		}
+	}
+	d.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, h := range replicas {
+		h := h
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := h.envelope.Shutdown(ctx); err != nil {
+				d.logger.Error("Cannot gracefully stop weavelet", "err", err, "group", h.g.name)
+			}
+		}()
+	}
+	wg.Wait()
+	d.stop(err)
+}
+
 // group returns the co-location group containing the provided component.
 //
 // REQUIRES: d.mu is held.
@@ -230,6 +300,8 @@ func (d *deployer) group(component string) *group {
 	if !ok {
 		g = &group{
 			name:        name,
+			bounds:      d.multi.replicas(name, d.colocation),
+			restarter:   newRestarter(d.multi),
 			components:  map[string]bool{},
 			addresses:   map[string]bool{},
 			assignments: map[string]*protos.Assignment{},
@@ -256,73 +328,143 @@ func (g *group) routing(component string) *protos.RoutingInfo {
 //
 // REQUIRES: d.mu is held.
 func (d *deployer) startColocationGroup(g *group) error {
+	if g.started {
+		return nil
+	}
+	for r := 0; r < g.bounds.MinReplicas; r++ {
+		h, err := d.startReplica(g)
+		if err != nil {
+			return err
+		}
+		d.running.Go(func() error { return d.supervise(h) })
+	}
+	g.started = true
+	return nil
+}
+
+// startReplica starts a new colocation group replica (i.e., a weavelet) and
+// registers it. It returns the handler of the started weavelet, which the
+// caller should pass to supervise.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) startReplica(g *group) (*handler, error) {
 	// Check if the deployer has already been stopped. The cleanup protocol
 	// requires that no further envelopes be started after the deployer
 	// has been stopped.
 	if d.err != nil {
-		return d.err
+		return nil, d.err
 	}
-	if len(g.envelopes) == defaultReplication {
-		// Already started.
-		return nil
+	if d.stopping {
+		return nil, fmt.Errorf("deployer is shutting down")
 	}
 
-	components := maps.Keys(g.components)
-	for r := 0; r < defaultReplication; r++ {
-		// Generate a signed certificate that encodes the group name.
-		cert, key, err := certs.GenerateSignedCert(d.caCert, d.caKey, g.name)
-		if err != nil {
-			return fmt.Errorf("cannot generate cert: %w", err)
-		}
-		certPEM, keyPEM, err := certs.PEMEncode(cert, key)
-		if err != nil {
-			return fmt.Errorf("cannot PEM-encode cert: %w", err)
-		}
-
-		// Start the weavelet and capture its logs, traces, and metrics.
-		info := &protos.EnvelopeInfo{
-			App:           d.config.Name,
-			DeploymentId:  d.deploymentId,
-			Id:            uuid.New().String(),
-			Sections:      d.config.Sections,
-			SingleProcess: false,
-			SingleMachine: true,
-			RunMain:       g.components[runtime.Main],
-			SelfCertChain: certPEM,
-			SelfKey:       keyPEM,
-		}
-		e, err := envelope.NewEnvelope(d.ctx, info, d.config)
-		if err != nil {
+	// Generate a signed certificate that encodes the group name.
+	cert, key, err := certs.GenerateSignedCert(d.caCert, d.caKey, g.name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate cert: %w", err)
+	}
+	certPEM, keyPEM, err := certs.PEMEncode(cert, key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot PEM-encode cert: %w", err)
+	}
+
+	// Start the weavelet and capture its logs, traces, and metrics.
+	info := &protos.EnvelopeInfo{
+		App:           d.config.Name,
+		DeploymentId:  d.deploymentId,
+		Id:            uuid.New().String(),
+		Sections:      d.config.Sections,
+		SingleProcess: false,
+		SingleMachine: true,
+		RunMain:       g.components[runtime.Main],
+		SelfCertChain: certPEM,
+		SelfKey:       keyPEM,
+	}
+	e, err := envelope.NewEnvelope(d.ctx, info, d.config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the version of the deployer matches the version of the
+	// compiled binary.
+	wlet := e.WeaveletInfo()
+	if err := checkVersion(wlet.Version); err != nil {
+		return nil, err
+	}
+
+	h := &handler{
+		deployer:   d,
+		g:          g,
+		subscribed: map[string]bool{},
+		exported:   map[string]string{},
+		envelope:   e,
+	}
+	d.registerReplica(g, wlet)
+	if err := e.UpdateComponents(maps.Keys(g.components)); err != nil {
+		return nil, err
+	}
+	g.replicas = append(g.replicas, h)
+	return h, nil
+}
+
+// supervise serves the weavelet with the provided handler. When the weavelet
+// exits, supervise unregisters it and, if the group's restart policy allows,
+// starts a replacement weavelet, backing off exponentially between
+// consecutive restarts. Otherwise, supervise stops the deployment.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) supervise(h *handler) error {
+	g := h.g
+	r := retry.BeginWithOptions(restartBackoff)
+	r.Continue(d.ctx) // the first call doesn't sleep
+	for {
+		started := time.Now()
+		err := h.envelope.Serve(h)
+		if d.ctx.Err() != nil {
+			// The deployment was stopped, which stopped the weavelet.
+			d.stop(err)
 			return err
 		}
 
-		// Make sure the version of the deployer matches the version of the
-		// compiled binary.
-		wlet := e.WeaveletInfo()
-		if err := checkVersion(wlet.Version); err != nil {
+		code := h.envelope.ExitCode()
+		d.mu.Lock()
+		if h.stopped {
+			// The weavelet was stopped by the autoscaler, which already
+			// unregistered it.
+			d.mu.Unlock()
+			return nil
+		}
+		d.unregisterReplica(h)
+		restartErr := g.restarter.restart(code)
+		d.mu.Unlock()
+		if restartErr != nil {
+			err := fmt.Errorf("group %q: %w: %v", g.name, restartErr, err)
+			d.stop(err)
 			return err
 		}
+		d.logger.Error("Weavelet exited; restarting", "err", err, "group", g.name, "exit_code", code)
 
-		d.running.Go(func() error {
-			h := &handler{
-				deployer:   d,
-				g:          g,
-				subscribed: map[string]bool{},
-				envelope:   e,
-			}
-			err := e.Serve(h)
+		// A weavelet that ran for a whole restart window is considered to
+		// have been healthy, so we restart it without back-off.
+		if time.Since(started) >= d.multi.RestartWindow {
+			r.Reset()
+			r.Continue(d.ctx) // the first call doesn't sleep
+		}
+		if !r.Continue(d.ctx) {
+			err := d.ctx.Err()
 			d.stop(err)
 			return err
-		})
-		if err := d.registerReplica(g, wlet); err != nil {
-			return err
 		}
-		if err := e.UpdateComponents(components); err != nil {
+
+		d.mu.Lock()
+		h, err = d.startReplica(g)
+		d.mu.Unlock()
+		if err != nil {
+			err := fmt.Errorf("group %q: restart weavelet: %w", g.name, err)
+			d.stop(err)
 			return err
 		}
-		g.envelopes = append(g.envelopes, e)
 	}
-	return nil
 }
 
 // checkVersion checks that the deployer API version the deployer was built
@@ -443,8 +585,8 @@ func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error
 
 		// Notify the weavelets.
 		components := maps.Keys(target.components)
-		for _, envelope := range target.envelopes {
-			if err := envelope.UpdateComponents(components); err != nil {
+		for _, replica := range target.replicas {
+			if err := replica.envelope.UpdateComponents(components); err != nil {
 				return err
 			}
 		}
@@ -458,12 +600,7 @@ func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error
 		}
 
 		// Notify the subscribers.
-		routing := target.routing(req.Component)
-		for _, sub := range target.subscribers[req.Component] {
-			if err := sub.UpdateRoutingInfo(routing); err != nil {
-				return err
-			}
-		}
+		d.notify(target, req.Component)
 	}
 
 	// Start the co-location group, if it hasn't started already.
@@ -472,15 +609,55 @@ func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error
 
 // registerReplica registers the information about a colocation group replica
 // (i.e., a weavelet).
-func (d *deployer) registerReplica(g *group, info *protos.WeaveletInfo) error {
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) registerReplica(g *group, info *protos.WeaveletInfo) {
 	// Update addresses and pids.
 	if g.addresses[info.DialAddr] {
 		// Replica already registered.
-		return nil
+		return
 	}
 	g.addresses[info.DialAddr] = true
 	g.pids = append(g.pids, info.Pid)
+	d.reassign(g)
+}
 
+// unregisterReplica unregisters a colocation group replica (i.e., a weavelet)
+// that has exited.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) unregisterReplica(h *handler) {
+	g, e := h.g, h.envelope
+	info := e.WeaveletInfo()
+	delete(g.addresses, info.DialAddr)
+	if i := slices.Index(g.pids, info.Pid); i >= 0 {
+		g.pids = slices.Delete(g.pids, i, i+1)
+	}
+	if i := slices.Index(g.replicas, h); i >= 0 {
+		g.replicas = slices.Delete(g.replicas, i, i+1)
+	}
+
+	// Stop sending routing info to the weavelet.
+	for _, target := range d.groups {
+		for component, subs := range target.subscribers {
+			if i := slices.Index(subs, e); i >= 0 {
+				target.subscribers[component] = slices.Delete(subs, i, i+1)
+			}
+		}
+	}
+
+	// Stop proxying traffic to the weavelet's listeners.
+	for listener, addr := range h.exported {
+		d.proxies.removeBackend(d.deploymentId, listener, addr)
+	}
+	d.reassign(g)
+}
+
+// reassign updates the assignments of the routed components hosted by the
+// provided group after its replicas change, and notifies the subscribers.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) reassign(g *group) {
 	// Update all assignments.
 	replicas := maps.Keys(g.addresses)
 	for component, assignment := range g.assignments {
@@ -491,14 +668,176 @@ func (d *deployer) registerReplica(g *group, info *protos.WeaveletInfo) error {
 
 	// Notify subscribers.
 	for component := range g.components {
-		routing := g.routing(component)
-		for _, sub := range g.subscribers[component] {
-			if err := sub.UpdateRoutingInfo(routing); err != nil {
+		d.notify(g, component)
+	}
+}
+
+// notify sends the latest routing info of the provided component to its
+// subscribers. Failures are logged rather than returned: a subscriber that
+// can't be reached has most likely exited, and will be unregistered by its
+// supervisor.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) notify(g *group, component string) {
+	routing := g.routing(component)
+	for _, sub := range g.subscribers[component] {
+		if err := sub.UpdateRoutingInfo(routing); err != nil {
+			d.logger.Error("Cannot update routing info", "err", err, "component", component)
+		}
+	}
+}
+
+// stopReplica gracefully stops a colocation group replica (i.e., a weavelet).
+// The replica is unregistered right away, so that it stops receiving new
+// method calls, and then given drainTimeout to finish the calls in progress.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) stopReplica(h *handler) {
+	h.stopped = true
+	d.unregisterReplica(h)
+	go func() {
+		ctx, cancel := context.WithTimeout(d.ctx, drainTimeout)
+		defer cancel()
+		if err := h.envelope.Shutdown(ctx); err != nil {
+			d.logger.Error("Cannot gracefully stop weavelet", "err", err, "group", h.g.name)
+		}
+	}()
+}
+
+// autoscale periodically adds and removes replicas of the co-location groups
+// that have fewer minimum than maximum replicas, based on their load.
+func (d *deployer) autoscale(ctx context.Context) error {
+	ticker := time.NewTicker(autoscaleInterval)
+	defer ticker.Stop()
+	var tracker loadTracker
+	for {
+		select {
+		case now := <-ticker.C:
+			loads := tracker.update(now, d.readMetrics())
+			if loads == nil {
+				continue
+			}
+			if err := d.autoscaleGroups(loads); err != nil {
 				return err
 			}
+		case <-ctx.Done():
+			return ctx.Err()
 		}
 	}
+}
+
+// autoscaleGroups scales every autoscaled co-location group to the number of
+// replicas its load requires, given the load of every component.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) autoscaleGroups(loads map[string]float64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
+	if d.stopping {
+		return nil
+	}
+	for _, g := range d.groups {
+		if !g.started || g.bounds.MinReplicas == g.bounds.MaxReplicas {
+			continue
+		}
+		var load float64
+		for component := range g.components {
+			load += loads[component]
+		}
+		n := len(g.replicas)
+		want := desiredReplicas(n, load, d.multi.TargetLoad, g.bounds)
+		if want == n {
+			continue
+		}
+		d.logger.Info("Autoscaling group", "group", g.name, "load", load, "from", n, "to", want)
+		for ; n < want; n++ {
+			h, err := d.startReplica(g)
+			if err != nil {
+				return err
+			}
+			d.running.Go(func() error { return d.supervise(h) })
+		}
+		for ; n > want; n-- {
+			d.stopReplica(g.replicas[len(g.replicas)-1])
+		}
+	}
+	return nil
+}
+
+// rebalance periodically rebalances the assignments of routed components
+// using the load reported by the weavelets hosting them.
+func (d *deployer) rebalance(ctx context.Context) error {
+	ticker := time.NewTicker(rebalanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := d.rebalanceGroups(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// rebalanceGroups rebalances the assignments of all routed components.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) rebalanceGroups() error {
+	// Snapshot the replicas of the groups with routed components. Loads are
+	// fetched without holding d.mu, so that a slow weavelet doesn't block the
+	// rest of the deployment.
+	d.mu.Lock()
+	replicas := map[*group][]*envelope.Envelope{}
+	for _, g := range d.groups {
+		if len(g.assignments) == 0 {
+			continue
+		}
+		replicas[g] = nil
+		for _, replica := range g.replicas {
+			replicas[g] = append(replicas[g], replica.envelope)
+		}
+	}
+	d.mu.Unlock()
+
+	// Collect the load of every weavelet.
+	reports := map[*group][]*protos.LoadReport{}
+	for g, envelopes := range replicas {
+		for _, e := range envelopes {
+			report, err := e.GetLoad()
+			if err != nil {
+				d.logger.Error("Cannot get load", "err", err, "group", g.name)
+				continue
+			}
+			reports[g] = append(reports[g], report)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for g := range replicas {
+		addresses := maps.Keys(g.addresses)
+		for component, assignment := range g.assignments {
+			var loads []*protos.LoadReport_ComponentLoad
+			for _, report := range reports[g] {
+				if load, ok := report.Loads[component]; ok {
+					loads = append(loads, load)
+				}
+			}
+			balanced := routing.BalanceByLoad(assignment, addresses, loads, routing.BalanceOptions{})
+			if balanced == assignment {
+				continue
+			}
+			g.assignments[component] = balanced
+			d.logger.Debug(fmt.Sprintf("Rebalanced assignment for component %s:\n%s", component, routing.FormatAssignment(balanced)))
+
+			// Notify subscribers. Subscribers that cannot be updated are
+			// logged and skipped, rather than stopping the deployment.
+			d.notify(g, component)
+		}
+	}
 	return nil
 }
 
@@ -513,6 +852,19 @@ func (d *deployer) HandleTraceSpans(ctx context.Context, spans []trace.ReadOnlyS
 	return d.traceDB.Store(ctx, d.config.Name, d.deploymentId, spans)
 }
 
+// ExportListener implements the envelope.EnvelopeHandler interface.
+func (h *handler) ExportListener(ctx context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	reply, err := h.deployer.ExportListener(ctx, req)
+	if err == nil && reply.Error == "" {
+		// Remember the listener, so that we can remove the weavelet from
+		// the proxy when the weavelet exits.
+		h.exported[req.Listener] = req.Address
+	}
+	return reply, err
+}
+
 // GetListenerAddress implements the envelope.EnvelopeHandler interface.
 func (d *deployer) GetListenerAddress(context.Context, *protos.GetListenerAddressRequest) (*protos.GetListenerAddressReply, error) {
 	return &protos.GetListenerAddressReply{Address: "localhost:0"}, nil
@@ -520,38 +872,7 @@ func (d *deployer) GetListenerAddress(context.Context, *protos.GetListenerAddres
 
 // ExportListener implements the envelope.EnvelopeHandler interface.
 func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	// Update the proxy.
-	if p, ok := d.proxies[req.Listener]; ok {
-		p.proxy.AddBackend(req.Address)
-		return &protos.ExportListenerReply{ProxyAddress: p.addr}, nil
-	}
-
-	lis, err := net.Listen("tcp", req.LocalAddress)
-	if errors.Is(err, syscall.EADDRINUSE) {
-		// Don't retry if this address is already in use.
-		return &protos.ExportListenerReply{Error: err.Error()}, nil
-	}
-	if err != nil {
-		return nil, fmt.Errorf("proxy listen: %w", err)
-	}
-	addr := lis.Addr().String()
-	d.logger.Info("Proxy listening", "address", addr)
-	proxy := proxy.NewProxy(d.logger)
-	proxy.AddBackend(req.Address)
-	d.proxies[req.Listener] = &proxyInfo{
-		listener: req.Listener,
-		proxy:    proxy,
-		addr:     addr,
-	}
-	go func() {
-		if err := serveHTTP(d.ctx, lis, proxy); err != nil {
-			d.logger.Error("proxy", "err", err)
-		}
-	}()
-	return &protos.ExportListenerReply{ProxyAddress: addr}, nil
+	return d.proxies.export(d.deploymentId, req)
 }
 
 func (d *deployer) readMetrics() []*metrics.MetricSnapshot {
@@ -560,8 +881,8 @@ func (d *deployer) readMetrics() []*metrics.MetricSnapshot {
 
 	var ms []*metrics.MetricSnapshot
 	for _, group := range d.groups {
-		for _, envelope := range group.envelopes {
-			m, err := envelope.GetMetrics()
+		for _, replica := range group.replicas {
+			m, err := replica.envelope.GetMetrics()
 			if err != nil {
 				continue
 			}
@@ -578,7 +899,9 @@ func (d *deployer) Profile(_ context.Context, req *protos.GetProfileRequest) (*p
 	d.mu.Lock()
 	envelopes := map[string][]*envelope.Envelope{}
 	for _, group := range d.groups {
-		envelopes[group.name] = slices.Clone(group.envelopes)
+		for _, replica := range group.replicas {
+			envelopes[group.name] = append(envelopes[group.name], replica.envelope)
+		}
 	}
 	d.mu.Unlock()
 
@@ -636,20 +959,12 @@ func (d *deployer) Status(context.Context) (*status.Status, error) {
 		}
 	}
 
-	var listeners []*status.Listener
-	for _, proxy := range d.proxies {
-		listeners = append(listeners, &status.Listener{
-			Name: proxy.listener,
-			Addr: proxy.addr,
-		})
-	}
-
 	return &status.Status{
 		App:            d.config.Name,
 		DeploymentId:   d.deploymentId,
 		SubmissionTime: timestamppb.New(d.started),
 		Components:     components,
-		Listeners:      listeners,
+		Listeners:      d.proxies.listeners(),
 		Config:         d.config,
 	}, nil
 }
@@ -46,7 +46,7 @@ var (
 
 	purgeSpec = &tool.PurgeSpec{
 		Tool: "weaver multi",
-		Kill: "weaver multi (dashboard|deploy|logs|profile)",
+		Kill: "weaver multi (dashboard|deploy|logs|profile|rollout)",
 		Paths: []string{
 			logdir,
 			must.Must(defaultRegistryDir()),
@@ -67,6 +67,7 @@ var (
 		"metrics":   status.MetricsCommand("weaver multi", defaultRegistry),
 		"profile":   status.ProfileCommand("weaver multi", defaultRegistry),
 		"purge":     tool.PurgeCmd(purgeSpec),
+		"rollout":   &rolloutCmd,
 		"version":   tool.VersionCmd("weaver multi"),
 	}
 )
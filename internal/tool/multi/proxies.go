@@ -0,0 +1,194 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ServiceWeaver/weaver"
+	"github.com/ServiceWeaver/weaver/internal/proxy"
+	"github.com/ServiceWeaver/weaver/internal/status"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"golang.org/x/exp/slog"
+)
+
+// healthCheckInterval is how often proxies health check their backends.
+const healthCheckInterval = 5 * time.Second
+
+// proxies holds the proxies of the listeners exported by an application. The
+// proxies are shared by all the versions of the application deployed by a
+// single weaver multi deploy, and outlive them. Every version is a backend set
+// in every proxy, named after the version's deployment id.
+type proxies struct {
+	ctx    context.Context
+	logger *slog.Logger
+
+	mu       sync.Mutex                // guards the following
+	proxies  map[string]*proxyInfo     // proxies, by listener name
+	weights  map[string]float64        // weights of the versions, by deployment id
+	backends map[string]map[string]int // number of backends, by deployment id and listener
+}
+
+// A proxyInfo contains information about a proxy.
+type proxyInfo struct {
+	listener string       // listener associated with the proxy
+	proxy    *proxy.Proxy // the proxy
+	addr     string       // dialable address of the proxy
+}
+
+// newProxies returns a new set of proxies. The proxies are stopped when the
+// provided context is cancelled.
+func newProxies(ctx context.Context, logger *slog.Logger) *proxies {
+	return &proxies{
+		ctx:      ctx,
+		logger:   logger,
+		proxies:  map[string]*proxyInfo{},
+		weights:  map[string]float64{},
+		backends: map[string]map[string]int{},
+	}
+}
+
+// export exports a listener of the provided version, starting a proxy for the
+// listener if needed.
+func (p *proxies) export(version string, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Update the proxy.
+	if info, ok := p.proxies[req.Listener]; ok {
+		info.proxy.AddSetBackend(version, req.Address)
+		p.addBackend(version, req.Listener)
+		return &protos.ExportListenerReply{ProxyAddress: info.addr}, nil
+	}
+
+	lis, err := net.Listen("tcp", req.LocalAddress)
+	if errors.Is(err, syscall.EADDRINUSE) {
+		// Don't retry if this address is already in use.
+		return &protos.ExportListenerReply{Error: err.Error()}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("proxy listen: %w", err)
+	}
+	addr := lis.Addr().String()
+	p.logger.Info("Proxy listening", "address", addr)
+	proxy := proxy.NewProxy(p.logger, proxy.Options{})
+	for version, weight := range p.weights {
+		proxy.SetWeight(version, weight)
+	}
+	proxy.AddSetBackend(version, req.Address)
+	p.addBackend(version, req.Listener)
+	p.proxies[req.Listener] = &proxyInfo{
+		listener: req.Listener,
+		proxy:    proxy,
+		addr:     addr,
+	}
+	go proxy.CheckHealth(p.ctx, weaver.HealthzURL, healthCheckInterval)
+	go func() {
+		if err := serveHTTP(p.ctx, lis, proxy); err != nil {
+			p.logger.Error("proxy", "err", err)
+		}
+	}()
+	return &protos.ExportListenerReply{ProxyAddress: addr}, nil
+}
+
+// addBackend records a new backend of the provided version for the provided
+// listener.
+//
+// REQUIRES: p.mu is held.
+func (p *proxies) addBackend(version, listener string) {
+	if p.backends[version] == nil {
+		p.backends[version] = map[string]int{}
+	}
+	p.backends[version][listener]++
+}
+
+// removeBackend stops proxying traffic for the provided listener to the
+// provided address of the provided version.
+func (p *proxies) removeBackend(version, listener, addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if info, ok := p.proxies[listener]; ok {
+		info.proxy.RemoveBackend(version, addr)
+		p.backends[version][listener]--
+	}
+}
+
+// ready returns whether the new version has a backend for every listener
+// that the old version has a backend for.
+func (p *proxies) ready(old, new string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for listener, n := range p.backends[old] {
+		if n > 0 && p.backends[new][listener] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// setWeight sets the share of traffic sent to the provided version by every
+// proxy, including proxies started later.
+func (p *proxies) setWeight(version string, weight float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.weights[version] = weight
+	for _, info := range p.proxies {
+		info.proxy.SetWeight(version, weight)
+	}
+}
+
+// removeVersion stops proxying traffic to the provided version.
+func (p *proxies) removeVersion(version string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.weights, version)
+	delete(p.backends, version)
+	for _, info := range p.proxies {
+		info.proxy.RemoveSet(version)
+	}
+}
+
+// stats returns the number of requests proxied to the provided version, and
+// the number of those requests that failed, summed across all proxies.
+func (p *proxies) stats(version string) (requests, errors int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, info := range p.proxies {
+		r, e := info.proxy.Stats(version)
+		requests += r
+		errors += e
+	}
+	return requests, errors
+}
+
+// listeners returns the proxied listeners.
+func (p *proxies) listeners() []*status.Listener {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var listeners []*status.Listener
+	for _, info := range p.proxies {
+		listeners = append(listeners, &status.Listener{
+			Name: info.listener,
+			Addr: info.addr,
+		})
+	}
+	return listeners
+}
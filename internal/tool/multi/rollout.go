@@ -0,0 +1,600 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/files"
+	"github.com/ServiceWeaver/weaver/internal/status"
+	"github.com/ServiceWeaver/weaver/runtime"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/protomsg"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/ServiceWeaver/weaver/runtime/retry"
+	"github.com/ServiceWeaver/weaver/runtime/tool"
+	"github.com/google/uuid"
+)
+
+const (
+	// rolloutURL is the URL path of the status server handler that rolls
+	// out a new version of an application.
+	rolloutURL = "/multi/rollout"
+
+	// defaultRollout is the duration of a rollout if the config of the new
+	// version doesn't specify one.
+	defaultRollout = time.Minute
+
+	// rolloutSteps is the number of steps in which traffic is shifted from
+	// the old version to the new version.
+	rolloutSteps = 10
+
+	// readyTimeout is how long a new version has to export all the
+	// listeners exported by the old version.
+	readyTimeout = time.Minute
+
+	// minRolloutCalls is the number of requests and method calls a new
+	// version has to serve during a rollout step for its error rate to be
+	// considered.
+	minRolloutCalls = 10
+)
+
+var (
+	errRolledBack = errors.New("version rolled back")
+	errReplaced   = errors.New("version replaced by a newer version")
+)
+
+var rolloutCmd = tool.Command{
+	Name:        "rollout",
+	Description: "Roll out a new version of a running Service Weaver app",
+	Help: `Usage:
+  weaver multi rollout <configfile>
+
+Flags:
+  -h, --help	Print this help message.
+
+Description:
+  "weaver multi rollout" replaces a running "weaver multi deploy" of an app
+  with a new version, without downtime. The new version is started alongside
+  the running version, and traffic on the app's listeners is gradually
+  shifted to it over the rollout duration in the config file (one minute by
+  default). If the new version crashes or its error rate regresses, the
+  rollout is rolled back. Interrupting "weaver multi rollout" also rolls back
+  the rollout.`,
+	Flags: flag.NewFlagSet("rollout", flag.ContinueOnError),
+	Fn:    rollout,
+}
+
+// rollout asks the running deployment of an app to roll out a new version.
+func rollout(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("want a single config file, got %d arguments", len(args))
+	}
+	config, err := loadConfig(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Find the running deployment of the app.
+	registry, err := defaultRegistry(ctx)
+	if err != nil {
+		return fmt.Errorf("create registry: %w", err)
+	}
+	regs, err := registry.List(ctx)
+	if err != nil {
+		return err
+	}
+	var running []status.Registration
+	for _, reg := range regs {
+		if reg.App == config.Name {
+			running = append(running, reg)
+		}
+	}
+	switch len(running) {
+	case 0:
+		return fmt.Errorf("no running deployment of app %q", config.Name)
+	case 1:
+	default:
+		return fmt.Errorf("app %q has %d running deployments; is a rollout already in progress?", config.Name, len(running))
+	}
+
+	token, err := readRolloutToken(running[0].DeploymentId)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Rolling out a new version of app %s...\n", config.Name)
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  &http.Client{Transport: tokenTransport{token}},
+		Addr:    "http://" + running[0].Addr,
+		URLPath: rolloutURL,
+		Request: config,
+	}); err != nil {
+		return fmt.Errorf("rollout: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Rolled out a new version of app %s\n", config.Name)
+	return nil
+}
+
+// A controller manages the versions of an application deployed by a single
+// weaver multi deploy. Usually, a single version is running. While a new
+// version is rolled out, the new version runs alongside the current version.
+// Every version is an independent deployer, so method calls never cross
+// versions, but the versions share the proxies of the exported listeners.
+type controller struct {
+	ctx      context.Context
+	app      string // application name
+	proxies  *proxies
+	registry *status.Registry
+	failed   chan error // receives the error that stopped the current version
+
+	// startFn and after are c.startVersion and time.After usually, but
+	// injected fakes in tests.
+	startFn func(deploymentId string, config *protos.AppConfig) (*version, error)
+	after   func(time.Duration) <-chan time.Time
+
+	mu         sync.Mutex
+	current    *version        // the version serving traffic
+	next       *version        // the version being rolled out, if any
+	rollingOut bool            // is a rollout in progress?
+	started    map[string]bool // ids of all the versions ever started
+}
+
+// A version is a deployed version of an application.
+type version struct {
+	d       *deployer
+	reg     status.Registration
+	retired bool // has the version been replaced or rolled back? guarded by controller.mu
+}
+
+// newController returns a controller that deploys the provided application.
+func newController(ctx context.Context, app string, registry *status.Registry, proxies *proxies) *controller {
+	c := &controller{
+		ctx:      ctx,
+		app:      app,
+		proxies:  proxies,
+		registry: registry,
+		failed:   make(chan error, 1),
+		after:    time.After,
+		started:  map[string]bool{},
+	}
+	c.startFn = c.startVersion
+	return c
+}
+
+// isStarted returns whether the controller started the version with the
+// provided deployment id.
+func (c *controller) isStarted(deploymentId string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.started[deploymentId]
+}
+
+// start starts the first version of the application.
+func (c *controller) start(config *protos.AppConfig) (*version, error) {
+	v, err := c.startFn(uuid.New().String(), config)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = v
+	return v, nil
+}
+
+// startVersion starts a new version of the application with the provided
+// deployment id, along with a status server for it, and registers it.
+func (c *controller) startVersion(deploymentId string, config *protos.AppConfig) (*version, error) {
+	c.mu.Lock()
+	c.started[deploymentId] = true
+	c.mu.Unlock()
+
+	// Create the deployer.
+	d, err := newDeployer(c.ctx, deploymentId, config, c.proxies)
+	if err != nil {
+		return nil, fmt.Errorf("create deployer: %w", err)
+	}
+	v := &version{d: d}
+	go func() {
+		err := d.wait()
+		c.exited(v, err)
+	}()
+
+	// Run a status server. The status server listens on localhost, where any
+	// local user can reach it, so rollouts must present a token that only
+	// the user running the deployer can read.
+	token, err := writeRolloutToken(deploymentId)
+	if err != nil {
+		d.stop(err)
+		return nil, fmt.Errorf("create rollout token: %w", err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		d.stop(err)
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	mux := http.NewServeMux()
+	status.RegisterServer(mux, d, d.logger)
+	mux.HandleFunc(rolloutURL, authorize(token, protomsg.HandlerDo(d.logger, c.rollout)))
+	go func() {
+		if err := serveHTTP(d.ctx, lis, mux); err != nil {
+			fmt.Fprintf(os.Stderr, "status server: %v\n", err)
+		}
+	}()
+
+	// Deploy main.
+	if err := d.startMain(); err != nil {
+		d.stop(err)
+		return nil, fmt.Errorf("start main process: %w", err)
+	}
+
+	// Wait for the status server to become active.
+	client := status.NewClient(lis.Addr().String())
+	for r := retry.Begin(); r.Continue(d.ctx); {
+		_, err := client.Status(d.ctx)
+		if err == nil {
+			break
+		}
+		fmt.Fprintf(os.Stderr, "status server %q unavailable: %#v\n", lis.Addr(), err)
+	}
+
+	// Register the deployment.
+	v.reg = status.Registration{
+		DeploymentId: deploymentId,
+		App:          config.Name,
+		Addr:         lis.Addr().String(),
+	}
+	fmt.Fprint(os.Stderr, v.reg.Rolodex())
+	if err := c.registry.Register(c.ctx, v.reg); err != nil {
+		d.stop(err)
+		return nil, fmt.Errorf("register deployment: %w", err)
+	}
+	return v, nil
+}
+
+// exited is called when the provided version stops with the provided error.
+func (c *controller) exited(v *version, err error) {
+	if err := c.registry.Unregister(c.ctx, v.d.deploymentId); err != nil {
+		fmt.Fprintf(os.Stderr, "unregister deployment: %v\n", err)
+	}
+	removeRolloutToken(v.d.deploymentId)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v == c.current && !v.retired {
+		c.failed <- err
+	}
+}
+
+// unregister unregisters all running versions.
+func (c *controller) unregister() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range []*version{c.current, c.next} {
+		if v == nil {
+			continue
+		}
+		if err := c.registry.Unregister(c.ctx, v.d.deploymentId); err != nil {
+			fmt.Fprintf(os.Stderr, "unregister deployment: %v\n", err)
+		}
+		removeRolloutToken(v.d.deploymentId)
+	}
+}
+
+// rollout rolls out a new version of the application with the provided
+// config. Traffic on the exported listeners is shifted from the current
+// version to the new version in rolloutSteps equal steps over the rollout
+// duration. The rollout is rolled back if the new version fails, if its
+// error rate regresses, or if the provided context is cancelled.
+func (c *controller) rollout(ctx context.Context, config *protos.AppConfig) error {
+	if config.Name != c.app {
+		return fmt.Errorf("cannot replace app %q with app %q", c.app, config.Name)
+	}
+	multi, err := parseMultiConfig(config)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	if c.rollingOut {
+		c.mu.Unlock()
+		return fmt.Errorf("a rollout is already in progress")
+	}
+	c.rollingOut = true
+	old := c.current
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.rollingOut = false
+		c.next = nil
+	}()
+
+	// Start the new version, without sending it any traffic.
+	logger := old.d.logger
+	oldId, nextId := old.d.deploymentId, uuid.New().String()
+	logger.Info("Rolling out new version", "old", oldId, "new", nextId)
+	c.proxies.setWeight(nextId, 0)
+	next, err := c.startFn(nextId, config)
+	if err != nil {
+		c.proxies.removeVersion(nextId)
+		return err
+	}
+	c.mu.Lock()
+	c.next = next
+	c.mu.Unlock()
+
+	// Wait for the new version to export all the listeners of the old one.
+	if err := c.waitReady(ctx, old, next); err != nil {
+		c.rollback(old, next, err)
+		return err
+	}
+
+	// Shift traffic to the new version.
+	duration := time.Duration(config.RolloutNanos)
+	if duration == 0 {
+		duration = defaultRollout
+	}
+	oldStats, nextStats := c.errorStats(old), c.errorStats(next)
+	for step := 1; step <= rolloutSteps; step++ {
+		fraction := float64(step) / rolloutSteps
+		c.proxies.setWeight(nextId, fraction)
+		c.proxies.setWeight(oldId, 1-fraction)
+		logger.Info("Shifted traffic to new version", "new", nextId, "fraction", fraction)
+
+		select {
+		case <-c.after(duration / rolloutSteps):
+		case <-next.d.ctx.Done():
+			err := fmt.Errorf("new version failed: %w", next.d.wait())
+			c.rollback(old, next, err)
+			return err
+		case <-ctx.Done():
+			err := fmt.Errorf("rollout cancelled: %w", ctx.Err())
+			c.rollback(old, next, err)
+			return err
+		}
+
+		// Compare the error rates of the two versions during the step.
+		o, n := c.errorStats(old), c.errorStats(next)
+		if regressed(o.sub(oldStats), n.sub(nextStats), multi.RollbackErrorRate) {
+			err := fmt.Errorf("error rate of new version regressed: %v, compared to %v for the old version", n.sub(nextStats), o.sub(oldStats))
+			c.rollback(old, next, err)
+			return err
+		}
+		oldStats, nextStats = o, n
+	}
+
+	// Retire the old version.
+	c.mu.Lock()
+	old.retired = true
+	c.current = next
+	c.mu.Unlock()
+	c.proxies.removeVersion(oldId)
+	logger.Info("Rolled out new version", "old", oldId, "new", nextId)
+	ctx, cancel := context.WithTimeout(c.ctx, drainTimeout)
+	defer cancel()
+	old.d.shutdown(ctx, errReplaced)
+	return nil
+}
+
+// waitReady waits until the new version exports all the listeners exported
+// by the old version.
+func (c *controller) waitReady(ctx context.Context, old, next *version) error {
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for !c.proxies.ready(old.d.deploymentId, next.d.deploymentId) {
+		select {
+		case <-ticker.C:
+		case <-next.d.ctx.Done():
+			return fmt.Errorf("new version failed: %w", next.d.wait())
+		case <-ctx.Done():
+			return fmt.Errorf("new version not ready: %w", ctx.Err())
+		}
+	}
+	return nil
+}
+
+// rollback stops rolling out the new version, which failed with the provided
+// error, and sends all traffic back to the old version.
+func (c *controller) rollback(old, next *version, err error) {
+	old.d.logger.Error("Rolling back new version", "err", err, "new", next.d.deploymentId)
+	c.mu.Lock()
+	next.retired = true
+	c.mu.Unlock()
+	c.proxies.setWeight(old.d.deploymentId, 1)
+	c.proxies.removeVersion(next.d.deploymentId)
+	if next.d.ctx.Err() != nil {
+		// The new version already stopped.
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, drainTimeout)
+	defer cancel()
+	next.d.shutdown(ctx, errRolledBack)
+}
+
+// errorStats holds the number of requests and method calls served by a
+// version, and the number of those that failed.
+type errorStats struct {
+	calls  int64
+	errors int64
+}
+
+// sub returns s - t.
+func (s errorStats) sub(t errorStats) errorStats {
+	return errorStats{calls: s.calls - t.calls, errors: s.errors - t.errors}
+}
+
+// rate returns the error rate.
+func (s errorStats) rate() float64 {
+	if s.calls <= 0 {
+		return 0
+	}
+	return float64(s.errors) / float64(s.calls)
+}
+
+// String implements the fmt.Stringer interface.
+func (s errorStats) String() string {
+	return fmt.Sprintf("%d errors in %d calls", s.errors, s.calls)
+}
+
+// errorStats returns the number of requests proxied to the provided version
+// and the number of remote method calls served by the version so far, along
+// with the number of those that failed.
+func (c *controller) errorStats(v *version) errorStats {
+	var s errorStats
+	s.calls, s.errors = c.proxies.stats(v.d.deploymentId)
+	for _, m := range v.d.readMetrics() {
+		if m.Labels["serviceweaver_node"] == "" {
+			// A metric of the deployer itself.
+			continue
+		}
+		switch m.Name {
+		case codegen.MethodCounts.Name():
+			s.calls += int64(m.Value)
+		case codegen.MethodErrors.Name():
+			s.errors += int64(m.Value)
+		}
+	}
+	return s
+}
+
+// regressed returns whether the error rate of a new version regressed,
+// compared to the error rate of an old version during the same period, by
+// more than the provided threshold. The new version's error rate is only
+// considered if it served at least minRolloutCalls calls.
+func regressed(old, new errorStats, threshold float64) bool {
+	if new.calls < minRolloutCalls {
+		return false
+	}
+	return new.rate() > old.rate()+threshold
+}
+
+// rolloutTokenFile returns the file that stores the token authorizing
+// rollouts to the deployment with the provided id.
+func rolloutTokenFile(deploymentId string) (string, error) {
+	dir, err := defaultRegistryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, deploymentId+".rollout_token"), nil
+}
+
+// writeRolloutToken generates a random token authorizing rollouts to the
+// deployment with the provided id, and writes it to a file that only the
+// current user can read.
+func writeRolloutToken(deploymentId string) (string, error) {
+	filename, err := rolloutTokenFile(deploymentId)
+	if err != nil {
+		return "", err
+	}
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b[:])
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return "", err
+	}
+	w := files.NewWriter(filename) // creates the file with mode 0600
+	defer w.Cleanup()
+	if _, err := w.Write([]byte(token)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// readRolloutToken reads the token written by writeRolloutToken.
+func readRolloutToken(deploymentId string) (string, error) {
+	filename, err := rolloutTokenFile(deploymentId)
+	if err != nil {
+		return "", err
+	}
+	token, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("read rollout token: %w", err)
+	}
+	return string(token), nil
+}
+
+// removeRolloutToken removes the token written by writeRolloutToken.
+func removeRolloutToken(deploymentId string) {
+	filename, err := rolloutTokenFile(deploymentId)
+	if err != nil {
+		return
+	}
+	if err := os.Remove(filename); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "remove rollout token: %v\n", err)
+	}
+}
+
+// authorize returns a handler that only forwards to the provided handler the
+// requests that present the provided token. See tokenTransport.
+func authorize(token string, handler http.HandlerFunc) http.HandlerFunc {
+	want := []byte("Bearer " + token)
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			http.Error(w, "invalid rollout token", http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// tokenTransport is an http.RoundTripper that authorizes requests with a
+// rollout token. See authorize.
+type tokenTransport struct {
+	token string
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t tokenTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// loadConfig loads the provided config file.
+func loadConfig(configFile string) (*protos.AppConfig, error) {
+	bytes, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("load config file %q: %w\n", configFile, err)
+	}
+	config, err := runtime.ParseConfig(configFile, string(bytes), codegen.ComponentConfigValidator)
+	if err != nil {
+		return nil, fmt.Errorf("load config file %q: %w\n", configFile, err)
+	}
+
+	// Sanity check the config.
+	if _, err := os.Stat(config.Binary); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("binary %q doesn't exist", config.Binary)
+	}
+	return config, nil
+}
@@ -0,0 +1,381 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/uuid"
+)
+
+func TestRegressed(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		old, new errorStats
+		want     bool
+	}{
+		{"no errors", errorStats{100, 0}, errorStats{100, 0}, false},
+		{"same rate", errorStats{100, 10}, errorStats{50, 5}, false},
+		{"within threshold", errorStats{100, 0}, errorStats{100, 5}, false},
+		{"above threshold", errorStats{100, 0}, errorStats{100, 6}, true},
+		{"old version idle", errorStats{0, 0}, errorStats{100, 50}, true},
+		{"too few calls", errorStats{100, 0}, errorStats{minRolloutCalls - 1, minRolloutCalls - 1}, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := regressed(test.old, test.new, 0.05); got != test.want {
+				t.Errorf("regressed(%v, %v): got %v, want %v", test.old, test.new, got, test.want)
+			}
+		})
+	}
+}
+
+func TestErrorStats(t *testing.T) {
+	s := errorStats{calls: 30, errors: 6}.sub(errorStats{calls: 10, errors: 2})
+	if want := (errorStats{calls: 20, errors: 4}); s != want {
+		t.Fatalf("sub: got %v, want %v", s, want)
+	}
+	if got, want := s.rate(), 0.2; got != want {
+		t.Errorf("rate: got %v, want %v", got, want)
+	}
+	if got := (errorStats{}).rate(); got != 0 {
+		t.Errorf("rate of no calls: got %v, want 0", got)
+	}
+}
+
+func TestRolloutToken(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	id := uuid.New().String()
+	token, err := writeRolloutToken(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the current user can read the token.
+	filename, err := rolloutTokenFile(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := info.Mode().Perm(), os.FileMode(0600); got != want {
+		t.Errorf("token file mode: got %v, want %v", got, want)
+	}
+
+	got, err := readRolloutToken(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != token {
+		t.Errorf("token: got %q, want %q", got, token)
+	}
+	removeRolloutToken(id)
+	if _, err := readRolloutToken(id); err == nil {
+		t.Error("token not removed")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	handler := authorize("secret", func(w http.ResponseWriter, _ *http.Request) {})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	for _, test := range []struct {
+		name   string
+		client *http.Client
+		want   int
+	}{
+		{"no token", http.DefaultClient, http.StatusUnauthorized},
+		{"wrong token", &http.Client{Transport: tokenTransport{"wrong"}}, http.StatusUnauthorized},
+		{"token", &http.Client{Transport: tokenTransport{"secret"}}, http.StatusOK},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := test.client.Post(server.URL, "", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != test.want {
+				t.Errorf("status: got %d, want %d", resp.StatusCode, test.want)
+			}
+		})
+	}
+}
+
+// rolloutTest drives controller.rollout against fake versions that run no
+// weavelets and export a single listener, served by an HTTP handler, through
+// real proxies.
+type rolloutTest struct {
+	t        *testing.T
+	c        *controller
+	proxies  *proxies
+	old      *version
+	handlers []http.HandlerFunc // handlers of the versions to start, in order
+	versions []*version         // started versions, in order
+}
+
+// newRolloutTest returns a rolloutTest whose current version serves requests
+// with the provided handler. The next version started serves requests with
+// next.
+func newRolloutTest(t *testing.T, next http.HandlerFunc) *rolloutTest {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	logger := logging.NewTestLogger(t)
+	ok := func(http.ResponseWriter, *http.Request) {}
+	r := &rolloutTest{
+		t:        t,
+		proxies:  newProxies(ctx, logger),
+		handlers: []http.HandlerFunc{ok, next},
+	}
+	r.c = newController(ctx, "app", nil, r.proxies)
+	r.c.startFn = func(deploymentId string, _ *protos.AppConfig) (*version, error) {
+		if len(r.versions) == len(r.handlers) {
+			return nil, fmt.Errorf("unexpected version %s", deploymentId)
+		}
+		server := httptest.NewServer(r.handlers[len(r.versions)])
+		t.Cleanup(server.Close)
+		ctx, cancel := context.WithCancel(ctx)
+		v := &version{d: &deployer{
+			ctx:          ctx,
+			ctxCancel:    cancel,
+			deploymentId: deploymentId,
+			logger:       logger,
+			groups:       map[string]*group{},
+		}}
+		r.versions = append(r.versions, v)
+		if _, err := r.proxies.export(deploymentId, &protos.ExportListenerRequest{
+			Listener:     "lis",
+			Address:      server.Listener.Addr().String(),
+			LocalAddress: "localhost:0",
+		}); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	old, err := r.c.start(&protos.AppConfig{Name: "app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.old = old
+	return r
+}
+
+// rollout rolls out the next version. Before waiting for every step to end,
+// the rollout calls step with the step number, starting at 1, and waits on
+// the returned channel.
+func (r *rolloutTest) rollout(ctx context.Context, step func(int) <-chan time.Time) error {
+	n := 0
+	r.c.after = func(time.Duration) <-chan time.Time {
+		n++
+		return step(n)
+	}
+	return r.c.rollout(ctx, &protos.AppConfig{Name: "app", RolloutNanos: int64(time.Second)})
+}
+
+// next returns the version being rolled out.
+func (r *rolloutTest) next() *version {
+	if len(r.versions) != 2 {
+		r.t.Fatalf("got %d versions, want 2", len(r.versions))
+	}
+	return r.versions[1]
+}
+
+// weights returns the weights of the old and next versions, and whether they
+// have weights at all.
+func (r *rolloutTest) weights() (old, next float64, oldOk, nextOk bool) {
+	r.proxies.mu.Lock()
+	defer r.proxies.mu.Unlock()
+	old, oldOk = r.proxies.weights[r.old.d.deploymentId]
+	next, nextOk = r.proxies.weights[r.next().d.deploymentId]
+	return old, next, oldOk, nextOk
+}
+
+// hasBackends returns whether the provided version has backends.
+func (r *rolloutTest) hasBackends(v *version) bool {
+	r.proxies.mu.Lock()
+	defer r.proxies.mu.Unlock()
+	_, ok := r.proxies.backends[v.d.deploymentId]
+	return ok
+}
+
+// now returns a channel that is ready immediately.
+func now() <-chan time.Time {
+	c := make(chan time.Time, 1)
+	c <- time.Now()
+	return c
+}
+
+func TestRollout(t *testing.T) {
+	r := newRolloutTest(t, func(http.ResponseWriter, *http.Request) {})
+	var got [][2]float64
+	err := r.rollout(context.Background(), func(int) <-chan time.Time {
+		old, next, _, _ := r.weights()
+		got = append(got, [2]float64{old, next})
+		return now()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Traffic is shifted in rolloutSteps equal steps.
+	if len(got) != rolloutSteps {
+		t.Fatalf("got %d steps, want %d", len(got), rolloutSteps)
+	}
+	for i, weights := range got {
+		fraction := float64(i+1) / rolloutSteps
+		if want := [2]float64{1 - fraction, fraction}; weights != want {
+			t.Errorf("step %d: got (old, next) weights %v, want %v", i+1, weights, want)
+		}
+	}
+
+	// The old version is retired.
+	next := r.next()
+	if r.c.current != next {
+		t.Error("next version is not the current version")
+	}
+	if !r.old.retired {
+		t.Error("old version not retired")
+	}
+	if r.old.d.ctx.Err() == nil {
+		t.Error("old version not stopped")
+	}
+	if next.d.ctx.Err() != nil {
+		t.Error("next version stopped")
+	}
+	_, weight, oldOk, _ := r.weights()
+	if oldOk {
+		t.Error("old version still has a weight")
+	}
+	if weight != 1 {
+		t.Errorf("next version weight: got %v, want 1", weight)
+	}
+	if r.hasBackends(r.old) {
+		t.Error("old version still has backends")
+	}
+}
+
+func TestRolloutRollback(t *testing.T) {
+	ok := func(http.ResponseWriter, *http.Request) {}
+	fail := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != weaver.HealthzURL {
+			// Pass health checks, but fail every request.
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+	for _, test := range []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string // substring of the expected error
+
+		// step is called before waiting for every step to end. cancel
+		// cancels the rollout.
+		step func(r *rolloutTest, step int, cancel func()) <-chan time.Time
+	}{
+		{
+			name:    "Failed",
+			handler: ok,
+			want:    "new version failed",
+			step: func(r *rolloutTest, step int, _ func()) <-chan time.Time {
+				if step == 3 {
+					r.next().d.stop(errors.New("crashed"))
+					return nil
+				}
+				return now()
+			},
+		},
+		{
+			name:    "Regressed",
+			handler: fail,
+			want:    "error rate of new version regressed",
+			step: func(r *rolloutTest, step int, _ func()) <-chan time.Time {
+				if step == 5 {
+					// Half of the requests are sent to the next version,
+					// and fail.
+					addr := r.proxies.listeners()[0].Addr
+					for i := 0; i < 100; i++ {
+						resp, err := http.Get("http://" + addr)
+						if err != nil {
+							r.t.Fatal(err)
+						}
+						resp.Body.Close()
+					}
+				}
+				return now()
+			},
+		},
+		{
+			name:    "Cancelled",
+			handler: ok,
+			want:    "rollout cancelled",
+			step: func(r *rolloutTest, step int, cancel func()) <-chan time.Time {
+				if step == 7 {
+					cancel()
+					return nil
+				}
+				return now()
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := newRolloutTest(t, test.handler)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			err := r.rollout(ctx, func(step int) <-chan time.Time {
+				return test.step(r, step, cancel)
+			})
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("rollout: got %v, want %q", err, test.want)
+			}
+
+			// The old version is serving all traffic, and the next version
+			// is removed.
+			next := r.next()
+			if r.c.current != r.old {
+				t.Error("old version is not the current version")
+			}
+			if r.old.d.ctx.Err() != nil {
+				t.Error("old version stopped")
+			}
+			if !next.retired {
+				t.Error("next version not retired")
+			}
+			if next.d.ctx.Err() == nil {
+				t.Error("next version not stopped")
+			}
+			weight, _, _, nextOk := r.weights()
+			if weight != 1 {
+				t.Errorf("old version weight: got %v, want 1", weight)
+			}
+			if nextOk {
+				t.Error("next version still has a weight")
+			}
+			if r.hasBackends(next) {
+				t.Error("next version still has backends")
+			}
+		})
+	}
+}
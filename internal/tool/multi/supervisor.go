@@ -0,0 +1,72 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/retry"
+)
+
+// restartBackoff is the exponential back-off applied between consecutive
+// restarts of a weavelet.
+var restartBackoff = retry.Options{
+	BackoffMultiplier:  2,
+	BackoffMinDuration: 100 * time.Millisecond,
+}
+
+// A restarter decides whether the weavelets of a co-location group are
+// restarted when they exit, based on a restart policy and a restart budget:
+// at most config.MaxRestarts restarts within any config.RestartWindow.
+type restarter struct {
+	config   *multiConfig
+	now      func() time.Time // time.Now usually, but injected fake in tests
+	restarts []time.Time      // times of recent restarts, oldest first
+}
+
+// newRestarter returns a new restarter for the provided config.
+func newRestarter(config *multiConfig) *restarter {
+	return &restarter{config: config, now: time.Now}
+}
+
+// restart decides whether to restart a weavelet that exited with the provided
+// exit code. It returns nil and records the restart if the weavelet should be
+// restarted, or an error explaining why it shouldn't be otherwise.
+func (r *restarter) restart(exitCode int) error {
+	switch r.config.RestartPolicy {
+	case restartNever:
+		return fmt.Errorf("weavelet exited with code %d and restart policy is %q", exitCode, restartNever)
+	case restartOnFailure:
+		if exitCode == 0 {
+			return fmt.Errorf("weavelet exited successfully and restart policy is %q", restartOnFailure)
+		}
+	}
+
+	// Forget the restarts that fell out of the window.
+	now := r.now()
+	cutoff := now.Add(-r.config.RestartWindow)
+	i := 0
+	for i < len(r.restarts) && !r.restarts[i].After(cutoff) {
+		i++
+	}
+	r.restarts = r.restarts[i:]
+
+	if len(r.restarts) >= r.config.MaxRestarts {
+		return fmt.Errorf("weavelet exited with code %d after %d restarts in the last %v", exitCode, len(r.restarts), r.config.RestartWindow)
+	}
+	r.restarts = append(r.restarts, now)
+	return nil
+}
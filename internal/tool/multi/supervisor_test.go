@@ -0,0 +1,149 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRestartPolicies(t *testing.T) {
+	for _, test := range []struct {
+		policy   string
+		exitCode int
+		want     bool
+	}{
+		{restartAlways, 0, true},
+		{restartAlways, 1, true},
+		{restartOnFailure, 0, false},
+		{restartOnFailure, 1, true},
+		{restartOnFailure, -1, true}, // killed by a signal
+		{restartNever, 0, false},
+		{restartNever, 1, false},
+	} {
+		config := &multiConfig{RestartPolicy: test.policy}
+		if err := config.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		err := newRestarter(config).restart(test.exitCode)
+		if got := err == nil; got != test.want {
+			t.Errorf("%s, exit code %d: got %v (%v), want %v", test.policy, test.exitCode, got, err, test.want)
+		}
+	}
+}
+
+func TestRestartBudget(t *testing.T) {
+	config := &multiConfig{MaxRestarts: 2, RestartWindow: time.Minute}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	var now time.Time
+	r := newRestarter(config)
+	r.now = func() time.Time { return now }
+
+	restart := func(want bool) {
+		t.Helper()
+		err := r.restart(1)
+		if got := err == nil; got != want {
+			t.Fatalf("%v: got %v (%v), want %v", now, got, err, want)
+		}
+	}
+	restart(true)
+	now = now.Add(30 * time.Second)
+	restart(true)
+	restart(false) // budget exhausted
+	now = now.Add(31 * time.Second)
+	restart(true) // the first restart fell out of the window
+	restart(false)
+}
+
+func TestMultiConfigDefaults(t *testing.T) {
+	config := &multiConfig{}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	want := &multiConfig{
+		RestartPolicy:     restartOnFailure,
+		MaxRestarts:       5,
+		RestartWindow:     time.Minute,
+		MinReplicas:       2,
+		MaxReplicas:       2,
+		TargetLoad:        10,
+		RollbackErrorRate: 0.05,
+	}
+	if diff := cmp.Diff(want, config); diff != "" {
+		t.Fatalf("(-want +got):\n%s", diff)
+	}
+	if err := (&multiConfig{RestartPolicy: "sometimes"}).Validate(); err == nil {
+		t.Fatal("unexpected success for unknown restart policy")
+	}
+	if err := (&multiConfig{MinReplicas: 3, MaxReplicas: 2}).Validate(); err == nil {
+		t.Fatal("unexpected success for max_replicas < min_replicas")
+	}
+}
+
+func TestParseMultiConfig(t *testing.T) {
+	app := &protos.AppConfig{Sections: map[string]string{
+		"multi": `
+restart_policy = "always"
+restart_window = "10s"
+max_replicas = 4
+
+[groups.Cache]
+max_replicas = 8
+
+[groups.Store]
+min_replicas = 5
+`,
+	}}
+	got, err := parseMultiConfig(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &multiConfig{
+		RestartPolicy:     restartAlways,
+		MaxRestarts:       5,
+		RestartWindow:     10 * time.Second,
+		MinReplicas:       2,
+		MaxReplicas:       4,
+		TargetLoad:        10,
+		RollbackErrorRate: 0.05,
+		Groups: map[string]*replicaConfig{
+			"Cache": {MinReplicas: 2, MaxReplicas: 8},
+			"Store": {MinReplicas: 5, MaxReplicas: 5},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("(-want +got):\n%s", diff)
+	}
+
+	// Overrides apply to the whole group of the component they name.
+	colocation := map[string]string{"Cache": "Cache+Frontend", "Frontend": "Cache+Frontend"}
+	for _, test := range []struct {
+		group string
+		want  replicaConfig
+	}{
+		{"Cache+Frontend", replicaConfig{2, 8}},
+		{"Store", replicaConfig{5, 5}},
+		{"Other", replicaConfig{2, 4}},
+	} {
+		if got := got.replicas(test.group, colocation); got != test.want {
+			t.Errorf("replicas(%q): got %v, want %v", test.group, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/ServiceWeaver/weaver/internal/tool/ssh/impl"
+	"github.com/ServiceWeaver/weaver/runtime/colors"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/tool"
+	"github.com/google/uuid"
+)
+
+var (
+	agentFlags    = flag.NewFlagSet("agent", flag.ContinueOnError)
+	agentAddress  = agentFlags.String("address", ":"+impl.DefaultAgentPort, "Address to listen on")
+	agentName     = agentFlags.String("name", "", "Name of the agent's certificate (defaults to the hostname)")
+	agentCertsDir = agentFlags.String("certs_dir", "", "Directory with the certificates generated by 'weaver ssh certs'")
+	agentDir      = agentFlags.String("dir", filepath.Join(os.TempDir(), "weaver_ssh_agent"), "Directory where deployment binaries are stored")
+
+	certsFlags = flag.NewFlagSet("certs", flag.ContinueOnError)
+	certsDir   = certsFlags.String("dir", "certs", "Directory to write the certificates to")
+)
+
+var agentCmd = tool.Command{
+	Name:        "agent",
+	Description: "Run a weaver ssh agent",
+	Help: `Usage:
+  weaver ssh agent --certs_dir=<dir> [options]
+
+Flags:
+  -h, --help	Print this help message.
+` + tool.FlagsHelp(agentFlags) + `
+
+Description:
+  "weaver ssh agent" runs a long-lived daemon that deploys and stops Service
+  Weaver applications on the local machine on behalf of "weaver ssh deploy".
+  The agent and the deployer authenticate each other using mTLS, with the
+  certificates generated by "weaver ssh certs". When the agent is stopped, it
+  stops all the deployments it is running.`,
+	Flags: agentFlags,
+	Fn:    runAgent,
+}
+
+var certsCmd = tool.Command{
+	Name:        "certs",
+	Description: "Generate certificates for weaver ssh agents",
+	Help: `Usage:
+  weaver ssh certs [--dir=<dir>] <host>...
+
+Flags:
+  -h, --help	Print this help message.
+` + tool.FlagsHelp(certsFlags) + `
+
+Description:
+  "weaver ssh certs" generates a CA certificate, a certificate for the
+  deployer, and a certificate for every provided host, all signed by the CA.
+  Every host must be named exactly as it appears in the locations file. Copy
+  the directory to every host, and pass it to "weaver ssh agent".`,
+	Flags: certsFlags,
+	Fn: func(_ context.Context, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("no hosts provided")
+		}
+		if err := impl.GenerateCerts(*certsDir, args); err != nil {
+			return err
+		}
+		fmt.Printf("Certificates written to %s\n", *certsDir)
+		return nil
+	},
+}
+
+var agentsCmd = tool.Command{
+	Name:        "agents",
+	Description: "Show the deployments running on weaver ssh agents",
+	Help:        "Usage:\n  weaver ssh agents <configfile>",
+	Flags:       flag.NewFlagSet("agents", flag.ContinueOnError),
+	Fn:          agents,
+}
+
+// runAgent runs an agent until it receives SIGINT or SIGTERM.
+func runAgent(ctx context.Context, _ []string) error {
+	if *agentCertsDir == "" {
+		return fmt.Errorf("--certs_dir not provided")
+	}
+	name := *agentName
+	if name == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+		name = host
+	}
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	logger := logging.StderrLogger(logging.Options{
+		App:       "weaver ssh",
+		Component: "agent",
+		Weavelet:  uuid.NewString(),
+		Attrs:     []string{"serviceweaver/system", ""},
+	})
+	opts := impl.AgentOptions{
+		Address:  *agentAddress,
+		Name:     name,
+		CertsDir: *agentCertsDir,
+		Dir:      *agentDir,
+	}
+	if err := impl.RunAgent(ctx, opts, logger); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// agents prints the deployments running on the agents at the locations listed
+// in the provided config file.
+func agents(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("want a single config file, got %d arguments", len(args))
+	}
+	_, config, err := loadConfig(args[0])
+	if err != nil {
+		return err
+	}
+	if config.AgentCertsDir == "" {
+		return fmt.Errorf("agent_certs_dir not set in the ssh section of %q", args[0])
+	}
+	locs, err := getLocations(config)
+	if err != nil {
+		return err
+	}
+	certsDir, err := getAbsoluteFilePath(config.AgentCertsDir)
+	if err != nil {
+		return err
+	}
+	client, err := impl.NewAgentClient(certsDir)
+	if err != nil {
+		return err
+	}
+
+	title := []colors.Text{{{S: "AGENTS", Bold: true}}}
+	t := colors.NewTabularizer(os.Stdout, title, colors.PrefixDim)
+	defer t.Flush()
+	t.Row("LOCATION", "APP", "DEPLOYMENT", "GROUPS", "PIDS")
+	for _, loc := range locs {
+		status, err := client.Status(ctx, loc)
+		if err != nil {
+			t.Row(loc, "", "", "", fmt.Sprintf("error: %v", err))
+			continue
+		}
+		for _, dep := range status.Deployments {
+			groups := make([]string, len(dep.Groups))
+			for i, group := range dep.Groups {
+				groups[i] = logging.ShortenComponent(group)
+			}
+			pids := make([]string, len(dep.Pids))
+			for i, pid := range dep.Pids {
+				pids[i] = fmt.Sprint(pid)
+			}
+			t.Row(loc, dep.App, dep.DeploymentId, strings.Join(groups, ", "), strings.Join(pids, ", "))
+		}
+	}
+	return nil
+}
@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"os/signal"
 	"os/user"
 	"path/filepath"
@@ -49,6 +48,9 @@ var deployCmd = tool.Command{
 
 // deploy deploys an application on a cluster of machines using an SSH deployer.
 // Note that each component is deployed as a separate OS process.
+//
+// TODO: Support rolling out a new version of a running application without
+// downtime, like "weaver multi rollout" does.
 func deploy(ctx context.Context, args []string) error {
 	// Validate command line arguments.
 	if len(args) == 0 {
@@ -59,14 +61,9 @@ func deploy(ctx context.Context, args []string) error {
 	}
 
 	// Load the config file.
-	cfgFile := args[0]
-	cfg, err := os.ReadFile(cfgFile)
-	if err != nil {
-		return fmt.Errorf("load config file %q: %w", cfgFile, err)
-	}
-	app, err := runtime.ParseConfig(cfgFile, string(cfg), codegen.ComponentConfigValidator)
+	app, config, err := loadConfig(args[0])
 	if err != nil {
-		return fmt.Errorf("load config file %q: %w", cfgFile, err)
+		return err
 	}
 
 	// Sanity check the config.
@@ -75,11 +72,25 @@ func deploy(ctx context.Context, args []string) error {
 	}
 
 	// Retrieve the list of locations to deploy.
-	locs, err := getLocations(app)
+	locs, err := getLocations(config)
 	if err != nil {
 		return err
 	}
 
+	// Deploy using the agents running at the locations, if configured, or
+	// using ssh otherwise.
+	launcher := impl.NewSSHLauncher()
+	if config.AgentCertsDir != "" {
+		certsDir, err := getAbsoluteFilePath(config.AgentCertsDir)
+		if err != nil {
+			return err
+		}
+		launcher, err = impl.NewAgentClient(certsDir)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create a deployment.
 	dep := &protos.Deployment{
 		Id:  uuid.New().String(),
@@ -87,12 +98,14 @@ func deploy(ctx context.Context, args []string) error {
 	}
 
 	// Copy the binaries to each location.
-	if err := copyBinaries(locs, dep); err != nil {
-		return err
+	for _, loc := range locs {
+		if err := launcher.CopyBinaries(ctx, loc, dep); err != nil {
+			return err
+		}
 	}
 
 	// Run the manager.
-	stopFn, err := impl.RunManager(ctx, dep, locs, logDir)
+	stopFn, err := impl.RunManager(ctx, dep, locs, launcher, logDir)
 	if err != nil {
 		return fmt.Errorf("cannot instantiate the manager: %w", err)
 	}
@@ -102,7 +115,7 @@ func deploy(ctx context.Context, args []string) error {
 	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-done // Will block here until user hits ctrl+c
-		if err := terminateDeployment(locs, dep); err != nil {
+		if err := terminateDeployment(ctx, launcher, locs, dep); err != nil {
 			fmt.Fprintf(os.Stderr, "failed to terminate deployment: %v\n", err)
 		}
 		fmt.Fprintf(os.Stderr, "Application %s terminated\n", app.Name)
@@ -131,62 +144,53 @@ func deploy(ctx context.Context, args []string) error {
 	}
 }
 
-// copyBinaries copies the tool and the application binary to the given set
-// of locations.
-func copyBinaries(locs []string, dep *protos.Deployment) error {
-	ex, err := os.Executable()
-	if err != nil {
-		return err
-	}
-
-	binary := dep.App.Binary
-	remoteDepDir := filepath.Join(os.TempDir(), dep.Id)
-	dep.App.Binary = filepath.Join(remoteDepDir, filepath.Base(dep.App.Binary))
-	for _, loc := range locs {
-		// Make an app deployment directory at each location.
-		cmd := exec.Command("ssh", loc, "mkdir", "-p", remoteDepDir)
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("unable to create deployment directory at location %s: %w\n", loc, err)
-		}
-
-		cmd = exec.Command("scp", ex, binary, loc+":"+remoteDepDir)
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("unable to copy app binary at location %s: %w\n", loc, err)
-		}
-	}
-	return nil
-}
-
 // terminateDeployment terminates all the processes corresponding to the deployment
 // at all locations.
-//
-// TODO(rgrandl): Find a different way to kill the deployment if the pkill command
-// is not installed.
-func terminateDeployment(locs []string, dep *protos.Deployment) error {
+func terminateDeployment(ctx context.Context, launcher impl.Launcher, locs []string, dep *protos.Deployment) error {
+	var errs []error
 	for _, loc := range locs {
-		cmd := exec.Command("ssh", loc, "pkill", "-f", dep.Id)
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("unable to terminate deployment at location %s: %w", loc, err)
-		}
+		errs = append(errs, launcher.StopDeployment(ctx, loc, dep))
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
-// getLocations returns the list of locations at which to deploy the application.
-func getLocations(app *protos.AppConfig) ([]string, error) {
+// sshConfig holds the ssh section of a config file.
+type sshConfig struct {
+	// LocationsFile is a file that lists the locations to deploy to, one
+	// per line.
+	LocationsFile string `toml:"locations_file"`
+
+	// AgentCertsDir, if set, is the directory with the certificates
+	// generated by "weaver ssh certs". Applications are then deployed by the
+	// "weaver ssh agent" daemons running at the locations, rather than by
+	// shelling out to ssh.
+	AgentCertsDir string `toml:"agent_certs_dir"`
+}
+
+// loadConfig loads the provided config file, including its ssh section.
+func loadConfig(cfgFile string) (*protos.AppConfig, *sshConfig, error) {
 	// SSH config as found in TOML config file.
 	const sshKey = "github.com/ServiceWeaver/weaver/ssh"
 	const shortSSHKey = "ssh"
 
-	type sshConfigSchema struct {
-		LocationsFile string `toml:"locations_file"`
+	cfg, err := os.ReadFile(cfgFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load config file %q: %w", cfgFile, err)
 	}
-	parsed := &sshConfigSchema{}
-	if err := runtime.ParseConfigSection(sshKey, shortSSHKey, app.Sections, parsed); err != nil {
-		return nil, fmt.Errorf("unable to parse ssh config: %w", err)
+	app, err := runtime.ParseConfig(cfgFile, string(cfg), codegen.ComponentConfigValidator)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load config file %q: %w", cfgFile, err)
+	}
+	config := &sshConfig{}
+	if err := runtime.ParseConfigSection(sshKey, shortSSHKey, app.Sections, config); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse ssh config: %w", err)
 	}
+	return app, config, nil
+}
 
-	file, err := getAbsoluteFilePath(parsed.LocationsFile)
+// getLocations returns the list of locations at which to deploy the application.
+func getLocations(config *sshConfig) ([]string, error) {
+	file, err := getAbsoluteFilePath(config.LocationsFile)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,587 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/proto"
+	"github.com/ServiceWeaver/weaver/internal/tool/certs"
+	"github.com/ServiceWeaver/weaver/runtime/protomsg"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	// URL suffixes for various agent handlers.
+	copyBinaryURL      = "/agent/copy_binary"
+	startBabysitterURL = "/agent/start_babysitter"
+	stopDeploymentURL  = "/agent/stop_deployment"
+	getAgentStatusURL  = "/agent/status"
+
+	// DefaultAgentPort is the port agents listen on by default.
+	DefaultAgentPort = "9050"
+
+	// DeployerCertName is the name of the certificate the deployer presents
+	// to agents. Agents reject clients that present any other certificate,
+	// including the certificates of other agents.
+	DeployerCertName = "deployer"
+
+	// File names of the certificates and keys in a certificates directory.
+	caCertFile = "ca.pem"
+
+	// stopTimeout is how long a babysitter has to gracefully shut down its
+	// weavelet after it is asked to stop, before it is killed. It is longer
+	// than drainTimeout, the time the weavelet has to shut down.
+	stopTimeout = drainTimeout + 5*time.Second
+)
+
+// CertFiles returns the certificate and key files with the provided name in
+// the provided certificates directory.
+func CertFiles(dir, name string) (cert, key string) {
+	return filepath.Join(dir, name+".pem"), filepath.Join(dir, name+".key")
+}
+
+// GenerateCerts generates a CA certificate, a certificate for the deployer,
+// and a certificate for every provided host, all signed by the CA, and writes
+// them to the provided directory. The CA key is not written, so the
+// certificates have to be regenerated to add hosts.
+func GenerateCerts(dir string, hosts []string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	caCert, caKey, err := certs.GenerateCACert()
+	if err != nil {
+		return fmt.Errorf("generate CA certificate: %w", err)
+	}
+	caPEM, _, err := certs.PEMEncode(caCert, caKey)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, caCertFile), caPEM, 0644); err != nil {
+		return err
+	}
+	for _, name := range append([]string{DeployerCertName}, hosts...) {
+		cert, key, err := certs.GenerateSignedCert(caCert, caKey, name)
+		if err != nil {
+			return fmt.Errorf("generate certificate for %q: %w", name, err)
+		}
+		certPEM, keyPEM, err := certs.PEMEncode(cert, key)
+		if err != nil {
+			return err
+		}
+		certFile, keyFile := CertFiles(dir, name)
+		if err := os.WriteFile(certFile, certPEM, 0644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCerts loads the CA certificate and the certificate with the provided
+// name from the provided certificates directory.
+func loadCerts(dir, name string) (*x509.Certificate, tls.Certificate, error) {
+	caPEM, err := os.ReadFile(filepath.Join(dir, caCertFile))
+	if err != nil {
+		return nil, tls.Certificate{}, fmt.Errorf("load CA certificate: %w", err)
+	}
+	block, _ := pem.Decode(caPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, tls.Certificate{}, fmt.Errorf("load CA certificate: no PEM-encoded certificate in %s", caCertFile)
+	}
+	ca, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, tls.Certificate{}, fmt.Errorf("load CA certificate: %w", err)
+	}
+	certFile, keyFile := CertFiles(dir, name)
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, tls.Certificate{}, fmt.Errorf("load certificate %q: %w", name, err)
+	}
+	return ca, cert, nil
+}
+
+// verifyPeer returns a function, suitable for tls.Config's
+// VerifyPeerCertificate, that checks that the peer presents a certificate
+// signed by the provided CA for the provided name.
+func verifyPeer(ca *x509.Certificate, name string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("no certificate")
+		}
+		names, err := certs.VerifySignedCert(rawCerts[0], ca)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("certificate for %v, want %q", names, name)
+		}
+		return nil
+	}
+}
+
+// agent is a long-lived daemon, running on a location, that copies the
+// binaries of deployments to the location and starts and stops their
+// babysitters on behalf of the deployer. The deployer and the agent
+// authenticate each other using mTLS.
+type agent struct {
+	ctx    context.Context
+	logger *slog.Logger
+	dir    string // directory where binaries are stored, one subdirectory per deployment
+
+	mu          sync.Mutex
+	deployments map[string]*agentDeployment // deployments, by id
+}
+
+// agentDeployment holds the state of a deployment on an agent.
+type agentDeployment struct {
+	app         string
+	babysitters map[*exec.Cmd]*agentBabysitter // running babysitters
+}
+
+// agentBabysitter is a babysitter started by an agent.
+type agentBabysitter struct {
+	cmd   *exec.Cmd
+	group string
+	done  chan struct{} // closed when the babysitter exits
+}
+
+// AgentOptions configure an agent.
+type AgentOptions struct {
+	Address  string // address to listen on
+	Name     string // name of the agent's certificate, typically the hostname
+	CertsDir string // directory with the certificates generated by GenerateCerts
+	Dir      string // directory where binaries are stored
+}
+
+// RunAgent runs an agent until the provided context is cancelled, at which
+// point all the deployments running on the agent are stopped.
+func RunAgent(ctx context.Context, opts AgentOptions, logger *slog.Logger) error {
+	lis, err := net.Listen("tcp", opts.Address)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	return serveAgent(ctx, lis, opts, logger)
+}
+
+// serveAgent runs an agent on the provided listener until the provided
+// context is cancelled. opts.Address is ignored.
+func serveAgent(ctx context.Context, lis net.Listener, opts AgentOptions, logger *slog.Logger) error {
+	defer lis.Close()
+	ca, cert, err := loadCerts(opts.CertsDir, opts.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(opts.Dir, 0700); err != nil {
+		return err
+	}
+	a := &agent{
+		ctx:         ctx,
+		logger:      logger,
+		dir:         opts.Dir,
+		deployments: map[string]*agentDeployment{},
+	}
+	a.logger.Info("Agent listening", "address", lis.Addr())
+	err = serveHTTP(ctx, tls.NewListener(lis, &tls.Config{
+		Certificates:          []tls.Certificate{cert},
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: verifyPeer(ca, DeployerCertName),
+	}), a.handler())
+	a.stopAll()
+	return err
+}
+
+// handler returns the HTTP handler of the agent.
+func (a *agent) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(copyBinaryURL, a.copyBinary)
+	mux.HandleFunc(startBabysitterURL, protomsg.HandlerDo(a.logger, a.startBabysitter))
+	mux.HandleFunc(stopDeploymentURL, protomsg.HandlerDo(a.logger, a.stopDeployment))
+	mux.HandleFunc(getAgentStatusURL, protomsg.HandlerThunk(a.logger, a.status))
+	return mux
+}
+
+// deploymentDir returns the directory where the binaries of the deployment
+// with the provided id are stored.
+func (a *agent) deploymentDir(id string) (string, error) {
+	// The id is used as a file name, so make sure it doesn't contain any
+	// path separators.
+	if _, err := uuid.Parse(id); err != nil {
+		return "", fmt.Errorf("invalid deployment id %q: %w", id, err)
+	}
+	return filepath.Join(a.dir, id), nil
+}
+
+// copyBinary handles requests to store a binary of a deployment. The
+// deployment id and the name of the binary are passed as query parameters,
+// and the contents of the binary are the body of the request. The body is
+// streamed to disk, so binaries are never held in memory.
+func (a *agent) copyBinary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	if err := a.storeBinary(q.Get("deployment"), q.Get("name"), r.Body); err != nil {
+		a.logger.Error("Cannot copy binary", "err", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+// storeBinary stores the binary with the provided name and contents in the
+// directory of the deployment with the provided id.
+func (a *agent) storeBinary(id, name string, contents io.Reader) error {
+	dir, err := a.deploymentDir(id)
+	if err != nil {
+		return err
+	}
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return fmt.Errorf("invalid binary name %q", name)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// Write the binary to a temporary file that is renamed once complete, so
+	// that a partially copied binary is never run.
+	f, err := os.CreateTemp(dir, "."+name+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // no-op once renamed
+	if _, err := io.Copy(f, contents); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0700); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, name))
+}
+
+func (a *agent) startBabysitter(_ context.Context, info *BabysitterInfo) error {
+	dep := info.Deployment
+	dir, err := a.deploymentDir(dep.Id)
+	if err != nil {
+		return err
+	}
+
+	// Run the copy of the app binary stored by copyBinary.
+	binary := filepath.Join(dir, filepath.Base(dep.App.Binary))
+	if _, err := os.Stat(binary); err != nil {
+		return fmt.Errorf("app binary not copied: %w", err)
+	}
+	dep.App.Binary = binary
+	input, err := proto.ToEnv(info)
+	if err != nil {
+		return err
+	}
+
+	// Run the babysitter using the agent's own binary. The babysitter is
+	// not tied to the lifetime of the request; it runs until the
+	// deployment is stopped.
+	ex, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(ex, "ssh", "babysitter")
+	cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", babysitterInfoKey, input))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.ctx.Err() != nil {
+		return a.ctx.Err()
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	d, ok := a.deployments[dep.Id]
+	if !ok {
+		d = &agentDeployment{app: dep.App.Name, babysitters: map[*exec.Cmd]*agentBabysitter{}}
+		a.deployments[dep.Id] = d
+	}
+	b := &agentBabysitter{cmd: cmd, group: info.Group, done: make(chan struct{})}
+	d.babysitters[cmd] = b
+	a.logger.Info("Started babysitter", "deployment", dep.Id, "group", info.Group, "pid", cmd.Process.Pid)
+
+	go func() {
+		err := cmd.Wait()
+		close(b.done)
+		a.logger.Info("Babysitter exited", "deployment", dep.Id, "group", info.Group, "err", err)
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		delete(d.babysitters, cmd)
+	}()
+	return nil
+}
+
+func (a *agent) stopDeployment(_ context.Context, req *StopDeploymentRequest) error {
+	dir, err := a.deploymentDir(req.DeploymentId)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	babysitters := a.stop(req.DeploymentId)
+	a.mu.Unlock()
+	a.wait(req.DeploymentId, babysitters, stopTimeout)
+	return os.RemoveAll(dir)
+}
+
+// stop asks the babysitters of the deployment with the provided id to stop,
+// and returns them. The caller should wait for them to exit using wait. The
+// weavelets started by a babysitter exit when the babysitter does.
+//
+// REQUIRES: a.mu is held.
+func (a *agent) stop(id string) []*agentBabysitter {
+	d, ok := a.deployments[id]
+	if !ok {
+		return nil
+	}
+	delete(a.deployments, id)
+	babysitters := maps.Values(d.babysitters)
+	for _, b := range babysitters {
+		// A babysitter gracefully shuts down its weavelet on SIGTERM.
+		if err := b.cmd.Process.Signal(syscall.SIGTERM); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			a.logger.Error("Cannot stop babysitter", "deployment", id, "pid", b.cmd.Process.Pid, "err", err)
+		}
+	}
+	return babysitters
+}
+
+// wait waits for the provided babysitters of the deployment with the provided
+// id to exit. Babysitters that haven't exited after the provided timeout are
+// killed.
+//
+// REQUIRES: a.mu is not held.
+func (a *agent) wait(id string, babysitters []*agentBabysitter, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for _, b := range babysitters {
+		select {
+		case <-b.done:
+			continue
+		case <-timer.C:
+		}
+		a.logger.Error("Babysitter did not stop in time; killing it", "deployment", id, "pid", b.cmd.Process.Pid)
+		if err := b.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			a.logger.Error("Cannot kill babysitter", "deployment", id, "pid", b.cmd.Process.Pid, "err", err)
+		}
+		<-b.done
+	}
+	a.logger.Info("Stopped deployment", "deployment", id)
+}
+
+// stopAll stops all deployments and deletes their binaries.
+func (a *agent) stopAll() {
+	a.mu.Lock()
+	stopped := map[string][]*agentBabysitter{}
+	for id := range a.deployments {
+		stopped[id] = a.stop(id)
+	}
+	a.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for id, babysitters := range stopped {
+		id, babysitters := id, babysitters
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.wait(id, babysitters, stopTimeout)
+			if err := os.RemoveAll(filepath.Join(a.dir, id)); err != nil {
+				a.logger.Error("Cannot delete deployment binaries", "deployment", id, "err", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (a *agent) status(context.Context) (*AgentStatus, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	status := &AgentStatus{}
+	for id, d := range a.deployments {
+		dep := &AgentDeployment{DeploymentId: id, App: d.app}
+		groups := map[string]bool{}
+		for cmd, b := range d.babysitters {
+			groups[b.group] = true
+			dep.Pids = append(dep.Pids, int64(cmd.Process.Pid))
+		}
+		dep.Groups = maps.Keys(groups)
+		sort.Strings(dep.Groups)
+		slices.Sort(dep.Pids)
+		status.Deployments = append(status.Deployments, dep)
+	}
+	sort.Slice(status.Deployments, func(i, j int) bool {
+		return status.Deployments[i].DeploymentId < status.Deployments[j].DeploymentId
+	})
+	return status, nil
+}
+
+// AgentClient is a client of the agents running at a set of locations. It
+// implements the Launcher interface.
+type AgentClient struct {
+	client *http.Client
+}
+
+var _ Launcher = &AgentClient{}
+
+// NewAgentClient returns a new client that authenticates to agents using
+// the deployer certificate in the provided certificates directory.
+func NewAgentClient(certsDir string) (*AgentClient, error) {
+	ca, cert, err := loadCerts(certsDir, DeployerCertName)
+	if err != nil {
+		return nil, err
+	}
+	return &AgentClient{
+		client: &http.Client{
+			Transport: &http.Transport{
+				// Locations may be IP addresses or hostnames, so rather
+				// than verifying the server name, VerifyPeerCertificate
+				// checks that the certificate was issued for the location
+				// being dialed.
+				DialTLSContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					host, _, err := net.SplitHostPort(addr)
+					if err != nil {
+						return nil, err
+					}
+					dialer := &tls.Dialer{Config: &tls.Config{
+						Certificates:          []tls.Certificate{cert},
+						InsecureSkipVerify:    true, // ok when VerifyPeerCertificate present
+						VerifyPeerCertificate: verifyPeer(ca, host),
+					}}
+					return dialer.DialContext(ctx, network, addr)
+				},
+			},
+		},
+	}, nil
+}
+
+// agentAddr returns the address of the agent at the provided location, which
+// is either a host or a host:port pair.
+func agentAddr(loc string) string {
+	if _, _, err := net.SplitHostPort(loc); err != nil {
+		loc = net.JoinHostPort(loc, DefaultAgentPort)
+	}
+	return "https://" + loc
+}
+
+// CopyBinaries implements the Launcher interface. Only the app binary is
+// copied; babysitters run the agent's own binary. The binary is streamed to
+// the agent as the body of the request.
+func (c *AgentClient) CopyBinaries(ctx context.Context, loc string, dep *protos.Deployment) error {
+	if err := c.copyBinary(ctx, loc, dep); err != nil {
+		return fmt.Errorf("unable to copy app binary at location %s: %w", loc, err)
+	}
+	return nil
+}
+
+func (c *AgentClient) copyBinary(ctx context.Context, loc string, dep *protos.Deployment) error {
+	f, err := os.Open(dep.App.Binary)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	q.Set("deployment", dep.Id)
+	q.Set("name", filepath.Base(dep.App.Binary))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, agentAddr(loc)+copyBinaryURL+"?"+q.Encode(), f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("HTTP status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// StartBabysitter implements the Launcher interface.
+func (c *AgentClient) StartBabysitter(ctx context.Context, loc string, info *BabysitterInfo) error {
+	return protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  c.client,
+		Addr:    agentAddr(loc),
+		URLPath: startBabysitterURL,
+		Request: info,
+	})
+}
+
+// StopDeployment implements the Launcher interface.
+func (c *AgentClient) StopDeployment(ctx context.Context, loc string, dep *protos.Deployment) error {
+	req := &StopDeploymentRequest{DeploymentId: dep.Id}
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  c.client,
+		Addr:    agentAddr(loc),
+		URLPath: stopDeploymentURL,
+		Request: req,
+	}); err != nil {
+		return fmt.Errorf("unable to terminate deployment at location %s: %w", loc, err)
+	}
+	return nil
+}
+
+// Status returns the deployments running on the agent at the provided
+// location.
+func (c *AgentClient) Status(ctx context.Context, loc string) (*AgentStatus, error) {
+	status := &AgentStatus{}
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  c.client,
+		Addr:    agentAddr(loc),
+		URLPath: getAgentStatusURL,
+		Reply:   status,
+	}); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/uuid"
+)
+
+// startAgent starts an agent on the loopback interface and returns its
+// location, along with a client authorized to talk to it.
+func startAgent(t *testing.T) (string, string, *AgentClient) {
+	t.Helper()
+	certsDir, dir := t.TempDir(), t.TempDir()
+	if err := GenerateCerts(certsDir, []string{"127.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		opts := AgentOptions{Name: "127.0.0.1", CertsDir: certsDir, Dir: dir}
+		done <- serveAgent(ctx, lis, opts, logging.NewTestLogger(t))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	client, err := NewAgentClient(certsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lis.Addr().String(), dir, client
+}
+
+func TestAgent(t *testing.T) {
+	// Generating certificates is slow, so the subtests share an agent.
+	loc, dir, client := startAgent(t)
+	t.Run("CopyAndStop", func(t *testing.T) {
+		testCopyAndStop(t, loc, dir, client)
+	})
+	t.Run("RejectsInvalidDeploymentIds", func(t *testing.T) {
+		testRejectsInvalidDeploymentIds(t, loc, client)
+	})
+	t.Run("RejectsUntrustedClients", func(t *testing.T) {
+		testRejectsUntrustedClients(t, loc)
+	})
+}
+
+func testCopyAndStop(t *testing.T, loc, dir string, client *AgentClient) {
+	ctx := context.Background()
+
+	binary := filepath.Join(t.TempDir(), "app")
+	if err := os.WriteFile(binary, []byte("binary"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	dep := &protos.Deployment{
+		Id:  uuid.New().String(),
+		App: &protos.AppConfig{Name: "app", Binary: binary},
+	}
+	if err := client.CopyBinaries(ctx, loc, dep); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, dep.Id, "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "binary" {
+		t.Fatalf("copied binary: got %q, want %q", got, "binary")
+	}
+
+	status, err := client.Status(ctx, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Deployments) != 0 {
+		t.Fatalf("got deployments %v, want none", status.Deployments)
+	}
+
+	if err := client.StopDeployment(ctx, loc, dep); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, dep.Id)); !os.IsNotExist(err) {
+		t.Fatalf("deployment directory not deleted: %v", err)
+	}
+}
+
+func testRejectsInvalidDeploymentIds(t *testing.T, loc string, client *AgentClient) {
+	binary := filepath.Join(t.TempDir(), "app")
+	if err := os.WriteFile(binary, []byte("binary"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	dep := &protos.Deployment{Id: "../../etc", App: &protos.AppConfig{Binary: binary}}
+	if err := client.CopyBinaries(context.Background(), loc, dep); err == nil {
+		t.Fatal("CopyBinaries: unexpected success")
+	}
+	if err := client.StopDeployment(context.Background(), loc, dep); err == nil {
+		t.Fatal("StopDeployment: unexpected success")
+	}
+}
+
+func testRejectsUntrustedClients(t *testing.T, loc string) {
+	// Generate a deployer certificate signed by a different CA.
+	certsDir := t.TempDir()
+	if err := GenerateCerts(certsDir, nil); err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewAgentClient(certsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Status(context.Background(), loc); err == nil {
+		t.Fatal("unexpected success")
+	}
+}
+
+func TestStopBabysitters(t *testing.T) {
+	// Start a babysitter that exits on SIGTERM and one that ignores it.
+	start := func(script string) *agentBabysitter {
+		cmd := exec.Command("sh", "-c", script)
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		b := &agentBabysitter{cmd: cmd, done: make(chan struct{})}
+		go func() {
+			cmd.Wait() //nolint:errcheck // killed on purpose
+			close(b.done)
+		}()
+		return b
+	}
+	graceful := start("sleep 60")
+	stubborn := start("trap '' TERM; while :; do sleep 0.1; done")
+
+	id := uuid.New().String()
+	a := &agent{
+		logger: logging.NewTestLogger(t),
+		deployments: map[string]*agentDeployment{id: {
+			babysitters: map[*exec.Cmd]*agentBabysitter{
+				graceful.cmd: graceful,
+				stubborn.cmd: stubborn,
+			},
+		}},
+	}
+	a.wait(id, a.stop(id), time.Second)
+
+	if got := graceful.cmd.ProcessState.Sys().(syscall.WaitStatus).Signal(); got != syscall.SIGTERM {
+		t.Errorf("graceful babysitter: got signal %v, want %v", got, syscall.SIGTERM)
+	}
+	if got := stubborn.cmd.ProcessState.Sys().(syscall.WaitStatus).Signal(); got != syscall.SIGKILL {
+		t.Errorf("stubborn babysitter: got signal %v, want %v", got, syscall.SIGKILL)
+	}
+}
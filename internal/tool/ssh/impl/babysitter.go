@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/proto"
@@ -36,6 +38,10 @@ import (
 	"golang.org/x/exp/slog"
 )
 
+// drainTimeout is how long a weavelet has to finish the method calls in
+// progress and shut down when its babysitter is stopped.
+const drainTimeout = 10 * time.Second
+
 // babysitter starts and manages weavelets belonging to a single colocation
 // group for a single application version, on the local machine.
 type babysitter struct {
@@ -121,6 +127,25 @@ func RunBabysitter(ctx context.Context) error {
 	}
 	c := metricsCollector{logger: b.logger, envelope: e, info: info}
 	go c.run(ctx)
+	l := loadCollector{logger: b.logger, envelope: e, info: info}
+	go l.run(ctx)
+
+	// The agent sends SIGTERM to stop the babysitter. Gracefully shut down
+	// the weavelet when that happens; Serve returns once it has stopped.
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	go func() {
+		select {
+		case <-sigs:
+			ctx, cancel := context.WithTimeout(ctx, drainTimeout)
+			defer cancel()
+			if err := e.Shutdown(ctx); err != nil {
+				b.logger.Error("Cannot gracefully stop weavelet", "err", err)
+			}
+		case <-ctx.Done():
+		}
+	}()
 	return e.Serve(b)
 }
 
@@ -185,6 +210,46 @@ func (m *metricsCollector) run(ctx context.Context) {
 	}
 }
 
+// loadCollector periodically collects the load of the routed components
+// hosted by a weavelet and reports it to the manager.
+type loadCollector struct {
+	logger   *slog.Logger
+	envelope *envelope.Envelope
+	info     *BabysitterInfo
+}
+
+func (l *loadCollector) run(ctx context.Context) {
+	ticker := time.NewTicker(rebalanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			load, err := l.envelope.GetLoad()
+			if err != nil {
+				l.logger.Error("Unable to collect load", "err", err)
+				continue
+			}
+			if len(load.Loads) == 0 {
+				continue
+			}
+			if err := protomsg.Call(ctx, protomsg.CallArgs{
+				Client:  http.DefaultClient,
+				Addr:    l.info.ManagerAddr,
+				URLPath: recvLoadURL,
+				Request: &BabysitterLoad{
+					GroupName: l.info.Group,
+					ReplicaId: l.info.ReplicaId,
+					Load:      load,
+				},
+			}); err != nil {
+				l.logger.Error("Error reporting load", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // ActivateComponent implements the protos.EnvelopeHandler interface.
 func (b *babysitter) ActivateComponent(_ context.Context, req *protos.ActivateComponentRequest) (*protos.ActivateComponentReply, error) {
 	if err := protomsg.Call(b.ctx, protomsg.CallArgs{
@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/ServiceWeaver/weaver/internal/proto"
+	"github.com/ServiceWeaver/weaver/runtime/protomsg"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+// A Launcher copies the binaries of a deployment to a set of locations,
+// starts babysitters at those locations, and stops them.
+type Launcher interface {
+	// CopyBinaries copies the binaries of the provided deployment to the
+	// provided location.
+	CopyBinaries(ctx context.Context, loc string, dep *protos.Deployment) error
+
+	// StartBabysitter starts a babysitter at the provided location.
+	StartBabysitter(ctx context.Context, loc string, info *BabysitterInfo) error
+
+	// StopDeployment stops all the babysitters of the provided deployment
+	// at the provided location.
+	StopDeployment(ctx context.Context, loc string, dep *protos.Deployment) error
+}
+
+// sshLauncher is a Launcher that shells out to ssh and scp.
+type sshLauncher struct{}
+
+var _ Launcher = sshLauncher{}
+
+// NewSSHLauncher returns a Launcher that runs commands at locations using
+// ssh, and copies binaries using scp.
+func NewSSHLauncher() Launcher {
+	return sshLauncher{}
+}
+
+// remoteDir returns the directory where the binaries of the provided
+// deployment are stored at a location.
+func remoteDir(dep *protos.Deployment) string {
+	return filepath.Join(os.TempDir(), dep.Id)
+}
+
+// CopyBinaries implements the Launcher interface.
+func (sshLauncher) CopyBinaries(ctx context.Context, loc string, dep *protos.Deployment) error {
+	ex, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// Make an app deployment directory at the location.
+	dir := remoteDir(dep)
+	cmd := exec.CommandContext(ctx, "ssh", loc, "mkdir", "-p", dir)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("unable to create deployment directory at location %s: %w", loc, err)
+	}
+
+	cmd = exec.CommandContext(ctx, "scp", ex, dep.App.Binary, loc+":"+dir)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("unable to copy app binary at location %s: %w", loc, err)
+	}
+	return nil
+}
+
+// StartBabysitter implements the Launcher interface.
+func (sshLauncher) StartBabysitter(_ context.Context, loc string, info *BabysitterInfo) error {
+	// Run the copy of the app binary at the location.
+	info = protomsg.Clone(info)
+	dir := remoteDir(info.Deployment)
+	info.Deployment.App.Binary = filepath.Join(dir, filepath.Base(info.Deployment.App.Binary))
+
+	input, err := proto.ToEnv(info)
+	if err != nil {
+		return err
+	}
+	env := fmt.Sprintf("%s=%s", babysitterInfoKey, input)
+	cmd := exec.Command("ssh", loc, env, filepath.Join(dir, "weaver"), "ssh", "babysitter")
+	return cmd.Start()
+}
+
+// StopDeployment implements the Launcher interface.
+//
+// TODO(rgrandl): Find a different way to kill the deployment if the pkill
+// command is not installed. Until then, use the agent launcher on such
+// locations.
+func (sshLauncher) StopDeployment(ctx context.Context, loc string, dep *protos.Deployment) error {
+	cmd := exec.CommandContext(ctx, "ssh", loc, "pkill", "-f", dep.Id)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("unable to terminate deployment at location %s: %w", loc, err)
+	}
+	return nil
+}
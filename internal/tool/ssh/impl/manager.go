@@ -21,12 +21,12 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/internal/files"
 	imetrics "github.com/ServiceWeaver/weaver/internal/metrics"
 	"github.com/ServiceWeaver/weaver/internal/routing"
@@ -39,7 +39,6 @@ import (
 	"golang.org/x/exp/slog"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/ServiceWeaver/weaver/internal/proto"
 	"github.com/ServiceWeaver/weaver/internal/proxy"
 	"github.com/ServiceWeaver/weaver/internal/status"
 	"github.com/ServiceWeaver/weaver/internal/traceio"
@@ -61,12 +60,20 @@ const (
 	recvLogEntryURL         = "/manager/recv_log_entry"
 	recvTraceSpansURL       = "/manager/recv_trace_spans"
 	recvMetricsURL          = "/manager/recv_metrics"
+	recvLoadURL             = "/manager/recv_load"
 
 	// babysitterInfoKey is the name of the env variable that contains deployment
 	// information for a babysitter deployed using SSH.
 	babysitterInfoKey = "SERVICEWEAVER_BABYSITTER_INFO"
+
+	// rebalanceInterval is the interval at which babysitters report load and
+	// the manager rebalances routed components.
+	rebalanceInterval = 30 * time.Second
 )
 
+// healthCheckInterval is how often proxies health check their backends.
+const healthCheckInterval = 5 * time.Second
+
 // manager manages an application version deployment across a set of locations,
 // where a location can be a physical or a virtual machine.
 //
@@ -79,6 +86,7 @@ type manager struct {
 	logger     *slog.Logger
 	logDir     string
 	locations  []string // addresses of the locations
+	launcher   Launcher // starts babysitters at the locations
 	mgrAddress string   // manager address
 	registry   *status.Registry
 	started    time.Time
@@ -111,6 +119,7 @@ type manager struct {
 	groups  map[string]*group                             // groups, by group name
 	proxies map[string]*proxyInfo                         // proxies, by listener name
 	metrics map[groupReplicaInfo][]*protos.MetricSnapshot // latest metrics, by group name and replica id
+	loads   map[groupReplicaInfo]*protos.LoadReport       // latest load, by group name and replica id
 }
 
 type group struct {
@@ -137,8 +146,9 @@ type groupReplicaInfo struct {
 
 var _ status.Server = &manager{}
 
-// RunManager creates and runs a new manager.
-func RunManager(ctx context.Context, dep *protos.Deployment, locations []string, logDir string) (func() error, error) {
+// RunManager creates and runs a new manager that starts babysitters at the
+// provided locations using the provided launcher.
+func RunManager(ctx context.Context, dep *protos.Deployment, locations []string, launcher Launcher, logDir string) (func() error, error) {
 	// Create log saver.
 	fs, err := logging.NewFileStore(logDir)
 	if err != nil {
@@ -182,6 +192,7 @@ func RunManager(ctx context.Context, dep *protos.Deployment, locations []string,
 		ctx:            ctx,
 		dep:            dep,
 		locations:      locations,
+		launcher:       launcher,
 		logger:         logger,
 		logDir:         logDir,
 		logSaver:       logSaver,
@@ -192,6 +203,7 @@ func RunManager(ctx context.Context, dep *protos.Deployment, locations []string,
 		groups:         map[string]*group{},
 		proxies:        map[string]*proxyInfo{},
 		metrics:        map[groupReplicaInfo][]*protos.MetricSnapshot{},
+		loads:          map[groupReplicaInfo]*protos.LoadReport{},
 	}
 
 	// Run the manager.
@@ -221,6 +233,9 @@ func RunManager(ctx context.Context, dep *protos.Deployment, locations []string,
 		}
 	}()
 
+	// Run the rebalancer.
+	go m.rebalance()
+
 	return func() error {
 		return m.registry.Unregister(m.ctx, m.dep.Id)
 	}, nil
@@ -291,6 +306,7 @@ func (m *manager) addHTTPHandlers(mux *http.ServeMux) {
 	mux.HandleFunc(recvLogEntryURL, protomsg.HandlerDo(m.logger, m.handleLogEntry))
 	mux.HandleFunc(recvTraceSpansURL, protomsg.HandlerDo(m.logger, m.handleTraceSpans))
 	mux.HandleFunc(recvMetricsURL, protomsg.HandlerDo(m.logger, m.handleRecvMetrics))
+	mux.HandleFunc(recvLoadURL, protomsg.HandlerDo(m.logger, m.handleRecvLoad))
 }
 
 // registerStatusPages registers the status pages with the provided mux.
@@ -505,8 +521,9 @@ func (m *manager) exportListener(_ context.Context, req *protos.ExportListenerRe
 	}
 	addr := lis.Addr().String()
 	m.logger.Info("Proxy listening", "address", addr)
-	proxy := proxy.NewProxy(m.logger)
+	proxy := proxy.NewProxy(m.logger, proxy.Options{})
 	proxy.AddBackend(req.Address)
+	go proxy.CheckHealth(m.ctx, weaver.HealthzURL, healthCheckInterval)
 	m.proxies[req.Listener] = &proxyInfo{
 		listener: req.Listener,
 		proxy:    proxy,
@@ -580,7 +597,7 @@ func (m *manager) startColocationGroup(g *group, runMain bool) error {
 			LogDir:      m.logDir,
 			RunMain:     runMain,
 		}
-		if err := m.startBabysitter(loc, info); err != nil {
+		if err := m.launcher.StartBabysitter(m.ctx, loc, info); err != nil {
 			return fmt.Errorf("unable to start babysitter for group %s at location %s: %w\n", g.name, loc, err)
 		}
 		m.logger.Info("Started babysitter", "location", loc, "colocation group", g.name)
@@ -607,17 +624,110 @@ func (m *manager) handleRecvMetrics(_ context.Context, metrics *BabysitterMetric
 	return nil
 }
 
-// startBabysitter starts a new babysitter that manages a colocation group using SSH.
-func (m *manager) startBabysitter(loc string, info *BabysitterInfo) error {
-	input, err := proto.ToEnv(info)
-	if err != nil {
-		return err
+func (m *manager) handleRecvLoad(_ context.Context, load *BabysitterLoad) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loads[groupReplicaInfo{name: load.GroupName, id: load.ReplicaId}] = load.Load
+	return nil
+}
+
+// rebalance periodically rebalances the assignments of routed components
+// using the latest load reported by the babysitters.
+func (m *manager) rebalance() {
+	ticker := time.NewTicker(rebalanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, g := range m.allGroups() {
+				m.rebalanceGroup(g)
+			}
+		case <-m.ctx.Done():
+			return
+		}
 	}
+}
 
-	env := fmt.Sprintf("%s=%s", babysitterInfoKey, input)
-	binaryPath := filepath.Join(os.TempDir(), m.dep.Id, "weaver")
-	cmd := exec.Command("ssh", loc, env, binaryPath, "ssh", "babysitter")
-	return cmd.Start()
+// rebalanceGroup rebalances the assignments of the routed components in the
+// provided group.
+//
+// REQUIRES: m.mu and g.mu are NOT held.
+func (m *manager) rebalanceGroup(g *group) {
+	// Snapshot the current assignment version of every routed component.
+	g.mu.Lock()
+	routings := maps.Clone(g.routings)
+	g.mu.Unlock()
+	versions := map[string]uint64{}
+	for component, r := range routings {
+		r.RLock("")
+		if r.Val.Assignment != nil {
+			versions[component] = r.Val.Assignment.Version
+		}
+		r.RUnlock()
+	}
+
+	// Gather the latest load reports for the group, pruning the reports that
+	// are older than every current assignment. Such reports are ignored by the
+	// balancer and would otherwise linger in m.loads forever.
+	m.mu.Lock()
+	var reports []*protos.LoadReport
+	for info, report := range m.loads {
+		if info.name != g.name {
+			continue
+		}
+		if staleLoad(report, versions) {
+			delete(m.loads, info)
+			continue
+		}
+		reports = append(reports, report)
+	}
+	m.mu.Unlock()
+	if len(reports) == 0 {
+		return
+	}
+
+	replicas := g.allAddresses()
+	for component, r := range routings {
+		var loads []*protos.LoadReport_ComponentLoad
+		for _, report := range reports {
+			if load, ok := report.Loads[component]; ok {
+				loads = append(loads, load)
+			}
+		}
+
+		// Compute the new assignment under the read lock, so that watchers
+		// aren't woken up if the assignment doesn't change.
+		r.RLock("")
+		assignment := r.Val.Assignment
+		var balanced *protos.Assignment
+		if assignment != nil {
+			balanced = routing.BalanceByLoad(assignment, replicas, loads, routing.BalanceOptions{})
+		}
+		r.RUnlock()
+		if balanced == assignment {
+			continue
+		}
+
+		r.Lock()
+		if r.Val.Assignment == assignment {
+			// The assignment hasn't changed in the meantime.
+			r.Val.Assignment = balanced
+			m.logger.Debug(fmt.Sprintf("Rebalanced assignment for component %s:\n%s", component, routing.FormatAssignment(balanced)))
+		}
+		r.Unlock()
+	}
+}
+
+// staleLoad returns whether every component load in the provided report was
+// reported with respect to an assignment older than the component's current
+// assignment, as given by versions.
+func staleLoad(report *protos.LoadReport, versions map[string]uint64) bool {
+	for component, load := range report.Loads {
+		if version, ok := versions[component]; !ok || load.Version >= version {
+			return false
+		}
+	}
+	return true
 }
 
 func (m *manager) getRoutingInfo(_ context.Context, req *GetRoutingInfoRequest) (*GetRoutingInfoReply, error) {
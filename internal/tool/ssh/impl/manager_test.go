@@ -0,0 +1,63 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/internal/versioned"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+func TestRebalancePrunesStaleLoads(t *testing.T) {
+	m := &manager{
+		logger: logging.NewTestLogger(t),
+		groups: map[string]*group{},
+		loads:  map[groupReplicaInfo]*protos.LoadReport{},
+	}
+	g := m.group("foo")
+	g.addresses["a"] = true
+	g.routings["foo"] = versioned.Version(&protos.RoutingInfo{
+		Component:  "foo",
+		Replicas:   []string{"a"},
+		Assignment: &protos.Assignment{Version: 2},
+	})
+
+	report := func(version uint64) *protos.LoadReport {
+		return &protos.LoadReport{
+			Loads: map[string]*protos.LoadReport_ComponentLoad{
+				"foo": {Version: version},
+			},
+		}
+	}
+	stale := groupReplicaInfo{name: "foo", id: 0}
+	current := groupReplicaInfo{name: "foo", id: 1}
+	other := groupReplicaInfo{name: "bar", id: 0}
+	m.loads[stale] = report(1)
+	m.loads[current] = report(2)
+	m.loads[other] = report(1)
+
+	m.rebalanceGroup(g)
+	if _, ok := m.loads[stale]; ok {
+		t.Errorf("stale load report for %v not pruned", stale)
+	}
+	if _, ok := m.loads[current]; !ok {
+		t.Errorf("current load report for %v pruned", current)
+	}
+	if _, ok := m.loads[other]; !ok {
+		t.Errorf("load report for %v in another group pruned", other)
+	}
+}
@@ -431,6 +431,71 @@ func (x *BabysitterMetrics) GetMetrics() []*protos.MetricSnapshot {
 	return nil
 }
 
+// BabysitterLoad is a load report for the routed components hosted by a
+// weavelet, as collected by a babysitter for a given colocation group.
+type BabysitterLoad struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupName string             `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	ReplicaId int32              `protobuf:"varint,2,opt,name=replica_id,json=replicaId,proto3" json:"replica_id,omitempty"`
+	Load      *protos.LoadReport `protobuf:"bytes,3,opt,name=load,proto3" json:"load,omitempty"`
+}
+
+func (x *BabysitterLoad) Reset() {
+	*x = BabysitterLoad{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BabysitterLoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BabysitterLoad) ProtoMessage() {}
+
+func (x *BabysitterLoad) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BabysitterLoad.ProtoReflect.Descriptor instead.
+func (*BabysitterLoad) Descriptor() ([]byte, []int) {
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BabysitterLoad) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *BabysitterLoad) GetReplicaId() int32 {
+	if x != nil {
+		return x.ReplicaId
+	}
+	return 0
+}
+
+func (x *BabysitterLoad) GetLoad() *protos.LoadReport {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
 // ReplicaToRegister is a request to the manager to register a replica of
 // a given colocation group (i.e., a weavelet).
 type ReplicaToRegister struct {
@@ -446,7 +511,7 @@ type ReplicaToRegister struct {
 func (x *ReplicaToRegister) Reset() {
 	*x = ReplicaToRegister{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[6]
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -459,7 +524,7 @@ func (x *ReplicaToRegister) String() string {
 func (*ReplicaToRegister) ProtoMessage() {}
 
 func (x *ReplicaToRegister) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[6]
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -472,7 +537,7 @@ func (x *ReplicaToRegister) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplicaToRegister.ProtoReflect.Descriptor instead.
 func (*ReplicaToRegister) Descriptor() ([]byte, []int) {
-	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{6}
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ReplicaToRegister) GetGroup() string {
@@ -496,6 +561,175 @@ func (x *ReplicaToRegister) GetPid() int64 {
 	return 0
 }
 
+// StopDeploymentRequest is a request to an agent to stop all the babysitters
+// of a deployment and delete its binaries.
+type StopDeploymentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeploymentId string `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+}
+
+func (x *StopDeploymentRequest) Reset() {
+	*x = StopDeploymentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopDeploymentRequest) ProtoMessage() {}
+
+func (x *StopDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*StopDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StopDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+// AgentStatus describes the deployments running on an agent.
+type AgentStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deployments []*AgentDeployment `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+}
+
+func (x *AgentStatus) Reset() {
+	*x = AgentStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStatus) ProtoMessage() {}
+
+func (x *AgentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStatus.ProtoReflect.Descriptor instead.
+func (*AgentStatus) Descriptor() ([]byte, []int) {
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AgentStatus) GetDeployments() []*AgentDeployment {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+// AgentDeployment describes a deployment running on an agent.
+type AgentDeployment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeploymentId string   `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	App          string   `protobuf:"bytes,2,opt,name=app,proto3" json:"app,omitempty"`
+	Groups       []string `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`     // co-location groups with running babysitters
+	Pids         []int64  `protobuf:"varint,4,rep,packed,name=pids,proto3" json:"pids,omitempty"` // babysitter pids
+}
+
+func (x *AgentDeployment) Reset() {
+	*x = AgentDeployment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentDeployment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentDeployment) ProtoMessage() {}
+
+func (x *AgentDeployment) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentDeployment.ProtoReflect.Descriptor instead.
+func (*AgentDeployment) Descriptor() ([]byte, []int) {
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AgentDeployment) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *AgentDeployment) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+func (x *AgentDeployment) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *AgentDeployment) GetPids() []int64 {
+	if x != nil {
+		return x.Pids
+	}
+	return nil
+}
+
 var File_internal_tool_ssh_impl_ssh_proto protoreflect.FileDescriptor
 
 var file_internal_tool_ssh_impl_ssh_proto_rawDesc = []byte{
@@ -552,16 +786,40 @@ var file_internal_tool_ssh_impl_ssh_proto_rawDesc = []byte{
 	0x64, 0x12, 0x31, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x4d, 0x65, 0x74,
 	0x72, 0x69, 0x63, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x07, 0x6d, 0x65, 0x74,
-	0x72, 0x69, 0x63, 0x73, 0x22, 0x55, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x54,
-	0x6f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
-	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x70, 0x69, 0x64, 0x42, 0x38, 0x5a, 0x36, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x57, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x6f, 0x6c, 0x2f, 0x73, 0x73, 0x68,
-	0x2f, 0x69, 0x6d, 0x70, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x69, 0x63, 0x73, 0x22, 0x77, 0x0a, 0x0e, 0x42, 0x61, 0x62, 0x79, 0x73, 0x69, 0x74, 0x74,
+	0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x4c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x55, 0x0a,
+	0x11, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x54, 0x6f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x70, 0x69, 0x64, 0x22, 0x3c, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x70, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x22, 0x46, 0x0a, 0x0b, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x37, 0x0a, 0x0b, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x64,
+	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x74, 0x0a, 0x0f, 0x41, 0x67,
+	0x65, 0x6e, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x61, 0x70, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x70, 0x69, 0x64, 0x73,
+	0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x77, 0x65, 0x61,
+	0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x6f,
+	0x6c, 0x2f, 0x73, 0x73, 0x68, 0x2f, 0x69, 0x6d, 0x70, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -576,7 +834,7 @@ func file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP() []byte {
 	return file_internal_tool_ssh_impl_ssh_proto_rawDescData
 }
 
-var file_internal_tool_ssh_impl_ssh_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_internal_tool_ssh_impl_ssh_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_internal_tool_ssh_impl_ssh_proto_goTypes = []interface{}{
 	(*BabysitterInfo)(nil),        // 0: impl.BabysitterInfo
 	(*GetComponentsRequest)(nil),  // 1: impl.GetComponentsRequest
@@ -584,20 +842,27 @@ var file_internal_tool_ssh_impl_ssh_proto_goTypes = []interface{}{
 	(*GetRoutingInfoRequest)(nil), // 3: impl.GetRoutingInfoRequest
 	(*GetRoutingInfoReply)(nil),   // 4: impl.GetRoutingInfoReply
 	(*BabysitterMetrics)(nil),     // 5: impl.BabysitterMetrics
-	(*ReplicaToRegister)(nil),     // 6: impl.ReplicaToRegister
-	(*protos.Deployment)(nil),     // 7: runtime.Deployment
-	(*protos.RoutingInfo)(nil),    // 8: runtime.RoutingInfo
-	(*protos.MetricSnapshot)(nil), // 9: runtime.MetricSnapshot
+	(*BabysitterLoad)(nil),        // 6: impl.BabysitterLoad
+	(*ReplicaToRegister)(nil),     // 7: impl.ReplicaToRegister
+	(*StopDeploymentRequest)(nil), // 8: impl.StopDeploymentRequest
+	(*AgentStatus)(nil),           // 9: impl.AgentStatus
+	(*AgentDeployment)(nil),       // 10: impl.AgentDeployment
+	(*protos.Deployment)(nil),     // 11: runtime.Deployment
+	(*protos.RoutingInfo)(nil),    // 12: runtime.RoutingInfo
+	(*protos.MetricSnapshot)(nil), // 13: runtime.MetricSnapshot
+	(*protos.LoadReport)(nil),     // 14: runtime.LoadReport
 }
 var file_internal_tool_ssh_impl_ssh_proto_depIdxs = []int32{
-	7, // 0: impl.BabysitterInfo.deployment:type_name -> runtime.Deployment
-	8, // 1: impl.GetRoutingInfoReply.routing_info:type_name -> runtime.RoutingInfo
-	9, // 2: impl.BabysitterMetrics.metrics:type_name -> runtime.MetricSnapshot
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	11, // 0: impl.BabysitterInfo.deployment:type_name -> runtime.Deployment
+	12, // 1: impl.GetRoutingInfoReply.routing_info:type_name -> runtime.RoutingInfo
+	13, // 2: impl.BabysitterMetrics.metrics:type_name -> runtime.MetricSnapshot
+	14, // 3: impl.BabysitterLoad.load:type_name -> runtime.LoadReport
+	10, // 4: impl.AgentStatus.deployments:type_name -> impl.AgentDeployment
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_internal_tool_ssh_impl_ssh_proto_init() }
@@ -679,6 +944,18 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			}
 		}
 		file_internal_tool_ssh_impl_ssh_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BabysitterLoad); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_ssh_impl_ssh_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReplicaToRegister); i {
 			case 0:
 				return &v.state
@@ -690,6 +967,42 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 				return nil
 			}
 		}
+		file_internal_tool_ssh_impl_ssh_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopDeploymentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_ssh_impl_ssh_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_ssh_impl_ssh_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentDeployment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -697,7 +1010,7 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_tool_ssh_impl_ssh_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -28,6 +28,9 @@ var (
 
 	Commands = map[string]*tool.Command{
 		"deploy":    &deployCmd,
+		"agent":     &agentCmd,
+		"agents":    &agentsCmd,
+		"certs":     &certsCmd,
 		"logs":      tool.LogsCmd(&logsSpec),
 		"dashboard": status.DashboardCommand(dashboardSpec),
 		"version":   tool.VersionCmd("weaver ssh"),
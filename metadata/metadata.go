@@ -0,0 +1,80 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata provides an API for attaching request-scoped metadata
+// (e.g., a tenant id, an authenticated principal, or a set of experiment
+// flags) to a context.
+//
+// Metadata is a set of string key-value pairs. Metadata attached to the
+// context passed to a component method call is propagated to the context
+// received by the component method, whether the call is local or remote. The
+// metadata is propagated transitively through every component that the
+// method calls with that context.
+//
+//	// In the frontend.
+//	ctx = metadata.NewContext(ctx, map[string]string{"tenant": "acme"})
+//	reply, err := backend.Get(ctx, key)
+//
+//	// In the backend.
+//	func (b *backend) Get(ctx context.Context, key string) (string, error) {
+//		tenant, _ := metadata.Value(ctx, "tenant")
+//		...
+//	}
+//
+// Metadata is sent along with every remote method call, so keep it small.
+package metadata
+
+import (
+	"context"
+)
+
+// metadataKey is the context key for metadata.
+type metadataKey struct{}
+
+// NewContext returns a copy of ctx that carries the provided metadata, merged
+// with the metadata (if any) already carried by ctx. If a key is present in
+// both, the value in md takes precedence.
+func NewContext(ctx context.Context, md map[string]string) context.Context {
+	old, _ := ctx.Value(metadataKey{}).(map[string]string)
+	merged := make(map[string]string, len(old)+len(md))
+	for k, v := range old {
+		merged[k] = v
+	}
+	for k, v := range md {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, metadataKey{}, merged)
+}
+
+// FromContext returns the metadata carried by ctx, if any. The returned map
+// is a copy and may be freely modified by the caller.
+func FromContext(ctx context.Context) (map[string]string, bool) {
+	md, ok := ctx.Value(metadataKey{}).(map[string]string)
+	if !ok {
+		return nil, false
+	}
+	result := make(map[string]string, len(md))
+	for k, v := range md {
+		result[k] = v
+	}
+	return result, true
+}
+
+// Value returns the value associated with key in the metadata carried by
+// ctx, if any.
+func Value(ctx context.Context, key string) (string, bool) {
+	md, _ := ctx.Value(metadataKey{}).(map[string]string)
+	v, ok := md[key]
+	return v, ok
+}
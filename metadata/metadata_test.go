@@ -0,0 +1,63 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/metadata"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNoMetadata(t *testing.T) {
+	ctx := context.Background()
+	if md, ok := metadata.FromContext(ctx); ok {
+		t.Fatalf("FromContext: unexpected metadata %v", md)
+	}
+	if v, ok := metadata.Value(ctx, "a"); ok {
+		t.Fatalf("Value: unexpected value %q", v)
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	ctx := context.Background()
+	ctx = metadata.NewContext(ctx, map[string]string{"a": "1", "b": "2"})
+	ctx = metadata.NewContext(ctx, map[string]string{"b": "3", "c": "4"})
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		t.Fatal("FromContext: no metadata")
+	}
+	want := map[string]string{"a": "1", "b": "3", "c": "4"}
+	if diff := cmp.Diff(want, md); diff != "" {
+		t.Fatalf("FromContext (-want +got):\n%s", diff)
+	}
+	if v, ok := metadata.Value(ctx, "b"); !ok || v != "3" {
+		t.Fatalf("Value(b) = %q, %t; want %q, true", v, ok, "3")
+	}
+}
+
+func TestImmutable(t *testing.T) {
+	// Modifying the maps passed to and returned by the metadata package
+	// should not modify the metadata in a context.
+	in := map[string]string{"a": "1"}
+	ctx := metadata.NewContext(context.Background(), in)
+	in["a"] = "2"
+	out, _ := metadata.FromContext(ctx)
+	out["a"] = "3"
+	if v, _ := metadata.Value(ctx, "a"); v != "1" {
+		t.Fatalf("Value(a) = %q; want %q", v, "1")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"crypto/tls"
 	"math/rand"
 	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/cond"
 	"github.com/ServiceWeaver/weaver/internal/net/call"
@@ -36,9 +37,15 @@ type routingBalancer struct {
 	index      index
 }
 
-// newRoutingBalancer returns a new routingBalancer.
-func newRoutingBalancer(tlsConfig *tls.Config) *routingBalancer {
-	return &routingBalancer{balancer: call.RoundRobin(), tlsConfig: tlsConfig}
+// newRoutingBalancer returns a new routingBalancer for the provided
+// component that uses the provided default balancer. Unhealthy endpoints are
+// ejected from the default balancer, but not from routing assignments.
+func newRoutingBalancer(component string, balancer call.Balancer, tlsConfig *tls.Config) *routingBalancer {
+	opts := call.EjectionOptions{Component: component}
+	return &routingBalancer{
+		balancer:  call.OutlierEjection(balancer, opts),
+		tlsConfig: tlsConfig,
+	}
 }
 
 // Update implements the call.Balancer interface.
@@ -46,6 +53,13 @@ func (rb *routingBalancer) Update(endpoints []call.Endpoint) {
 	rb.balancer.Update(endpoints)
 }
 
+// Observe implements the call.HealthObserver interface.
+func (rb *routingBalancer) Observe(endpoint call.Endpoint, latency time.Duration, err error) {
+	if h, ok := rb.balancer.(call.HealthObserver); ok {
+		h.Observe(endpoint, latency, err)
+	}
+}
+
 // update updates the balancer with the provided assignment
 func (rb *routingBalancer) update(assignment *protos.Assignment) {
 	if assignment == nil {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"strconv"
 )
 
@@ -43,6 +44,11 @@ type Bootstrap struct {
 	ToWeaveletFile *os.File // Pipe to send to weavelet (weavertest only).
 	ToEnvelopeFile *os.File // Pipe to send to envelope (weavertest only).
 	TestConfig     string   // Config file contents (weavertest only).
+
+	// Fakes maps component interface types to fake implementations that
+	// are used instead of the real component implementations (weavertest
+	// only).
+	Fakes map[reflect.Type]any
 }
 
 // BootstrapKey is the Context key used by weavertest to pass Bootstrap to [weaver.Run].
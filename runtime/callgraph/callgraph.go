@@ -0,0 +1,187 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package callgraph contains code to read the static component graph that
+// "weaver generate" embeds in a Service Weaver binary.
+//
+// The graph has one node per component and an edge from component A to
+// component B if the implementation of A has a weaver.Ref[B] field. Because
+// the graph is read directly from the binary, deployers and tools can inspect
+// it without running the application.
+package callgraph
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+// Graph is a static component graph.
+type Graph struct {
+	Components []Component `json:"components"`
+	Edges      []Edge      `json:"edges"`
+}
+
+// Component is a node in a component graph.
+type Component struct {
+	Name    string   `json:"name"`              // full package-prefixed component name
+	Methods []string `json:"methods,omitempty"` // component method names
+}
+
+// Edge is an edge in a component graph. It records that the implementation
+// of the Caller component holds a weaver.Ref to the Callee component.
+type Edge struct {
+	Caller string `json:"caller"`
+	Callee string `json:"callee"`
+}
+
+// ReadBinary reads the component graph embedded in the provided binary.
+func ReadBinary(file string) (*Graph, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read component graph: %w", err)
+	}
+	return Extract(data), nil
+}
+
+// Extract extracts the component graph embedded in the provided data (e.g.,
+// the contents of a binary). Components and edges are sorted by name, and
+// duplicates are removed.
+func Extract(data []byte) *Graph {
+	g := &Graph{Components: []Component{}, Edges: []Edge{}}
+	seen := map[string]bool{}
+	for _, c := range codegen.ExtractComponents(data) {
+		if seen[c.Name] {
+			continue
+		}
+		seen[c.Name] = true
+		g.Components = append(g.Components, Component{Name: c.Name, Methods: c.Methods})
+	}
+	seenEdges := map[Edge]bool{}
+	for _, e := range codegen.ExtractEdges(data) {
+		edge := Edge{Caller: e[0], Callee: e[1]}
+		if seenEdges[edge] {
+			continue
+		}
+		seenEdges[edge] = true
+		g.Edges = append(g.Edges, edge)
+
+		// A component may reference a component that is not linked into
+		// the binary (e.g., a component whose package was not regenerated).
+		for _, name := range e {
+			if !seen[name] {
+				seen[name] = true
+				g.Components = append(g.Components, Component{Name: name})
+			}
+		}
+	}
+	sort.Slice(g.Components, func(i, j int) bool {
+		return g.Components[i].Name < g.Components[j].Name
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		ei, ej := g.Edges[i], g.Edges[j]
+		if ei.Caller != ej.Caller {
+			return ei.Caller < ej.Caller
+		}
+		return ei.Callee < ej.Callee
+	})
+	return g
+}
+
+// Cycles returns the cycles in the component graph. Every cycle is returned
+// as the sorted list of the names of the components that are part of it. A
+// component that holds a weaver.Ref to itself forms a cycle of length one.
+func (g *Graph) Cycles() [][]string {
+	succs := map[string][]string{}
+	for _, e := range g.Edges {
+		succs[e.Caller] = append(succs[e.Caller], e.Callee)
+	}
+
+	// Compute the strongly connected components of the graph using Tarjan's
+	// algorithm. Every strongly connected component with more than one node,
+	// or with a self-edge, is a cycle.
+	index := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var cycles [][]string
+	var visit func(v string)
+	visit = func(v string) {
+		index[v] = len(index)
+		lowlink[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+		selfEdge := false
+		for _, w := range succs[v] {
+			if w == v {
+				selfEdge = true
+			}
+			if _, ok := index[w]; !ok {
+				visit(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], index[w])
+			}
+		}
+		if lowlink[v] != index[v] {
+			return
+		}
+		var scc []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		if len(scc) > 1 || selfEdge {
+			sort.Strings(scc)
+			cycles = append(cycles, scc)
+		}
+	}
+	for _, c := range g.Components {
+		if _, ok := index[c.Name]; !ok {
+			visit(c.Name)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// DOT returns the component graph in the Graphviz DOT format.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "digraph {")
+	for _, c := range g.Components {
+		fmt.Fprintf(&b, "  %s;\n", strconv.Quote(c.Name))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", strconv.Quote(e.Caller), strconv.Quote(e.Callee))
+	}
+	fmt.Fprintln(&b, "}")
+	return b.String()
+}
+
+func min(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
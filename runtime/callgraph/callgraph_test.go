@@ -0,0 +1,127 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callgraph_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/callgraph"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/google/go-cmp/cmp"
+)
+
+// refData mimics the RefData that "weaver generate" emits for two components
+// A and B, where A holds a weaver.Ref[B]. It is embedded in the test binary.
+var refData = "⟦fadfaaa2:wEaVeRcOmPoNeNt:callgraph_test/A:Foo,Bar⟧\n⟦d7804379:wEaVeRcOmPoNeNt:callgraph_test/B:⟧\n⟦ebc5f21d:wEaVeReDgE:callgraph_test/A→callgraph_test/B⟧\n"
+
+func TestMarkers(t *testing.T) {
+	// Check that refData matches the output of codegen.
+	want := codegen.MakeComponentString("callgraph_test/A", []string{"Foo", "Bar"}) +
+		codegen.MakeComponentString("callgraph_test/B", nil) +
+		codegen.MakeEdgeString("callgraph_test/A", "callgraph_test/B")
+	if refData != want {
+		t.Fatalf("refData = %q, want %q", refData, want)
+	}
+}
+
+func TestReadBinary(t *testing.T) {
+	binary, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph, err := callgraph.ReadBinary(binary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &callgraph.Graph{
+		Components: []callgraph.Component{
+			{Name: "callgraph_test/A", Methods: []string{"Foo", "Bar"}},
+			{Name: "callgraph_test/B"},
+		},
+		Edges: []callgraph.Edge{{Caller: "callgraph_test/A", Callee: "callgraph_test/B"}},
+	}
+	if diff := cmp.Diff(want, graph); diff != "" {
+		t.Fatalf("ReadBinary (-want +got):\n%s", diff)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	data := strings.Join([]string{
+		"garbage",
+		codegen.MakeComponentString("c", []string{"M"}),
+		codegen.MakeEdgeString("c", "a"),
+		codegen.MakeComponentString("a", nil),
+		codegen.MakeEdgeString("c", "a"), // duplicate
+		codegen.MakeEdgeString("a", "b"), // b has no component entry
+		"⟦00000000:wEaVeReDgE:a→c⟧",      // bad checksum
+		"more garbage",
+	}, "")
+	want := &callgraph.Graph{
+		Components: []callgraph.Component{
+			{Name: "a"},
+			{Name: "b"},
+			{Name: "c", Methods: []string{"M"}},
+		},
+		Edges: []callgraph.Edge{
+			{Caller: "a", Callee: "b"},
+			{Caller: "c", Callee: "a"},
+		},
+	}
+	if diff := cmp.Diff(want, callgraph.Extract([]byte(data))); diff != "" {
+		t.Fatalf("Extract (-want +got):\n%s", diff)
+	}
+}
+
+func TestCycles(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		edges [][2]string
+		want  [][]string
+	}{
+		{"Empty", nil, nil},
+		{"Chain", [][2]string{{"a", "b"}, {"b", "c"}}, nil},
+		{"Diamond", [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}}, nil},
+		{"SelfEdge", [][2]string{{"a", "a"}, {"a", "b"}}, [][]string{{"a"}}},
+		{"Cycle", [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}}, [][]string{{"a", "b", "c"}}},
+		{"TwoCycles", [][2]string{{"d", "c"}, {"c", "d"}, {"a", "b"}, {"b", "a"}, {"b", "c"}}, [][]string{{"a", "b"}, {"c", "d"}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var data strings.Builder
+			for _, e := range test.edges {
+				data.WriteString(codegen.MakeEdgeString(e[0], e[1]))
+			}
+			got := callgraph.Extract([]byte(data.String())).Cycles()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Fatalf("Cycles (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDOT(t *testing.T) {
+	data := codegen.MakeComponentString("a", nil) + codegen.MakeEdgeString("a", "b")
+	got := callgraph.Extract([]byte(data)).DOT()
+	want := `digraph {
+  "a";
+  "b";
+  "a" -> "b";
+}
+`
+	if got != want {
+		t.Fatalf("DOT() = %q, want %q", got, want)
+	}
+}
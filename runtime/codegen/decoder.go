@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"unsafe"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -42,12 +43,31 @@ func (d decoderError) Unwrap() error {
 
 // Decoder deserializes data from a byte slice data in the expected results.
 type Decoder struct {
-	data []byte
+	data  []byte
+	alias bool // if true, decoded strings alias data
 }
 
 // NewDecoder instantiates a new Decoder for a given byte slice.
 func NewDecoder(data []byte) *Decoder {
-	return &Decoder{data}
+	return &Decoder{data: data}
+}
+
+// NewAliasingDecoder is like NewDecoder, but the returned Decoder avoids
+// copying data when possible: decoded strings, like decoded byte slices,
+// alias data rather than being copied out of it. This saves allocations, but
+// the decoded values keep all of data alive.
+//
+// It is only safe to use NewAliasingDecoder if data is never modified after
+// decoding starts (e.g., if data holds a message that was read from the
+// network and is not reused).
+func NewAliasingDecoder(data []byte) *Decoder {
+	return &Decoder{data: data, alias: true}
+}
+
+// sub returns a Decoder for data, a part of the data of d, that decodes in
+// the same mode as d.
+func (d *Decoder) sub(data []byte) *Decoder {
+	return &Decoder{data: data, alias: d.alias}
 }
 
 // Empty returns true iff all bytes in d have been consumed.
@@ -81,7 +101,9 @@ func (d *Decoder) Read(n int) []byte {
 	if len := len(d.data); len < n {
 		panic(makeDecodeError("unable to read #bytes: %d", n))
 	}
-	b := d.data[:n]
+	// Limit the capacity of b, so that appending to it doesn't overwrite
+	// the data that follows it.
+	b := d.data[:n:n]
 	d.data = d.data[n:]
 	return b
 }
@@ -181,7 +203,11 @@ func (d *Decoder) Complex128() complex128 {
 
 // String decodes a value of type string.
 func (d *Decoder) String() string {
-	return string(d.Bytes())
+	b := d.Bytes()
+	if d.alias && len(b) > 0 {
+		return unsafe.String(&b[0], len(b))
+	}
+	return string(b)
 }
 
 // Bytes decodes a value of type []byte.
@@ -212,8 +238,29 @@ func (d *Decoder) Len() int {
 	return n
 }
 
+// Field decodes the header of the next field of a struct with numbered fields
+// (see Encoder.BeginField). It returns the field number and a Decoder for the
+// serialization of the field. Once the last field has been decoded, Field
+// returns 0 and nil.
+//
+// Fields with unknown field numbers, written by a newer version of a struct,
+// can be skipped by ignoring the returned Decoder.
+//
+// NOTE that this method should be called only in the generated code.
+func (d *Decoder) Field() (int, *Decoder) {
+	num := d.Uint32()
+	if num == 0 {
+		return 0, nil
+	}
+	n := d.Int32()
+	if n < 0 {
+		panic(makeDecodeError("unable to decode field %d; expected length >= 0 got %d", num, n))
+	}
+	return int(num), d.sub(d.Read(int(n)))
+}
+
 // Error decodes an error. We construct an instance of a special error value
-// that provides Is and Unwrap support.
+// that provides Is, As, and Unwrap support.
 func (d *Decoder) Error() error {
 	n := d.Int()
 	if n == 0 {
@@ -223,7 +270,8 @@ func (d *Decoder) Error() error {
 	for i := 0; i < n; i++ {
 		msg := d.String()
 		f := d.String()
-		err = append(err, decodedErrorEntry{msg, f})
+		value := decodeErrorValue(d)
+		err = append(err, decodedErrorEntry{msg, f, value})
 	}
 	// Note that we intentionally return nil when n==0 so that the deserialization
 	// of a serialized nil error remains nil
@@ -233,8 +281,9 @@ func (d *Decoder) Error() error {
 type decodedErrorStack []decodedErrorEntry
 
 type decodedErrorEntry struct {
-	msg string // Error() result
-	fmt string // Result of fmtError
+	msg   string // Error() result
+	fmt   string // Result of fmtError
+	value error  // Reconstructed error value, or nil if not serializable
 }
 
 // Error implements error.Error.
@@ -253,6 +302,24 @@ func (e decodedErrorStack) Is(target error) bool {
 	return e[0].fmt == fmtError(target)
 }
 
+// As sets target to the reconstructed value of e, if e's value was serialized
+// and is assignable to target. See RegisterSerializableError.
+func (e decodedErrorStack) As(target any) bool {
+	if e[0].value == nil {
+		return false
+	}
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return false
+	}
+	value := reflect.ValueOf(e[0].value)
+	if !value.Type().AssignableTo(v.Type().Elem()) {
+		return false
+	}
+	v.Elem().Set(value)
+	return true
+}
+
 // fmtError serializes an error value including its type info using fmt.Sprintf.
 func fmtError(v error) string {
 	// Include package and type info explicitly since %#v uses a shortened path.
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -52,6 +53,33 @@ func NewEncoder() *Encoder {
 	return &enc
 }
 
+// maxPooledSize is the largest buffer capacity of an Encoder that is returned
+// to the pool by PutEncoder. Larger encoders are left to the garbage collector,
+// so that a few large messages don't pin a lot of memory.
+const maxPooledSize = 64 << 10
+
+// encoders is a pool of Encoders. See GetEncoder and PutEncoder.
+var encoders = sync.Pool{New: func() any { return NewEncoder() }}
+
+// GetEncoder returns an empty Encoder with a capacity of at least n bytes.
+// The Encoder is taken from a pool of Encoders, so it may reuse the buffer of
+// an Encoder that was previously returned with PutEncoder.
+func GetEncoder(n int) *Encoder {
+	e := encoders.Get().(*Encoder)
+	e.Reset(n)
+	return e
+}
+
+// PutEncoder returns an Encoder obtained from GetEncoder to the pool. Neither
+// the Encoder nor the data it returned from Data may be used afterwards.
+func PutEncoder(e *Encoder) {
+	if cap(e.data) > maxPooledSize {
+		return
+	}
+	e.data = e.data[:0]
+	encoders.Put(e)
+}
+
 // Reset resets the Encoder to use a buffer with a capacity of at least the
 // provided size. All encoded data is lost.
 func (e *Encoder) Reset(n int) {
@@ -250,8 +278,45 @@ func (e *Encoder) Len(l int) {
 	e.Int32(int32(l))
 }
 
+// BeginField begins encoding the field with the provided field number. It is
+// used by the WeaverMarshal methods of structs with numbered fields, which
+// encode every field as its field number, followed by the length of its
+// serialization, followed by its serialization. The returned offset must be
+// passed to EndField once the value of the field has been encoded.
+//
+// NOTE that this method should be called only in the generated code.
+func (e *Encoder) BeginField(num int) int {
+	if num <= 0 || num > math.MaxUint32 {
+		panic(makeEncodeError("invalid field number %d", num))
+	}
+	e.Uint32(uint32(num))
+	e.Int32(0) // The length is filled in by EndField.
+	return len(e.data)
+}
+
+// EndField finishes encoding the field begun by the call to BeginField that
+// returned start.
+//
+// NOTE that this method should be called only in the generated code.
+func (e *Encoder) EndField(start int) {
+	n := len(e.data) - start
+	if n > math.MaxInt32 {
+		panic(makeEncodeError("unable to encode field; length doesn't fit in 4 bytes"))
+	}
+	binary.LittleEndian.PutUint32(e.data[start-4:start], uint32(n))
+}
+
+// EndFields marks the end of the fields of a struct with numbered fields.
+//
+// NOTE that this method should be called only in the generated code.
+func (e *Encoder) EndFields() {
+	e.Uint32(0)
+}
+
 // Error encodes an arg of type error. We save enough type information
-// to allow errors.Unwrap() and errors.Is() to work correctly.
+// to allow errors.Unwrap() and errors.Is() to work correctly. Errors whose
+// types were registered with RegisterSerializableError additionally have their
+// values saved, which allows errors.As() to work correctly.
 func (e *Encoder) Error(err error) {
 	// Get the stack of wrapped errors.
 	stack := make([]error, 0, 4)
@@ -264,9 +329,6 @@ func (e *Encoder) Error(err error) {
 	for _, err := range stack {
 		e.String(err.Error())
 		e.String(fmtError(err))
-
-		// TODO(sanjay): If a wrapped errors can be serialized using Gob, consider
-		// saving that serialization. This may allow us to implement the As() method
-		// that reconstructs an error value with the original type at the receiver.
+		encodeErrorValue(e, err)
 	}
 }
@@ -24,6 +24,7 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -131,6 +132,65 @@ func convertCallPanicToError(fn func()) (err error) {
 	return
 }
 
+// TestGetEncoder gets encoders from the pool. Verify that they are empty and
+// have the requested capacity, even if they were used before.
+func TestGetEncoder(t *testing.T) {
+	for _, n := range []int{0, 10, 100, 1000, 10000, 2 * maxPooledSize} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			enc := GetEncoder(n)
+			defer PutEncoder(enc)
+			if got, want := len(enc.Data()), 0; got != want {
+				t.Fatalf("len(enc.Data()): got %d, want %d", got, want)
+			}
+			if got, want := cap(enc.data), n; got < want {
+				t.Fatalf("cap(enc.data): got %d, want at least %d", got, want)
+			}
+			enc.String("this is garbage text that must not be seen again")
+		})
+	}
+}
+
+// TestAliasingDecoder encodes values and decodes them with an aliasing
+// decoder. Verify that the values are decoded as expected and that decoded
+// strings alias the encoded data.
+func TestAliasingDecoder(t *testing.T) {
+	input := append([]interface{}{}, values...)
+	enc := newEncoder()
+	encode(&enc, input)
+
+	dec := NewAliasingDecoder(enc.data)
+	output := decode(dec, input)
+	if diff := cmp.Diff(input, output); diff != "" {
+		t.Fatalf("list: (-want,+got):\n%s\n", diff)
+	}
+	if !dec.Empty() {
+		t.Fatalf("unexpected bytes left to be read:%d\n", len(dec.data))
+	}
+
+	enc = newEncoder()
+	enc.String("hello")
+	s := NewAliasingDecoder(enc.data).String()
+	if got, want := unsafe.StringData(s), &enc.data[len(enc.data)-len(s)]; got != want {
+		t.Fatalf("decoded string does not alias the encoded data")
+	}
+}
+
+// TestAppendDecodedBytes decodes two byte slices and appends to the first
+// one. Verify that the append doesn't overwrite the second one.
+func TestAppendDecodedBytes(t *testing.T) {
+	enc := newEncoder()
+	enc.Bytes([]byte("foo"))
+	enc.Bytes([]byte("bar"))
+
+	dec := NewDecoder(enc.data)
+	foo := dec.Bytes()
+	bar := dec.Bytes()
+	_ = append(foo, "xxxxxxxx"...)
+	if got, want := string(bar), "bar"; got != want {
+		t.Fatalf("bar: got %q, want %q", got, want)
+	}
+}
+
 // TestErrorDecUnableToRead encodes an integer and attempts to decode an integer
 // and a bool value. Verify that a decoding error is triggered because there are
 // not enough bytes encoded to decode both values.
@@ -139,7 +199,7 @@ func TestErrorDecUnableToRead(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(12345)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Int()
 		dec.Bool()
 	})
@@ -156,7 +216,7 @@ func TestErrorUnableToDecBool(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(123)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Bool()
 	})
 	if !strings.Contains(err.Error(), "unable to decode bool") {
@@ -172,7 +232,7 @@ func TestErrorUnableToDecBytes(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(-10)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Bytes()
 	})
 	if !strings.Contains(err.Error(), "unable to decode bytes; expected length") {
@@ -239,6 +299,272 @@ func TestErrorValues(t *testing.T) {
 	}
 }
 
+// serializableTestError is a serializable error type.
+type serializableTestError struct {
+	Code int
+	Msg  string
+}
+
+func (e serializableTestError) Error() string { return fmt.Sprintf("%d: %s", e.Code, e.Msg) }
+
+func (e *serializableTestError) WeaverMarshal(enc *Encoder) {
+	enc.Int(e.Code)
+	enc.String(e.Msg)
+}
+
+func (e *serializableTestError) WeaverUnmarshal(dec *Decoder) {
+	e.Code = dec.Int()
+	e.Msg = dec.String()
+}
+
+func init() {
+	RegisterSerializableError[serializableTestError]()
+}
+
+func TestSerializableErrors(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		val  error
+	}{
+		{"value", serializableTestError{404, "not found"}},
+		{"pointer", &serializableTestError{404, "not found"}},
+		{"wrapped", fmt.Errorf("lookup: %w", serializableTestError{404, "not found"})},
+		{"wrapped-pointer", fmt.Errorf("lookup: %w", &serializableTestError{404, "not found"})},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			enc := newEncoder()
+			enc.Error(c.val)
+			dec := Decoder{data: enc.data}
+			got := dec.Error()
+			if !dec.Empty() {
+				t.Fatalf("leftover bytes in decoder")
+			}
+			if got.Error() != c.val.Error() {
+				t.Errorf("got error %q, want %q", got, c.val)
+			}
+
+			// errors.As should reconstruct the original value, with the
+			// original type.
+			var value serializableTestError
+			var pointer *serializableTestError
+			wantValue := errors.As(c.val, &value)
+			wantPointer := errors.As(c.val, &pointer)
+			var gotValue serializableTestError
+			var gotPointer *serializableTestError
+			if errors.As(got, &gotValue) != wantValue {
+				t.Errorf("errors.As(%v, *serializableTestError): got %t, want %t", got, !wantValue, wantValue)
+			}
+			if errors.As(got, &gotPointer) != wantPointer {
+				t.Errorf("errors.As(%v, **serializableTestError): got %t, want %t", got, !wantPointer, wantPointer)
+			}
+			if diff := cmp.Diff(value, gotValue); diff != "" {
+				t.Errorf("value (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(pointer, gotPointer); diff != "" {
+				t.Errorf("pointer (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnserializableErrors(t *testing.T) {
+	enc := newEncoder()
+	enc.Error(customTestError{"x"})
+	dec := Decoder{data: enc.data}
+	var target customTestError
+	if err := dec.Error(); errors.As(err, &target) {
+		t.Errorf("errors.As(%v, *customTestError) unexpectedly succeeded", err)
+	}
+}
+
+// testShape is an interface implemented by registered types.
+type testShape interface {
+	Area() float64
+}
+
+// testSquare implements testShape with a value receiver.
+type testSquare struct {
+	Side float64
+}
+
+func (s testSquare) Area() float64 { return s.Side * s.Side }
+
+func (s *testSquare) WeaverMarshal(enc *Encoder)   { enc.Float64(s.Side) }
+func (s *testSquare) WeaverUnmarshal(dec *Decoder) { s.Side = dec.Float64() }
+
+// testRect implements testShape with a pointer receiver.
+type testRect struct {
+	Width, Height float64
+}
+
+func (r *testRect) Area() float64 { return r.Width * r.Height }
+
+func (r *testRect) WeaverMarshal(enc *Encoder) {
+	enc.Float64(r.Width)
+	enc.Float64(r.Height)
+}
+
+func (r *testRect) WeaverUnmarshal(dec *Decoder) {
+	r.Width = dec.Float64()
+	r.Height = dec.Float64()
+}
+
+// testUnregistered is an AutoMarshal type that is not registered.
+type testUnregistered struct{ testSquare }
+
+func init() {
+	RegisterType[testSquare]()
+	RegisterType[testRect]()
+}
+
+func TestInterfaces(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		val  testShape
+	}{
+		{"nil", nil},
+		{"value", testSquare{2}},
+		{"pointer", &testSquare{3}},
+		{"pointer-receiver", &testRect{2, 3}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			enc := newEncoder()
+			enc.EncodeInterface(c.val)
+			dec := Decoder{data: enc.data}
+			got := DecodeInterface[testShape](&dec)
+			if !dec.Empty() {
+				t.Fatalf("leftover bytes in decoder")
+			}
+			if diff := cmp.Diff(c.val, got); diff != "" {
+				t.Fatalf("(-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnregisteredInterfaces(t *testing.T) {
+	enc := newEncoder()
+	err := convertCallPanicToError(func() { enc.EncodeInterface(testUnregistered{}) })
+	if err == nil || !strings.Contains(err.Error(), "unregistered type") {
+		t.Fatalf("EncodeInterface(testUnregistered{}): got %v, want unregistered type error", err)
+	}
+
+	// A value of a registered type that doesn't implement the interface
+	// can't be decoded.
+	enc = newEncoder()
+	enc.EncodeInterface(testSquare{2})
+	dec := Decoder{data: enc.data}
+	err = convertCallPanicToError(func() { DecodeInterface[error](&dec) })
+	if err == nil || !strings.Contains(err.Error(), "does not implement") {
+		t.Fatalf("DecodeInterface[error]: got %v, want does not implement error", err)
+	}
+}
+
+// fieldsV1 and fieldsV2 are two versions of a struct with numbered fields.
+// fieldsV2 deprecates field 2 and adds field 3.
+type fieldsV1 struct {
+	A int    // field 1
+	B string // field 2
+}
+
+type fieldsV2 struct {
+	A int      // field 1
+	C []string // field 3
+}
+
+func (f *fieldsV1) WeaverMarshal(enc *Encoder) {
+	start := enc.BeginField(1)
+	enc.Int(f.A)
+	enc.EndField(start)
+	start = enc.BeginField(2)
+	enc.String(f.B)
+	enc.EndField(start)
+	enc.EndFields()
+}
+
+func (f *fieldsV1) WeaverUnmarshal(dec *Decoder) {
+	*f = fieldsV1{}
+	for {
+		num, field := dec.Field()
+		switch num {
+		case 0:
+			return
+		case 1:
+			f.A = field.Int()
+		case 2:
+			f.B = field.String()
+		}
+	}
+}
+
+func (f *fieldsV2) WeaverMarshal(enc *Encoder) {
+	start := enc.BeginField(1)
+	enc.Int(f.A)
+	enc.EndField(start)
+	start = enc.BeginField(3)
+	enc.Len(len(f.C))
+	for _, c := range f.C {
+		enc.String(c)
+	}
+	enc.EndField(start)
+	enc.EndFields()
+}
+
+func (f *fieldsV2) WeaverUnmarshal(dec *Decoder) {
+	*f = fieldsV2{}
+	for {
+		num, field := dec.Field()
+		switch num {
+		case 0:
+			return
+		case 1:
+			f.A = field.Int()
+		case 3:
+			n := field.Len()
+			for i := 0; i < n; i++ {
+				f.C = append(f.C, field.String())
+			}
+		}
+	}
+}
+
+func TestNumberedFields(t *testing.T) {
+	// Old readers skip fields they don't know about.
+	enc := newEncoder()
+	(&fieldsV2{A: 1, C: []string{"x", "y"}}).WeaverMarshal(&enc)
+	enc.Int(42) // trailing data
+	dec := Decoder{data: enc.data}
+	var v1 fieldsV1
+	v1.WeaverUnmarshal(&dec)
+	if got, want := v1, (fieldsV1{A: 1}); got != want {
+		t.Fatalf("v1: got %v, want %v", got, want)
+	}
+	if got, want := dec.Int(), 42; got != want {
+		t.Fatalf("trailing data: got %d, want %d", got, want)
+	}
+
+	// New readers leave missing fields with their zero values.
+	enc = newEncoder()
+	(&fieldsV1{A: 2, B: "deprecated"}).WeaverMarshal(&enc)
+	dec = Decoder{data: enc.data}
+	v2 := fieldsV2{C: []string{"stale"}}
+	v2.WeaverUnmarshal(&dec)
+	if !dec.Empty() {
+		t.Fatalf("leftover bytes in decoder")
+	}
+	if diff := cmp.Diff(fieldsV2{A: 2}, v2); diff != "" {
+		t.Fatalf("v2 (-want,+got):\n%s", diff)
+	}
+}
+
+func TestInvalidFieldNumber(t *testing.T) {
+	enc := newEncoder()
+	err := convertCallPanicToError(func() { enc.BeginField(0) })
+	if err == nil || !strings.Contains(err.Error(), "invalid field number") {
+		t.Fatalf("BeginField(0): got %v, want invalid field number error", err)
+	}
+}
+
 // encode serializes args using the encoder enc.
 func encode(enc *Encoder, args []interface{}) {
 	for _, elem := range args {
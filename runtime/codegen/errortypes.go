@@ -0,0 +1,119 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// errorTypes holds the registered serializable error types, keyed by name.
+// If T is registered, both T and *T are included, if they implement error.
+var errorTypes = struct {
+	sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}{
+	byName: map[string]reflect.Type{},
+	byType: map[reflect.Type]string{},
+}
+
+// RegisterSerializableError registers T as a serializable error type. When an
+// error of type T or *T is encoded, its value is encoded along with its error
+// message. When the error is decoded, the value is reconstructed, and can be
+// retrieved with errors.As. Errors of unregistered types only preserve their
+// error messages.
+//
+// RegisterSerializableError is called by generated code for types that embed
+// weaver.AutoMarshal and implement the error interface.
+func RegisterSerializableError[T any, P interface {
+	*T
+	AutoMarshal
+}]() {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Name() == "" {
+		panic(fmt.Errorf("RegisterSerializableError: unnamed type %v", t))
+	}
+	name := t.PkgPath() + "." + t.Name()
+
+	errorTypes.Lock()
+	defer errorTypes.Unlock()
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	for _, x := range []struct {
+		t    reflect.Type
+		name string
+	}{{t, name}, {reflect.PointerTo(t), "*" + name}} {
+		if !x.t.Implements(errType) {
+			continue
+		}
+		errorTypes.byName[x.name] = x.t
+		errorTypes.byType[x.t] = x.name
+	}
+}
+
+// encodeErrorValue encodes the value of err, if its type is a registered
+// serializable error type.
+func encodeErrorValue(e *Encoder, err error) {
+	t := reflect.TypeOf(err)
+	errorTypes.RLock()
+	name, ok := errorTypes.byType[t]
+	errorTypes.RUnlock()
+	v := reflect.ValueOf(err)
+	if !ok || (t.Kind() == reflect.Pointer && v.IsNil()) {
+		e.String("")
+		return
+	}
+
+	// WeaverMarshal has a pointer receiver.
+	if t.Kind() != reflect.Pointer {
+		p := reflect.New(t)
+		p.Elem().Set(v)
+		v = p
+	}
+	value := NewEncoder()
+	v.Interface().(AutoMarshal).WeaverMarshal(value)
+	e.String(name)
+	e.Bytes(value.Data())
+}
+
+// decodeErrorValue decodes a value encoded by encodeErrorValue. It returns nil
+// if no value was encoded, or if the value's type is not registered.
+func decodeErrorValue(d *Decoder) error {
+	name := d.String()
+	if name == "" {
+		return nil
+	}
+	data := d.Bytes()
+	errorTypes.RLock()
+	t, ok := errorTypes.byName[name]
+	errorTypes.RUnlock()
+	if !ok {
+		// The error was encoded by a binary that registered a type that we
+		// don't know about. Fall back to preserving only the message.
+		return nil
+	}
+
+	elem := t
+	if t.Kind() == reflect.Pointer {
+		elem = t.Elem()
+	}
+	p := reflect.New(elem)
+	p.Interface().(AutoMarshal).WeaverUnmarshal(NewDecoder(data))
+	if t.Kind() == reflect.Pointer {
+		return p.Interface().(error)
+	}
+	return p.Elem().Interface().(error)
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The generated code embeds the static component graph of an application
+// (i.e. the set of components, their methods, and the weaver.Ref[T] fields
+// held by every component implementation) into the RefData field of a
+// component's Registration. Because RefData is a string literal, it ends up
+// verbatim in the compiled binary, where it can be found by scanning the
+// binary's bytes (see ExtractComponents and ExtractEdges). This lets tools
+// recover the component graph without running the application.
+//
+// The generated code similarly embeds the schema of every package's
+// components and serializable types (see EmbedSchema and ExtractSchemas), so
+// that tools can check whether two binaries are wire compatible.
+//
+// Every piece of data is wrapped in a marker of the form:
+//
+//	⟦checksum:kind:data⟧
+//
+// where checksum is the first 8 hex digits of the SHA-256 hash of
+// "kind:data". The checksum makes it very unlikely that an unrelated sequence
+// of bytes in the binary is mistaken for a marker.
+
+const (
+	componentKey = "wEaVeRcOmPoNeNt"
+	edgeKey      = "wEaVeReDgE"
+	schemaKey    = "wEaVeRsChEmA"
+)
+
+var (
+	componentRegexp = regexp.MustCompile(`⟦([0-9a-f]{8}):` + componentKey + `:([^⟦⟧→:\n]+):([^⟦⟧→:\n]*)⟧`)
+	edgeRegexp      = regexp.MustCompile(`⟦([0-9a-f]{8}):` + edgeKey + `:([^⟦⟧→:\n]+)→([^⟦⟧→:\n]+)⟧`)
+	schemaRegexp    = regexp.MustCompile(`⟦([0-9a-f]{8}):` + schemaKey + `:([^⟦⟧\n]+)⟧`)
+)
+
+// embeddedSchemas holds the strings passed to EmbedSchema. It is never read;
+// it only keeps the linker from discarding the strings.
+var embeddedSchemas []string
+
+// ComponentInfo describes a component recorded in a binary.
+type ComponentInfo struct {
+	Name    string   // full package-prefixed component name
+	Methods []string // component method names
+}
+
+// MakeComponentString returns a string that should be included in the
+// RefData of the component with the provided name and methods.
+func MakeComponentString(name string, methods []string) string {
+	return marker(componentKey, name+":"+strings.Join(methods, ","))
+}
+
+// MakeEdgeString returns a string that should be included in the RefData of
+// component src if it holds a weaver.Ref to component dst.
+func MakeEdgeString(src, dst string) string {
+	return marker(edgeKey, src+"→"+dst)
+}
+
+// ExtractComponents returns the components recorded in data by
+// MakeComponentString, in the order in which they appear.
+func ExtractComponents(data []byte) []ComponentInfo {
+	var result []ComponentInfo
+	for _, m := range componentRegexp.FindAllSubmatch(data, -1) {
+		name, methods := string(m[2]), string(m[3])
+		if !validChecksum(string(m[1]), componentKey, name+":"+methods) {
+			continue
+		}
+		info := ComponentInfo{Name: name}
+		if methods != "" {
+			info.Methods = strings.Split(methods, ",")
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// ExtractEdges returns the edges recorded in data by MakeEdgeString, in the
+// order in which they appear. Every edge is returned as a [src, dst] pair.
+func ExtractEdges(data []byte) [][2]string {
+	var result [][2]string
+	for _, m := range edgeRegexp.FindAllSubmatch(data, -1) {
+		src, dst := string(m[2]), string(m[3])
+		if !validChecksum(string(m[1]), edgeKey, src+"→"+dst) {
+			continue
+		}
+		result = append(result, [2]string{src, dst})
+	}
+	return result
+}
+
+// MakeSchemaString returns a string that should be passed to EmbedSchema to
+// embed the provided schema in the binary. The schema must not contain
+// newlines.
+func MakeSchemaString(schema string) string {
+	return marker(schemaKey, schema)
+}
+
+// EmbedSchema embeds a string returned by MakeSchemaString in the binary. It
+// is called by the init functions in the code generated by "weaver generate".
+func EmbedSchema(s string) {
+	embeddedSchemas = append(embeddedSchemas, s)
+}
+
+// ExtractSchemas returns the schemas recorded in data by MakeSchemaString, in
+// the order in which they appear.
+func ExtractSchemas(data []byte) []string {
+	var result []string
+	for _, m := range schemaRegexp.FindAllSubmatch(data, -1) {
+		schema := string(m[2])
+		if !validChecksum(string(m[1]), schemaKey, schema) {
+			continue
+		}
+		result = append(result, schema)
+	}
+	return result
+}
+
+// marker returns a checksummed marker for the provided kind and data.
+func marker(kind, data string) string {
+	return fmt.Sprintf("⟦%s:%s:%s⟧\n", checksum(kind, data), kind, data)
+}
+
+func checksum(kind, data string) string {
+	sum := sha256.Sum256([]byte(kind + ":" + data))
+	return fmt.Sprintf("%0x", sum)[:8]
+}
+
+func validChecksum(sum, kind, data string) bool {
+	return sum == checksum(kind, data)
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// registeredTypes holds the concrete types that can be encoded in
+// interface-typed values, keyed by name. If T is registered, both T and *T
+// are included.
+var registeredTypes = struct {
+	sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}{
+	byName: map[string]reflect.Type{},
+	byType: map[reflect.Type]string{},
+}
+
+// RegisterType registers T as a concrete type that can be encoded in
+// interface-typed values. When a value of type T or *T is encoded as an
+// interface, its type name is encoded along with its value. When the value is
+// decoded, a value of the same type is reconstructed.
+//
+// RegisterType is called by generated code for every type that embeds
+// weaver.AutoMarshal. Types that implement AutoMarshal by hand can be
+// registered explicitly.
+func RegisterType[T any, P interface {
+	*T
+	AutoMarshal
+}]() {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Name() == "" {
+		panic(fmt.Errorf("RegisterType: unnamed type %v", t))
+	}
+	name := t.PkgPath() + "." + t.Name()
+
+	registeredTypes.Lock()
+	defer registeredTypes.Unlock()
+	registeredTypes.byName[name] = t
+	registeredTypes.byType[t] = name
+	registeredTypes.byName["*"+name] = reflect.PointerTo(t)
+	registeredTypes.byType[reflect.PointerTo(t)] = "*" + name
+}
+
+// EncodeInterface encodes an interface-typed value. The concrete type of the
+// value must have been registered with RegisterType. A nil value, or a nil
+// pointer, is encoded as a nil interface.
+func (e *Encoder) EncodeInterface(value any) {
+	if value == nil {
+		e.String("")
+		return
+	}
+	t := reflect.TypeOf(value)
+	v := reflect.ValueOf(value)
+	if t.Kind() == reflect.Pointer && v.IsNil() {
+		e.String("")
+		return
+	}
+	registeredTypes.RLock()
+	name, ok := registeredTypes.byType[t]
+	registeredTypes.RUnlock()
+	if !ok {
+		panic(makeEncodeError("unregistered type %v; consider embedding weaver.AutoMarshal", t))
+	}
+
+	// WeaverMarshal has a pointer receiver.
+	if t.Kind() != reflect.Pointer {
+		p := reflect.New(t)
+		p.Elem().Set(v)
+		v = p
+	}
+	data := NewEncoder()
+	v.Interface().(AutoMarshal).WeaverMarshal(data)
+	e.String(name)
+	e.Bytes(data.Data())
+}
+
+// DecodeInterface decodes a value of interface type T encoded by
+// EncodeInterface.
+func DecodeInterface[T any](d *Decoder) T {
+	var zero T
+	name := d.String()
+	if name == "" {
+		return zero
+	}
+	data := d.Bytes()
+	registeredTypes.RLock()
+	t, ok := registeredTypes.byName[name]
+	registeredTypes.RUnlock()
+	if !ok {
+		panic(makeDecodeError("unregistered type %q", name))
+	}
+
+	elem := t
+	if t.Kind() == reflect.Pointer {
+		elem = t.Elem()
+	}
+	p := reflect.New(elem)
+	p.Interface().(AutoMarshal).WeaverUnmarshal(d.sub(data))
+	var value any = p.Interface()
+	if t.Kind() != reflect.Pointer {
+		value = p.Elem().Interface()
+	}
+	result, ok := value.(T)
+	if !ok {
+		panic(makeDecodeError("type %q does not implement %v", name, reflect.TypeOf(&zero).Elem()))
+	}
+	return result
+}
@@ -52,16 +52,25 @@ type registry struct {
 
 // Registration is the configuration needed to register a Service Weaver component.
 type Registration struct {
-	Name     string             // full package-prefixed component name
-	Iface    reflect.Type       // interface type for the component
-	Impl     reflect.Type       // implementation type (struct)
-	ConfigFn func(impl any) any // returns pointer to config field in local impl if non-nil
-	Routed   bool               // True if calls to this component should be routed
-
-	// Functions that return different types of stubs.
-	LocalStubFn  func(impl any, tracer trace.Tracer) any
-	ClientStubFn func(stub Stub, caller string) any
-	ServerStubFn func(impl any, load func(key uint64, load float64)) Server
+	Name      string             // full package-prefixed component name
+	Iface     reflect.Type       // interface type for the component
+	Impl      reflect.Type       // implementation type (struct)
+	ConfigFn  func(impl any) any // returns pointer to config field in local impl if non-nil
+	Routed    bool               // True if calls to this component should be routed
+	Retriable []int              // indices of methods that are safe to retry
+	Streams   []int              // indices of streaming methods
+
+	// RefData holds the component and the weaver.Ref[T] edges of its
+	// implementation, encoded with MakeComponentString and MakeEdgeString.
+	// It is embedded in the binary so that tools can extract the component
+	// graph without running the application.
+	RefData string
+
+	// Functions that return different types of stubs. The returned stubs
+	// run every method call through interceptor, if it is not nil.
+	LocalStubFn  func(impl any, tracer trace.Tracer, interceptor Interceptor) any
+	ClientStubFn func(stub Stub, caller string, interceptor Interceptor) any
+	ServerStubFn func(impl any, load func(key uint64, load float64), interceptor Interceptor) Server
 }
 
 // register registers a Service Weaver component. If the registry's close method was
@@ -111,6 +120,16 @@ func verifyRegistration(reg Registration) error {
 	if reg.ServerStubFn == nil {
 		return errors.New("nil ServerStubFn")
 	}
+	for _, i := range reg.Retriable {
+		if i < 0 || i >= reg.Iface.NumMethod() {
+			return fmt.Errorf("retriable method index %d out of range", i)
+		}
+	}
+	for _, i := range reg.Streams {
+		if i < 0 || i >= reg.Iface.NumMethod() {
+			return fmt.Errorf("streaming method index %d out of range", i)
+		}
+	}
 	return nil
 }
 
@@ -123,9 +123,9 @@ func register[Intf, Impl any](name string, configFn func(any) any) {
 		Iface:        reflect.TypeOf((*Intf)(nil)).Elem(),
 		Impl:         reflect.TypeOf(zero),
 		ConfigFn:     configFn,
-		LocalStubFn:  func(any, trace.Tracer) any { return nil },
-		ClientStubFn: func(codegen.Stub, string) any { return nil },
-		ServerStubFn: func(any, func(uint64, float64)) codegen.Server { return nil },
+		LocalStubFn:  func(any, trace.Tracer, codegen.Interceptor) any { return nil },
+		ClientStubFn: func(codegen.Stub, string, codegen.Interceptor) any { return nil },
+		ServerStubFn: func(any, func(uint64, float64), codegen.Interceptor) codegen.Server { return nil },
 	})
 }
 
@@ -0,0 +1,113 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"io"
+	"sync"
+)
+
+// SendStream encodes the values returned by next and sends them using send,
+// until next returns io.EOF. It returns a non-nil streamErr if next returns
+// any other error, and a non-nil err if a value couldn't be sent.
+func SendStream[T any](send func([]byte) error, next func() (T, error), encode func(*Encoder, T)) (streamErr, err error) {
+	for {
+		v, err := next()
+		if err == io.EOF {
+			return nil, nil
+		} else if err != nil {
+			return err, nil
+		}
+		enc := NewEncoder()
+		encode(enc, v)
+		if err := send(enc.Data()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// SendArgs encodes the values returned by next and sends them on stream in a
+// separate goroutine. Once next returns io.EOF, SendArgs informs the remote
+// method that no more values will be sent. If next returns any other error,
+// the call is canceled. done is called once no more values will be sent. The
+// returned function returns the error returned by next, if any, and can be
+// used to explain why the call was canceled.
+func SendArgs[T any](stream ClientStream, next func() (T, error), done func(), encode func(*Encoder, T)) func() error {
+	var mu sync.Mutex
+	var streamErr error
+	go func() {
+		defer done()
+		serr, err := SendStream(stream.Send, next, encode)
+		if serr != nil {
+			mu.Lock()
+			streamErr = serr
+			mu.Unlock()
+			stream.Close()
+			return
+		}
+		if err == nil {
+			// If err != nil, the call failed, and the failure is reported
+			// by the stream.
+			stream.CloseSend()
+		}
+	}()
+	return func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return streamErr
+	}
+}
+
+// RecvStream returns a function that receives a value using recv and decodes
+// it using decode. The returned function returns any error returned by recv,
+// including io.EOF.
+func RecvStream[T any](recv func() ([]byte, error), decode func(*Decoder) T) func() (T, error) {
+	return func() (v T, err error) {
+		// Catch and return any panics detected during decoding.
+		defer func() {
+			if err == nil {
+				err = CatchPanics(recover())
+			}
+		}()
+
+		data, err := recv()
+		if err != nil {
+			return v, err
+		}
+		return decode(NewDecoder(data)), nil
+	}
+}
+
+// RecvResults returns a function that receives the values sent by the remote
+// method of a streaming call. Once the remote method has sent all values, the
+// returned function returns the error serialized in the results of the call,
+// or io.EOF if there is no such error.
+func RecvResults(stream ClientStream) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		data, err := stream.Recv()
+		if err != io.EOF {
+			return data, err
+		}
+		results, err := stream.Result()
+		if err != nil {
+			return nil, err
+		}
+		dec := NewDecoder(results)
+		if err := dec.Error(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+}
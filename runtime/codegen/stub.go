@@ -35,6 +35,44 @@ type Stub interface {
 	// serialized arguments and results, respectively. shardKey is the shard
 	// key for routed components, and 0 otherwise.
 	Run(ctx context.Context, method int, args []byte, shardKey uint64) (results []byte, err error)
+
+	// Stream starts a streaming call of the provided method with the provided
+	// serialized arguments. See Run for a description of method and shardKey.
+	// The returned stream must be closed once the caller is done with it.
+	Stream(ctx context.Context, method int, args []byte, shardKey uint64) (ClientStream, error)
+}
+
+// A ClientStream is the client side of a streaming call. Serialized values can
+// be sent to and received from the remote method while the call is in
+// progress.
+type ClientStream interface {
+	// Send sends a serialized value to the remote method.
+	Send(data []byte) error
+
+	// CloseSend informs the remote method that no more values will be sent.
+	CloseSend() error
+
+	// Recv returns the next serialized value sent by the remote method. It
+	// returns io.EOF once the call has finished and all values have been
+	// received.
+	Recv() ([]byte, error)
+
+	// Result waits for the call to finish and returns its serialized results.
+	Result() ([]byte, error)
+
+	// Close ends the call, canceling it if it is still in progress.
+	Close()
+}
+
+// A ServerStream is the server side of a streaming call. It is the dual of a
+// ClientStream.
+type ServerStream interface {
+	// Send sends a serialized value to the caller.
+	Send(data []byte) error
+
+	// Recv returns the next serialized value sent by the caller. It returns
+	// io.EOF once the caller has no more values to send.
+	Recv() ([]byte, error)
 }
 
 // A Server allows a Service Weaver component in one process to receive and execute
@@ -49,3 +87,19 @@ type Server interface {
 	// TODO(mwhittaker): Rename GetHandler? This is returning a call.Handler.
 	GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error)
 }
+
+// A StreamServer is a Server for a component with streaming methods.
+type StreamServer interface {
+	Server
+
+	// GetStreamStubFn returns a handler function for the given streaming
+	// method. It is like GetStubFn, except that the handler also receives
+	// the server side of the call's stream.
+	GetStreamStubFn(method string) func(ctx context.Context, args []byte, stream ServerStream) ([]byte, error)
+}
+
+// An Interceptor runs a call of the named method of a component through the
+// interceptors of an application (see weaver.Interceptor). invoke performs the
+// call. Generated stubs call their Interceptor, if it is not nil, for every
+// method call.
+type Interceptor func(ctx context.Context, method string, invoke func(context.Context) error) error
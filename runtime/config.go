@@ -53,6 +53,14 @@ func ParseConfig(file string, input string, sectionValidator func(string, string
 		return nil, err
 	}
 
+	// Validate the calls and network sections.
+	if _, err := ParseCallConfigs(config.Sections); err != nil {
+		return nil, err
+	}
+	if _, err := ParseNetworkConfig(config.Sections); err != nil {
+		return nil, err
+	}
+
 	for key, val := range config.Sections {
 		if err := sectionValidator(key, val); err != nil {
 			return nil, err
@@ -62,6 +70,152 @@ func ParseConfig(file string, input string, sectionValidator func(string, string
 	return config, nil
 }
 
+// CallConfig configures the method calls made to a component. The call
+// configuration of every component is listed in the calls section of a
+// config, keyed by the full component name. For example:
+//
+//	[calls."github.com/my/project/package/Cache"]
+//	timeout = "1s"
+//	balancer = "least_loaded"
+//
+//	[calls."github.com/my/project/package/Cache".methods.Get]
+//	timeout = "100ms"
+//	hedge = true
+type CallConfig struct {
+	// Timeout is the default timeout for calls to any of the component's
+	// methods. A zero timeout means no timeout.
+	Timeout time.Duration
+
+	// Balancer is the load balancer used to pick the replica that serves a
+	// call, one of RoundRobinBalancer and LeastLoadedBalancer. Defaults to
+	// RoundRobinBalancer. Calls to routed methods follow the routing
+	// assignment instead.
+	Balancer string
+
+	// Methods holds method-specific overrides, keyed by method name.
+	Methods map[string]MethodCallConfig
+}
+
+// Names of the load balancers that can be configured in a CallConfig.
+const (
+	// RoundRobinBalancer picks replicas in round-robin order.
+	RoundRobinBalancer = "round_robin"
+
+	// LeastLoadedBalancer picks the replica with fewer in-flight calls out
+	// of two random replicas.
+	LeastLoadedBalancer = "least_loaded"
+)
+
+// MethodCallConfig configures the calls made to a single component method.
+type MethodCallConfig struct {
+	// Timeout is the timeout for calls to the method. If zero, the
+	// component's default timeout is used.
+	Timeout time.Duration
+
+	// Hedge enables hedged calls to the method: if a call hasn't returned
+	// within the recent p95 latency of the method, a duplicate call is sent
+	// to a different replica, and the first successful result is used.
+	// Only retriable methods can be hedged.
+	Hedge bool
+}
+
+// MethodTimeout returns the timeout for calls to the provided method, or
+// zero if calls to the method have no timeout.
+func (c *CallConfig) MethodTimeout(method string) time.Duration {
+	if c == nil {
+		return 0
+	}
+	if m, ok := c.Methods[method]; ok && m.Timeout > 0 {
+		return m.Timeout
+	}
+	return c.Timeout
+}
+
+// MethodHedged returns whether calls to the provided method are hedged.
+func (c *CallConfig) MethodHedged(method string) bool {
+	if c == nil {
+		return false
+	}
+	return c.Methods[method].Hedge
+}
+
+// ParseCallConfigs parses the calls section of the provided config sections.
+// It returns the call configuration of every listed component, keyed by
+// component name.
+func ParseCallConfigs(sections map[string]string) (map[string]*CallConfig, error) {
+	const callsKey = "github.com/ServiceWeaver/weaver/calls"
+	const shortCallsKey = "calls"
+
+	configs := map[string]*CallConfig{}
+	if err := ParseConfigSection(callsKey, shortCallsKey, sections, &configs); err != nil {
+		return nil, err
+	}
+	for component, config := range configs {
+		if config.Timeout < 0 {
+			return nil, fmt.Errorf("calls to %q: negative timeout %v", component, config.Timeout)
+		}
+		switch config.Balancer {
+		case "", RoundRobinBalancer, LeastLoadedBalancer:
+		default:
+			return nil, fmt.Errorf("calls to %q: unknown balancer %q; want %q or %q", component, config.Balancer, RoundRobinBalancer, LeastLoadedBalancer)
+		}
+		for method, m := range config.Methods {
+			if m.Timeout < 0 {
+				return nil, fmt.Errorf("calls to %q method %q: negative timeout %v", component, method, m.Timeout)
+			}
+		}
+	}
+	return configs, nil
+}
+
+// NetworkConfig configures the network connections between the weavelets of
+// an application. It is listed in the network section of a config. For
+// example:
+//
+//	[network]
+//	health_check_interval = "500ms"
+//	compress_threshold = 16384
+type NetworkConfig struct {
+	// HealthCheckInterval is how often a weavelet pings the replicas it is
+	// connected to, to eject unresponsive replicas from load balancing.
+	// Defaults to DefaultHealthCheckInterval.
+	HealthCheckInterval time.Duration `toml:"health_check_interval"`
+
+	// CompressThreshold is the size, in bytes, above which the payloads of
+	// calls between weavelets are compressed. Smaller payloads are not worth
+	// the CPU cost of compression. Defaults to DefaultCompressThreshold. A
+	// negative threshold disables compression.
+	CompressThreshold int `toml:"compress_threshold"`
+}
+
+// Defaults of the NetworkConfig fields.
+const (
+	DefaultHealthCheckInterval = time.Second
+	DefaultCompressThreshold   = 64 << 10
+)
+
+// ParseNetworkConfig parses the network section of the provided config
+// sections. Unset fields are replaced with their defaults.
+func ParseNetworkConfig(sections map[string]string) (*NetworkConfig, error) {
+	const networkKey = "github.com/ServiceWeaver/weaver/network"
+	const shortNetworkKey = "network"
+
+	config := &NetworkConfig{}
+	if err := ParseConfigSection(networkKey, shortNetworkKey, sections, config); err != nil {
+		return nil, err
+	}
+	if config.HealthCheckInterval < 0 {
+		return nil, fmt.Errorf("network: negative health check interval %v", config.HealthCheckInterval)
+	}
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+	if config.CompressThreshold == 0 {
+		config.CompressThreshold = DefaultCompressThreshold
+	}
+	return config, nil
+}
+
 // ParseConfigSection parses the config section for key into dst.
 // If shortKey is not empty, either key or shortKey is accepted.
 // If the named section is not found, returns nil without changing dst.
@@ -19,6 +19,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/runtime"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
@@ -152,6 +153,22 @@ rollout = "hello"
 `,
 			expectedError: "invalid duration",
 		},
+		{
+			name: "negative call timeout",
+			cfg: `
+[calls."github.com/foo/Cache"]
+timeout = "-1s"
+`,
+			expectedError: "negative timeout",
+		},
+		{
+			name: "unknown call config key",
+			cfg: `
+[calls."github.com/foo/Cache"]
+deadline = "1s"
+`,
+			expectedError: "unknown",
+		},
 	} {
 		t.Run(c.name, func(t *testing.T) {
 			_, err := runtime.ParseConfig("weaver.toml", c.cfg, codegen.ComponentConfigValidator)
@@ -164,3 +181,111 @@ rollout = "hello"
 		})
 	}
 }
+
+func TestParseCallConfigs(t *testing.T) {
+	const config = `
+[calls."github.com/foo/Cache"]
+timeout = "1s"
+balancer = "least_loaded"
+
+[calls."github.com/foo/Cache".methods.Get]
+timeout = "100ms"
+hedge = true
+
+[calls."github.com/foo/Store".methods.Put]
+timeout = "2s"
+`
+	app, err := runtime.ParseConfig("weaver.toml", config, codegen.ComponentConfigValidator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configs, err := runtime.ParseCallConfigs(app.Sections)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		component, method string
+		want              time.Duration
+	}{
+		{"github.com/foo/Cache", "Get", 100 * time.Millisecond},
+		{"github.com/foo/Cache", "Put", time.Second},
+		{"github.com/foo/Store", "Put", 2 * time.Second},
+		{"github.com/foo/Store", "Get", 0},
+		{"github.com/foo/Other", "Get", 0},
+	} {
+		got := configs[test.component].MethodTimeout(test.method)
+		if got != test.want {
+			t.Errorf("%s.%s timeout: got %v, want %v", test.component, test.method, got, test.want)
+		}
+	}
+	if got, want := configs["github.com/foo/Cache"].Balancer, runtime.LeastLoadedBalancer; got != want {
+		t.Errorf("balancer: got %q, want %q", got, want)
+	}
+	if !configs["github.com/foo/Cache"].MethodHedged("Get") {
+		t.Error("Cache.Get not hedged")
+	}
+	if configs["github.com/foo/Cache"].MethodHedged("Put") {
+		t.Error("Cache.Put hedged")
+	}
+}
+
+func TestParseCallConfigsBadBalancer(t *testing.T) {
+	const config = `
+[calls."github.com/foo/Cache"]
+balancer = "random"
+`
+	_, err := runtime.ParseConfig("weaver.toml", config, codegen.ComponentConfigValidator)
+	if err == nil || !strings.Contains(err.Error(), "unknown balancer") {
+		t.Fatalf("got error %v, want unknown balancer error", err)
+	}
+}
+
+func TestParseNetworkConfig(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		config string
+		want   runtime.NetworkConfig
+	}{
+		{
+			"Default",
+			"",
+			runtime.NetworkConfig{
+				HealthCheckInterval: runtime.DefaultHealthCheckInterval,
+				CompressThreshold:   runtime.DefaultCompressThreshold,
+			},
+		},
+		{
+			"Set",
+			"[network]\nhealth_check_interval = \"250ms\"\ncompress_threshold = -1\n",
+			runtime.NetworkConfig{
+				HealthCheckInterval: 250 * time.Millisecond,
+				CompressThreshold:   -1,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			app, err := runtime.ParseConfig("weaver.toml", test.config, codegen.ComponentConfigValidator)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, err := runtime.ParseNetworkConfig(app.Sections)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, *config); diff != "" {
+				t.Fatalf("network config (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseNetworkConfigNegativeInterval(t *testing.T) {
+	const config = `
+[network]
+health_check_interval = "-1s"
+`
+	_, err := runtime.ParseConfig("weaver.toml", config, codegen.ComponentConfigValidator)
+	if err == nil || !strings.Contains(err.Error(), "negative health check interval") {
+		t.Fatalf("got error %v, want negative health check interval error", err)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/envelope/conn"
 	"github.com/ServiceWeaver/weaver/internal/pipe"
@@ -222,6 +223,16 @@ func (e *Envelope) Serve(h EnvelopeHandler) error {
 	return stopErr
 }
 
+// ExitCode returns the exit code of the weavelet process, or -1 if the
+// process hasn't exited or was terminated by a signal. The exit code is only
+// available after [Serve] returns.
+func (e *Envelope) ExitCode() int {
+	if e.cmd.ProcessState == nil {
+		return -1
+	}
+	return e.cmd.ProcessState.ExitCode()
+}
+
 // toggleProfiling compares the value of e.profiling to the given expected
 // value, and if they are the same, toggles the value of e.profiling and
 // returns true; otherwise, it leaves the value of e.profiling unchanged
@@ -281,6 +292,39 @@ func (e *Envelope) UpdateRoutingInfo(routing *protos.RoutingInfo) error {
 	return e.conn.UpdateRoutingInfoRPC(routing)
 }
 
+// Shutdown gracefully shuts down the weavelet. The weavelet stops accepting
+// new method calls, waits for the method calls in progress to finish, calls
+// the Shutdown method of every component it hosts, and flushes its logs and
+// traces. The deadline of the provided context, if any, bounds how long the
+// weavelet has to do so.
+//
+// Once the weavelet has shut down, or the provided context is done, the
+// weavelet process is stopped, as if the context passed to [NewEnvelope] was
+// canceled.
+func (e *Envelope) Shutdown(ctx context.Context) error {
+	defer e.ctxCancel()
+
+	var grace time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		grace = time.Until(deadline)
+		if grace <= 0 {
+			return ctx.Err()
+		}
+	}
+
+	// ShutdownRPC doesn't take a context, so we issue it in a separate
+	// goroutine. If ctx is done first, canceling e.ctx breaks the connection
+	// and unblocks the RPC.
+	errs := make(chan error, 1)
+	go func() { errs <- e.conn.ShutdownRPC(grace) }()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (e *Envelope) logLines(component string, src io.Reader, h EnvelopeHandler) error {
 	// Fill partial log entry.
 	entry := &protos.LogEntry{
@@ -40,6 +40,7 @@ import (
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/sdk/trace"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/exp/slices"
 )
 
 // The result of running os.Executable(). Populated by TestMain.
@@ -68,8 +69,9 @@ func TestMain(m *testing.M) {
 				// Default behavior of blocking forever.
 				return nil
 			},
-			"fail":       func() error { os.Exit(1); return nil },
-			"check_file": checkFile,
+			"fail":          func() error { os.Exit(1); return nil },
+			"hang_shutdown": func() error { return nil },
+			"check_file":    checkFile,
 			"bigprint": func() error {
 				n, err := strconv.Atoi(flag.Arg(1))
 				if err != nil {
@@ -284,6 +286,20 @@ func TestBigPrints(t *testing.T) {
 	}
 }
 
+func TestExitCode(t *testing.T) {
+	wlet, config := wlet(executable, "fail")
+	e, err := NewEnvelope(context.Background(), wlet, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Serve(&handlerForTest{logSaver: testSaver(t)}); err == nil {
+		t.Fatal("weavelet didn't fail")
+	}
+	if got, want := e.ExitCode(), 1; got != want {
+		t.Fatalf("ExitCode: got %d, want %d", got, want)
+	}
+}
+
 // TestCancel test that a weavelet process is stopped when the passed-in
 // context is canceled.
 func TestCancel(t *testing.T) {
@@ -328,7 +344,90 @@ func createWeaveletConn() (*conn.WeaveletConn, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable make weavelet<->envelope pipes: %w", err)
 	}
-	return conn.NewWeaveletConn(toWeavelet, toEnvelope, nil /*handler*/)
+	return conn.NewWeaveletConn(toWeavelet, toEnvelope, weaveletHandlerForTest{})
+}
+
+// weaveletHandlerForTest is the WeaveletHandler of the subprocesses.
+type weaveletHandlerForTest struct{}
+
+var _ conn.WeaveletHandler = weaveletHandlerForTest{}
+
+func (weaveletHandlerForTest) GetLoad(*protos.GetLoadRequest) (*protos.GetLoadReply, error) {
+	panic("unused")
+}
+
+func (weaveletHandlerForTest) UpdateComponents(*protos.UpdateComponentsRequest) (*protos.UpdateComponentsReply, error) {
+	panic("unused")
+}
+
+func (weaveletHandlerForTest) UpdateRoutingInfo(*protos.UpdateRoutingInfoRequest) (*protos.UpdateRoutingInfoReply, error) {
+	panic("unused")
+}
+
+func (weaveletHandlerForTest) Shutdown(*protos.ShutdownRequest) (*protos.ShutdownReply, error) {
+	if flag.Arg(0) == "hang_shutdown" {
+		select {}
+	}
+	fmt.Println("shutdown")
+	return &protos.ShutdownReply{}, nil
+}
+
+func TestShutdown(t *testing.T) {
+	ctx := context.Background()
+	wlet, config := wlet(executable, "loop")
+	e, err := NewEnvelope(ctx, wlet, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m sync.Mutex
+	var msgs []string
+	h := &handlerForTest{logSaver: func(entry *protos.LogEntry) {
+		m.Lock()
+		defer m.Unlock()
+		msgs = append(msgs, entry.Msg)
+	}}
+	done := make(chan error)
+	go func() { done <- e.Serve(h) }()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("weavelet failed: %v", err)
+	}
+
+	// The weavelet printed "shutdown" before replying, so the message was
+	// written to stdout before the weavelet was stopped.
+	m.Lock()
+	defer m.Unlock()
+	if !slices.Contains(msgs, "shutdown") {
+		t.Fatalf("weavelet didn't shut down; got logs %q", msgs)
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	ctx := context.Background()
+	wlet, config := wlet(executable, "hang_shutdown")
+	e, err := NewEnvelope(ctx, wlet, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() { done <- e.Serve(&handlerForTest{logSaver: testSaver(t)}) }()
+
+	// The weavelet never finishes shutting down, so it should be stopped
+	// once the deadline expires.
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := e.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown: got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("weavelet failed: %v", err)
+	}
 }
 
 func writeTraces(conn *conn.WeaveletConn) error {
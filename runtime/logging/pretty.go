@@ -212,15 +212,21 @@ func Shorten(s string) string {
 
 // ShortenComponent shortens the given component name to be of the format
 // <pkg>.<IfaceType>. (Recall that the full component name is of the format
-// <path1>/<path2>/.../<pathN>/<IfaceType>.)
+// <path1>/<path2>/.../<pathN>/<IfaceType>.) The type arguments of a component
+// whose interface is an instantiation of a generic interface (e.g.,
+// <path1>/.../<pathN>/<IfaceType>[<args>]) are preserved.
 func ShortenComponent(component string) string {
-	parts := strings.Split(component, "/")
+	name, args, generic := strings.Cut(component, "[")
+	if generic {
+		args = "[" + args
+	}
+	parts := strings.Split(name, "/")
 	switch len(parts) {
 	case 0: // should never happen
 		return "nil"
 	case 1:
-		return parts[0]
+		return parts[0] + args
 	default:
-		return fmt.Sprintf("%s.%s", parts[len(parts)-2], parts[len(parts)-1])
+		return fmt.Sprintf("%s.%s%s", parts[len(parts)-2], parts[len(parts)-1], args)
 	}
 }
@@ -351,6 +351,25 @@ func (mm *MetricMap[L]) Get(labels L) *Metric {
 	return metric
 }
 
+// Delete unregisters the metric with the provided labels, if it exists. The
+// metric is no longer included in snapshots, and a later call to Get with the
+// same labels returns a new metric.
+func (mm *MetricMap[L]) Delete(labels L) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	metric, ok := mm.metrics[labels]
+	if !ok {
+		return
+	}
+	delete(mm.metrics, labels)
+
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	if i := slices.Index(metrics, metric); i >= 0 {
+		metrics = slices.Delete(metrics, i, i+1)
+	}
+}
+
 // Snapshot returns a snapshot of all currently registered metrics. The
 // snapshot is not guaranteed to be atomic.
 func Snapshot() []*MetricSnapshot {
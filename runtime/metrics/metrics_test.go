@@ -110,6 +110,32 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestDelete(t *testing.T) {
+	clear()
+	type dog struct {
+		Name, Breed string
+	}
+	counter := RegisterMap[dog](counterType, "TestDelete/counter", "", nil)
+	fido, bolt := dog{"fido", "doodle"}, dog{"bolt", "corgi"}
+	counter.Get(fido).Add(1)
+	counter.Get(bolt).Add(2)
+	counter.Delete(fido)
+
+	// Only bolt's counter is snapshotted.
+	var names []string
+	for _, snap := range Snapshot() {
+		names = append(names, snap.Labels["name"])
+	}
+	if diff := cmp.Diff([]string{"bolt"}, names); diff != "" {
+		t.Fatalf("snapshotted counters (-want +got):\n%s", diff)
+	}
+
+	// Getting a deleted counter returns a new counter.
+	if got := counter.Get(fido).Snapshot().Value; got != 0 {
+		t.Fatalf("recreated counter: got %f, want 0", got)
+	}
+}
+
 func TestSnapshot(t *testing.T) {
 	clear()
 
@@ -0,0 +1,147 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"golang.org/x/exp/slices"
+)
+
+// errDraining is returned for method calls that arrive at a weavelet that is
+// shutting down. It wraps call.Unreachable, so that callers know the call
+// never reached the component and can safely retry it on another replica.
+var errDraining = fmt.Errorf("%w: weavelet is shutting down", call.Unreachable)
+
+// inflight tracks the method calls in progress on a weavelet, so that the
+// weavelet can wait for them to finish before shutting down. The zero value
+// is ready to use.
+type inflight struct {
+	mu       sync.Mutex    // guards the following fields
+	n        int           // number of calls in progress
+	draining bool          // stop admitting new calls?
+	drained  chan struct{} // closed when draining and n reaches zero
+}
+
+// start records the start of a method call. It returns false if the weavelet
+// is draining, in which case the call should be rejected. Every successful
+// call to start must be paired with a call to end.
+func (f *inflight) start() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.draining {
+		return false
+	}
+	f.n++
+	return true
+}
+
+// end records the end of a method call.
+func (f *inflight) end() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n--
+	if f.draining && f.n == 0 {
+		close(f.drained)
+	}
+}
+
+// isDraining returns whether drain has been called.
+func (f *inflight) isDraining() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.draining
+}
+
+// drain stops admitting new method calls and blocks until the calls in
+// progress finish or until ctx is done.
+func (f *inflight) drain(ctx context.Context) error {
+	f.mu.Lock()
+	if !f.draining {
+		f.draining = true
+		f.drained = make(chan struct{})
+		if f.n == 0 {
+			close(f.drained)
+		}
+	}
+	drained := f.drained
+	f.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown implements the WeaveletHandler interface.
+func (w *weavelet) Shutdown(req *protos.ShutdownRequest) (*protos.ShutdownReply, error) {
+	ctx := w.ctx
+	if req.GracePeriodNs > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(req.GracePeriodNs))
+		defer cancel()
+	}
+	if err := w.shutdown(ctx); err != nil {
+		return nil, err
+	}
+	return &protos.ShutdownReply{}, nil
+}
+
+// shutdown gracefully shuts down the weavelet. It stops accepting new method
+// calls, waits for the calls in progress to finish, calls the Shutdown method
+// of the local components in the reverse order of their creation, and
+// flushes traces. Log entries are written synchronously, so they don't need
+// to be flushed.
+//
+// Every step is attempted, even if ctx is done or an earlier step failed.
+func (w *weavelet) shutdown(ctx context.Context) error {
+	w.env.SystemLogger().Debug("Shutting down weavelet")
+	var errs []error
+	if err := w.calls.drain(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("drain method calls: %w", err))
+	}
+
+	// A component is created after the components it depends on, so
+	// shutting down components in the reverse order of their creation
+	// ensures that a component can still use its dependencies in its
+	// Shutdown method.
+	w.mu.Lock()
+	created := slices.Clone(w.created)
+	w.mu.Unlock()
+	for i := len(created) - 1; i >= 0; i-- {
+		c := created[i]
+		s, ok := c.impl.impl.(interface{ Shutdown(context.Context) error })
+		if !ok {
+			continue
+		}
+		w.env.SystemLogger().Debug("Shutting down component", "component", c.info.Name)
+		if err := s.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("component %q shutdown failed: %w", c.info.Name, err))
+		}
+	}
+
+	if err := w.tracerProvider.ForceFlush(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("flush traces: %w", err))
+	}
+	return errors.Join(errs...)
+}
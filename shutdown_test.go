@@ -0,0 +1,76 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDrainIdle(t *testing.T) {
+	var f inflight
+	if err := f.drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if f.start() {
+		t.Fatal("start succeeded after drain")
+	}
+}
+
+func TestDrainWaitsForCalls(t *testing.T) {
+	var f inflight
+	for i := 0; i < 2; i++ {
+		if !f.start() {
+			t.Fatal("start failed before drain")
+		}
+	}
+
+	drained := make(chan error, 1)
+	go func() { drained <- f.drain(context.Background()) }()
+
+	// Wait for the drain to start, after which no new calls are admitted.
+	for !f.isDraining() {
+		time.Sleep(time.Millisecond)
+	}
+	if f.start() {
+		t.Fatal("start succeeded while draining")
+	}
+
+	f.end()
+	select {
+	case err := <-drained:
+		t.Fatalf("drain returned %v with a call in progress", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	f.end()
+	if err := <-drained; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDrainDeadline(t *testing.T) {
+	var f inflight
+	if !f.start() {
+		t.Fatal("start failed before drain")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := f.drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("drain: got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"io"
+	"sync"
+)
+
+// A Stream is a sequence of values of type T that is produced incrementally.
+// Component methods can accept a stream as their last argument and return a
+// stream as their only non-error result. For example:
+//
+//	type Exporter interface {
+//	    Export(ctx context.Context, since time.Time) (*weaver.Stream[Entry], error)
+//	    Import(ctx context.Context, entries *weaver.Stream[Entry]) (int, error)
+//	}
+//
+// When a component method is invoked remotely, the values of a stream are
+// sent between processes one at a time as they are produced, rather than
+// being buffered in memory all at once. Sends are flow controlled: a producer
+// blocks if the consumer falls too far behind.
+//
+// A Stream is not safe for concurrent use. A nil *Stream is an empty stream.
+type Stream[T any] struct {
+	next  func() (T, error)
+	close func()
+	once  sync.Once
+}
+
+// NewStream returns a new stream whose values are produced by next. next
+// should return io.EOF once there are no more values. A non-nil close, if
+// provided, is called when the stream is closed.
+func NewStream[T any](next func() (T, error), close func()) *Stream[T] {
+	return &Stream[T]{next: next, close: close}
+}
+
+// StreamOf returns a new stream of the provided values.
+func StreamOf[T any](values ...T) *Stream[T] {
+	return NewStream(func() (T, error) {
+		if len(values) == 0 {
+			var zero T
+			return zero, io.EOF
+		}
+		v := values[0]
+		values = values[1:]
+		return v, nil
+	}, nil)
+}
+
+// Next returns the next value in the stream. It returns io.EOF once there are
+// no more values. Any other error indicates that the stream terminated
+// abnormally; for example, because the remote producer of the stream failed.
+func (s *Stream[T]) Next() (T, error) {
+	if s == nil {
+		var zero T
+		return zero, io.EOF
+	}
+	return s.next()
+}
+
+// Close releases the resources held by the stream. The consumer of a stream
+// must close it once it is done with it, even if it didn't consume all of the
+// stream's values. Next must not be called after Close. Close can be called
+// more than once.
+func (s *Stream[T]) Close() {
+	if s == nil {
+		return
+	}
+	s.once.Do(func() {
+		if s.close != nil {
+			s.close()
+		}
+	})
+}
@@ -16,19 +16,31 @@ package weaver
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/net/call"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slices"
 )
 
 // stub holds information about a client stub to the remote component.
 type stub struct {
-	component string           // name of the remote component
-	conn      call.Connection  // connection to talk to the remote component
-	methods   []call.MethodKey // keys for the remote component methods
-	balancer  call.Balancer    // if not nil, component load balancer
-	tracer    trace.Tracer     // component tracer
+	component string            // name of the remote component
+	conn      call.Connection   // connection to talk to the remote component
+	methods   []call.MethodKey  // keys for the remote component methods
+	timeouts  []time.Duration   // if non-zero, per-method call timeouts
+	retriable []bool            // whether a method can be retried
+	hedges    []*latencyTracker // if non-nil, latencies of hedged methods
+	retries   budget            // bounds the number of retries
+	hedging   budget            // bounds the number of hedged calls
+	tracer    trace.Tracer      // component tracer
 }
 
 var _ codegen.Stub = &stub{}
@@ -40,9 +52,230 @@ func (s *stub) Tracer() trace.Tracer {
 
 // Run implements the codegen.Stub interface.
 func (s *stub) Run(ctx context.Context, method int, args []byte, shardKey uint64) ([]byte, error) {
-	opts := call.CallOptions{
-		ShardKey: shardKey,
-		Balancer: s.balancer,
+	opts := call.CallOptions{ShardKey: shardKey}
+	if method >= len(s.hedges) || s.hedges[method] == nil {
+		return s.run(ctx, method, args, opts)
 	}
-	return s.conn.Call(ctx, s.methods[method], args, opts)
+
+	// Hedge calls that take longer than the recent p95 latency, as long as
+	// the hedging budget allows it. Only the latency of the original request
+	// of a hedged call is recorded, since the latency of whichever request
+	// finishes first would bias the p95 latency downwards.
+	latencies := s.hedges[method]
+	s.hedging.deposit(hedgeRatio)
+	opts.HedgeDelay = latencies.p95()
+	opts.AllowHedge = func() bool { return s.hedging.withdraw(maxHedgeTokens) }
+	opts.Latency = latencies.record
+	return s.run(ctx, method, args, opts)
+}
+
+// run runs the provided method, timing it out if needed.
+func (s *stub) run(ctx context.Context, method int, args []byte, opts call.CallOptions) ([]byte, error) {
+	var timeout time.Duration
+	if method < len(s.timeouts) {
+		timeout = s.timeouts[method]
+	}
+	if timeout <= 0 {
+		return s.call(ctx, method, args, opts)
+	}
+
+	// The deadline is propagated to the remote component, which cancels the
+	// method's context once the deadline is exceeded.
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := s.call(callCtx, method, args, opts)
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		// The call timed out, rather than the caller's context.
+		return nil, fmt.Errorf("%w: call to %s timed out after %v: %w", CallTimeoutError, s.component, timeout, err)
+	}
+	return result, err
+}
+
+// timeoutLocalCall returns an InterceptorFunc that times out local calls of
+// the methods of the provided component, just like run times out remote calls,
+// so that timeouts don't depend on the placement of components. A local call
+// cannot be preempted, though: it returns once the method returns, typically
+// as soon as the method notices that its context expired.
+func timeoutLocalCall(c *component) InterceptorFunc {
+	return func(ctx context.Context, info CallInfo, invoke func(context.Context) error) error {
+		timeout := c.calls.MethodTimeout(info.Method)
+		if timeout <= 0 {
+			return invoke(ctx)
+		}
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		err := invoke(callCtx)
+		if ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+			// The call timed out, rather than the caller's context.
+			if err == nil {
+				err = callCtx.Err()
+			}
+			return fmt.Errorf("%w: call to %s timed out after %v: %w", CallTimeoutError, c.info.Name, timeout, err)
+		}
+		return err
+	}
+}
+
+// Stream implements the codegen.Stub interface.
+//
+// Streaming calls are neither timed out nor retried, since they may
+// legitimately run for a long time, and their values can't be replayed.
+func (s *stub) Stream(ctx context.Context, method int, args []byte, shardKey uint64) (codegen.ClientStream, error) {
+	opts := call.CallOptions{ShardKey: shardKey}
+	stream, err := s.conn.Stream(ctx, s.methods[method], args, opts)
+	if err != nil {
+		return nil, err
+	}
+	return clientStream{stream}, nil
+}
+
+// clientStream is a codegen.ClientStream that marks the errors returned by
+// an in-progress streaming call as remote call errors.
+type clientStream struct {
+	*call.ClientStream
+}
+
+// Recv implements the codegen.ClientStream interface.
+func (s clientStream) Recv() ([]byte, error) {
+	data, err := s.ClientStream.Recv()
+	if err != nil && err != io.EOF {
+		err = errors.Join(RemoteCallError, err)
+	}
+	return data, err
+}
+
+// Result implements the codegen.ClientStream interface.
+func (s clientStream) Result() ([]byte, error) {
+	results, err := s.ClientStream.Result()
+	if err != nil {
+		err = errors.Join(RemoteCallError, err)
+	}
+	return results, err
+}
+
+// call calls the provided method, retrying the call if the method is
+// retriable and the call fails with a transport error.
+func (s *stub) call(ctx context.Context, method int, args []byte, opts call.CallOptions) ([]byte, error) {
+	if method >= len(s.retriable) || !s.retriable[method] {
+		return s.conn.Call(ctx, s.methods[method], args, opts)
+	}
+
+	s.retries.deposit(retryRatio)
+	var err error
+	attempts := 0
+	for r := retry.Begin(); r.Continue(ctx); {
+		var result []byte
+		result, err = s.conn.Call(ctx, s.methods[method], args, opts)
+		if err == nil || !isTransportError(err) {
+			return result, err
+		}
+		attempts++
+		if attempts >= maxAttempts || !s.retries.withdraw(maxRetryTokens) {
+			return nil, err
+		}
+		// Retries are not hedged, so that a single call doesn't issue a
+		// duplicate request for every attempt.
+		opts.HedgeDelay = 0
+	}
+	if err == nil {
+		return nil, ctx.Err()
+	}
+	return nil, fmt.Errorf("%w: %w", ctx.Err(), err)
+}
+
+// isTransportError returns whether the provided error indicates that a call
+// failed to reach the remote component, in which case the call is safe to
+// retry if the method is retriable. Application errors are never transport
+// errors.
+func isTransportError(err error) bool {
+	return errors.Is(err, call.CommunicationError) || errors.Is(err, call.Unreachable)
+}
+
+const (
+	// maxAttempts is the maximum number of times a call is attempted.
+	maxAttempts = 5
+
+	// Every call to a retriable method earns the stub retryRatio retries, up
+	// to a total of maxRetryTokens. This ensures that retries make up at
+	// most a small fraction of calls when the remote component is down,
+	// rather than multiplying the load on it.
+	retryRatio     = 0.1
+	maxRetryTokens = 10
+
+	// Likewise, every call to a hedged method earns the stub hedgeRatio
+	// hedged calls, up to a total of maxHedgeTokens. Calls are hedged after
+	// the p95 latency, so about 5% of calls are hedged when latencies are
+	// stable. The budget prevents hedging from multiplying the load on a
+	// remote component whose latency suddenly spikes.
+	hedgeRatio     = 0.1
+	maxHedgeTokens = 10
+)
+
+// budget is a token bucket that bounds the number of retries or hedged calls
+// issued by a stub. The zero value is a full budget.
+type budget struct {
+	mu    sync.Mutex
+	spent float64 // number of tokens spent, in the range [0, max]
+}
+
+// deposit adds the provided number of tokens to the budget.
+func (b *budget) deposit(tokens float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent = math.Max(0, b.spent-tokens)
+}
+
+// withdraw withdraws a token from a budget of max tokens, returning false if
+// the budget is exhausted.
+func (b *budget) withdraw(max float64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.spent+1 > max {
+		return false
+	}
+	b.spent++
+	return true
+}
+
+const (
+	// A latencyTracker tracks the latencies of the latest latencySamples
+	// calls, and recomputes their p95 latency every p95Interval calls.
+	// Calls are not hedged until minLatencySamples calls have completed.
+	latencySamples    = 100
+	minLatencySamples = 20
+	p95Interval       = 10
+)
+
+// latencyTracker tracks the recent latencies of successful calls to a method,
+// to decide when to hedge calls to the method.
+type latencyTracker struct {
+	mu       sync.Mutex
+	samples  [latencySamples]time.Duration // circular buffer of latencies
+	n        int                           // number of recorded latencies
+	computed time.Duration                 // latest computed p95 latency
+}
+
+// record records the latency of a successful call.
+func (l *latencyTracker) record(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.samples[l.n%latencySamples] = latency
+	l.n++
+	if l.n >= minLatencySamples && l.n%p95Interval == 0 {
+		n := l.n
+		if n > latencySamples {
+			n = latencySamples
+		}
+		sorted := slices.Clone(l.samples[:n])
+		slices.Sort(sorted)
+		l.computed = sorted[len(sorted)*95/100]
+	}
+}
+
+// p95 returns the recent p95 latency, or zero if too few calls have
+// completed to estimate it.
+func (l *latencyTracker) p95() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.computed
 }
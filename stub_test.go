@@ -21,8 +21,10 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/google/go-cmp/cmp"
 )
@@ -37,6 +39,10 @@ func (c *localClient) Call(ctx context.Context, _ call.MethodKey, args []byte, _
 	return handleCall(ctx, reflect.ValueOf(c.fn), args)
 }
 
+func (c *localClient) Stream(context.Context, call.MethodKey, []byte, call.CallOptions) (*call.ClientStream, error) {
+	return nil, fmt.Errorf("streaming calls not supported")
+}
+
 func (c *localClient) Close() {}
 
 func TestCall(t *testing.T) {
@@ -306,3 +312,289 @@ func weaverDecode(d *codegen.Decoder, output interface{}) interface{} {
 		panic(fmt.Errorf("Unable to decode type %v with Service Weaver decoder\n", x))
 	}
 }
+
+// TestCallTimeout verifies that a call that exceeds its configured timeout
+// returns a CallTimeoutError, while a call whose context is canceled by the
+// caller does not.
+func TestCallTimeout(t *testing.T) {
+	fn := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	stub := stub{
+		conn:     &localClient{fn: fn},
+		methods:  []call.MethodKey{call.MakeMethodKey("", "test")},
+		timeouts: []time.Duration{10 * time.Millisecond},
+	}
+
+	_, err := stub.Run(context.Background(), 0, nil, 0)
+	if !errors.Is(err, CallTimeoutError) {
+		t.Errorf("Run: got %v, want CallTimeoutError", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run: got %v, want context.DeadlineExceeded", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	stub.timeouts[0] = time.Hour
+	_, err = stub.Run(ctx, 0, nil, 0)
+	if errors.Is(err, CallTimeoutError) {
+		t.Errorf("Run: got %v, want caller's deadline error", err)
+	}
+}
+
+// TestLocalCallTimeout verifies that local calls are timed out just like
+// remote calls.
+func TestLocalCallTimeout(t *testing.T) {
+	c := &component{
+		info: &codegen.Registration{Name: "test"},
+		calls: &runtime.CallConfig{
+			Methods: map[string]runtime.MethodCallConfig{
+				"Slow": {Timeout: 10 * time.Millisecond},
+			},
+		},
+	}
+	intercept := timeoutLocalCall(c)
+	wait := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	err := intercept(context.Background(), CallInfo{Component: "test", Method: "Slow"}, wait)
+	if !errors.Is(err, CallTimeoutError) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Slow: got %v, want CallTimeoutError", err)
+	}
+
+	// A method that ignores its context still times out.
+	ignore := func(context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+	err = intercept(context.Background(), CallInfo{Component: "test", Method: "Slow"}, ignore)
+	if !errors.Is(err, CallTimeoutError) {
+		t.Errorf("Slow ignoring context: got %v, want CallTimeoutError", err)
+	}
+
+	// Methods without a timeout are not timed out.
+	err = intercept(context.Background(), CallInfo{Component: "test", Method: "Fast"}, func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); ok {
+			return errors.New("unexpected deadline")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Fast: %v", err)
+	}
+
+	// The caller's deadline is not a timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	c.calls.Methods["Slow"] = runtime.MethodCallConfig{Timeout: time.Hour}
+	if err := intercept(ctx, CallInfo{Component: "test", Method: "Slow"}, wait); errors.Is(err, CallTimeoutError) {
+		t.Errorf("Slow: got %v, want caller's deadline error", err)
+	}
+}
+
+// flakyClient is a call.Connection whose first calls fail with a
+// communication error.
+type flakyClient struct {
+	failures int
+	calls    int
+}
+
+var _ call.Connection = &flakyClient{}
+
+func (c *flakyClient) Call(context.Context, call.MethodKey, []byte, call.CallOptions) ([]byte, error) {
+	c.calls++
+	if c.calls <= c.failures {
+		return nil, fmt.Errorf("%w: connection closed", call.CommunicationError)
+	}
+	return nil, nil
+}
+
+func (c *flakyClient) Stream(context.Context, call.MethodKey, []byte, call.CallOptions) (*call.ClientStream, error) {
+	return nil, fmt.Errorf("%w: connection closed", call.CommunicationError)
+}
+
+func (c *flakyClient) Close() {}
+
+func TestRetries(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		retriable bool
+		failures  int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"NotRetriable", false, 1, 1, true},
+		{"Retriable", true, 2, 3, false},
+		{"MaxAttempts", true, 100, maxAttempts, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			conn := &flakyClient{failures: test.failures}
+			stub := stub{
+				conn:      conn,
+				methods:   []call.MethodKey{call.MakeMethodKey("", "test")},
+				retriable: []bool{test.retriable},
+			}
+			_, err := stub.Run(context.Background(), 0, nil, 0)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("Run: got error %v, want error %t", err, test.wantErr)
+			}
+			if conn.calls != test.wantCalls {
+				t.Errorf("Run: got %d calls, want %d", conn.calls, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	// A full budget allows maxRetryTokens retries.
+	var b budget
+	for i := 0; i < maxRetryTokens; i++ {
+		if !b.withdraw(maxRetryTokens) {
+			t.Fatalf("withdraw %d: budget unexpectedly exhausted", i)
+		}
+	}
+	if b.withdraw(maxRetryTokens) {
+		t.Fatal("withdraw: budget unexpectedly not exhausted")
+	}
+
+	// Every 1/retryRatio calls earn one more retry.
+	for i := 0; i < int(1/retryRatio)+1; i++ {
+		b.deposit(retryRatio)
+	}
+	if !b.withdraw(maxRetryTokens) {
+		t.Fatal("withdraw: budget unexpectedly exhausted after deposits")
+	}
+}
+
+func TestLatencyTracker(t *testing.T) {
+	var l latencyTracker
+	for i := 1; i < minLatencySamples; i++ {
+		l.record(time.Duration(i) * time.Millisecond)
+	}
+	if got := l.p95(); got != 0 {
+		t.Fatalf("p95 with %d samples: got %v, want 0", minLatencySamples-1, got)
+	}
+
+	// Record latencies 1ms, 2ms, ..., 1000ms. Only the latest latencySamples
+	// latencies count.
+	l = latencyTracker{}
+	for i := 1; i <= 1000; i++ {
+		l.record(time.Duration(i) * time.Millisecond)
+	}
+	if got, want := l.p95(), 996*time.Millisecond; got != want {
+		t.Fatalf("p95: got %v, want %v", got, want)
+	}
+}
+
+// hedgeClient is a call.Connection that records the hedge delays and the
+// latest options of calls. The first failures calls fail with a transport
+// error.
+type hedgeClient struct {
+	failures int
+	delays   []time.Duration
+	opts     call.CallOptions
+}
+
+var _ call.Connection = &hedgeClient{}
+
+func (c *hedgeClient) Call(_ context.Context, _ call.MethodKey, _ []byte, opts call.CallOptions) ([]byte, error) {
+	c.delays = append(c.delays, opts.HedgeDelay)
+	c.opts = opts
+	if len(c.delays) <= c.failures {
+		return nil, call.CommunicationError
+	}
+	if opts.Latency != nil {
+		opts.Latency(time.Millisecond)
+	}
+	return nil, nil
+}
+
+func (c *hedgeClient) Stream(context.Context, call.MethodKey, []byte, call.CallOptions) (*call.ClientStream, error) {
+	return nil, fmt.Errorf("streaming calls not supported")
+}
+
+func (c *hedgeClient) Close() {}
+
+func TestHedgedRun(t *testing.T) {
+	conn := &hedgeClient{}
+	stub := stub{
+		conn:      conn,
+		methods:   []call.MethodKey{call.MakeMethodKey("", "hedged"), call.MakeMethodKey("", "other")},
+		retriable: []bool{true, true},
+		hedges:    []*latencyTracker{{}, nil},
+	}
+	for i := 0; i <= minLatencySamples; i++ {
+		if _, err := stub.Run(context.Background(), 0, nil, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := stub.Run(context.Background(), 1, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Calls are hedged once enough latencies have been recorded. Calls to
+	// methods that are not hedged never are.
+	n := len(conn.delays)
+	if got := conn.delays[0]; got != 0 {
+		t.Errorf("first call: got hedge delay %v, want 0", got)
+	}
+	if got := conn.delays[n-2]; got <= 0 {
+		t.Errorf("call %d: got hedge delay %v, want > 0", n-2, got)
+	}
+	if got := conn.delays[n-1]; got != 0 {
+		t.Errorf("unhedged call: got hedge delay %v, want 0", got)
+	}
+}
+
+func TestHedgedRetries(t *testing.T) {
+	conn := &hedgeClient{}
+	stub := stub{
+		conn:      conn,
+		methods:   []call.MethodKey{call.MakeMethodKey("", "hedged")},
+		retriable: []bool{true},
+		hedges:    []*latencyTracker{{}},
+	}
+	for i := 0; i < minLatencySamples; i++ {
+		if _, err := stub.Run(context.Background(), 0, nil, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the first attempt of a retried call is hedged.
+	conn.delays = nil
+	conn.failures = 1
+	if _, err := stub.Run(context.Background(), 0, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(conn.delays) != 2 || conn.delays[0] <= 0 || conn.delays[1] != 0 {
+		t.Fatalf("got hedge delays %v, want [>0, 0]", conn.delays)
+	}
+}
+
+func TestHedgeBudget(t *testing.T) {
+	conn := &hedgeClient{}
+	stub := stub{
+		conn:      conn,
+		methods:   []call.MethodKey{call.MakeMethodKey("", "hedged")},
+		retriable: []bool{true},
+		hedges:    []*latencyTracker{{}},
+	}
+	if _, err := stub.Run(context.Background(), 0, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// A full budget allows maxHedgeTokens hedged calls.
+	allow := conn.opts.AllowHedge
+	for i := 0; i < maxHedgeTokens; i++ {
+		if !allow() {
+			t.Fatalf("hedge %d: budget unexpectedly exhausted", i)
+		}
+	}
+	if allow() {
+		t.Fatal("hedge: budget unexpectedly not exhausted")
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/envelope/conn"
@@ -58,9 +59,17 @@ type weavelet struct {
 	dialAddr  string               // Address this weavelet is reachable at
 	tracer    trace.Tracer         // Tracer for this weavelet
 	selfCert  *tls.Certificate     // TLS certificate for this weavelet
+	calls     inflight             // Method calls in progress
+
+	// Provider of w.tracer, flushed on shutdown.
+	tracerProvider *sdktrace.TracerProvider
 
 	componentsByName map[string]*component       // component name -> component
 	componentsByType map[reflect.Type]*component // component type -> component
+	interceptors     []Interceptor               // component method call interceptors
+
+	mu      sync.Mutex   // guards the following fields
+	created []*component // local components, in the order they were created
 }
 
 type transport struct {
@@ -82,11 +91,12 @@ type server struct {
 var _ conn.WeaveletHandler = &weavelet{}
 
 // newWeavelet returns a new weavelet.
-func newWeavelet(ctx context.Context, componentInfos []*codegen.Registration) (*weavelet, error) {
+func newWeavelet(ctx context.Context, componentInfos []*codegen.Registration, interceptors []Interceptor) (*weavelet, error) {
 	w := &weavelet{
 		ctx:              ctx,
 		componentsByName: make(map[string]*component, len(componentInfos)),
 		componentsByType: make(map[reflect.Type]*component, len(componentInfos)),
+		interceptors:     interceptors,
 	}
 
 	// TODO(mwhittaker): getEnv starts the WeaveletConn handler which calls
@@ -115,6 +125,47 @@ func newWeavelet(ctx context.Context, componentInfos []*codegen.Registration) (*
 		w.componentsByType[info.Iface] = c
 	}
 
+	// Install the fake component implementations, if any.
+	bootstrap, err := runtime.GetBootstrap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for t, fake := range bootstrap.Fakes {
+		c, ok := w.componentsByType[t]
+		if !ok {
+			return nil, fmt.Errorf("fake for unknown component type %v", t)
+		}
+		c.fake = fake
+	}
+
+	// Parse the call configurations of the components.
+	calls, err := runtime.ParseCallConfigs(info.Sections)
+	if err != nil {
+		return nil, err
+	}
+	for name, config := range calls {
+		c, ok := w.componentsByName[name]
+		if !ok {
+			return nil, fmt.Errorf("calls config for unknown component %q", name)
+		}
+		for method, m := range config.Methods {
+			mt, ok := c.info.Iface.MethodByName(method)
+			if !ok {
+				return nil, fmt.Errorf("calls config for unknown method %q of component %q", method, name)
+			}
+			if m.Hedge && !slices.Contains(c.info.Retriable, mt.Index) {
+				// A hedged call may run twice.
+				return nil, fmt.Errorf("calls config hedges method %q of component %q, which is not retriable", method, name)
+			}
+		}
+		c.calls = config
+	}
+
+	network, err := runtime.ParseNetworkConfig(info.Sections)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize client side of the mTLS protocol.
 	if (info.SelfCertChain == nil) != (info.SelfKey == nil) {
 		return nil, fmt.Errorf(
@@ -163,15 +214,23 @@ func newWeavelet(ctx context.Context, componentInfos []*codegen.Registration) (*
 		clientOpts: call.ClientOptions{
 			Logger:            env.SystemLogger(),
 			WriteFlattenLimit: 4 << 10,
+			// Share network connections across components.
+			Pool: call.NewPool(),
+			// Ping the replicas of other components to eject the
+			// unresponsive ones from load balancing.
+			HealthCheckInterval: network.HealthCheckInterval,
+			CompressThreshold:   network.CompressThreshold,
 		},
 		serverOpts: call.ServerOptions{
 			Logger:                env.SystemLogger(),
 			Tracer:                tracer,
 			InlineHandlerDuration: 20 * time.Microsecond,
 			WriteFlattenLimit:     4 << 10,
+			CompressThreshold:     network.CompressThreshold,
 		},
 	}
 	w.tracer = tracer
+	w.tracerProvider = tracerProvider
 	return w, nil
 }
 
@@ -300,21 +359,8 @@ func (w *weavelet) logRolodexCard() {
 // as well as the actual local object. Otherwise, the results are a network client
 // and nil.
 func (w *weavelet) getInstance(c *component, requester string) (any, any, error) {
-	// Register the component.
-	c.registerInit.Do(func() {
-		w.env.SystemLogger().Debug("Activating component...", "component", c.info.Name)
-		errMsg := fmt.Sprintf("cannot activate component %q", c.info.Name)
-		c.registerErr = w.repeatedly(errMsg, func() error {
-			return w.env.ActivateComponent(w.ctx, c.info.Name, c.info.Routed)
-		})
-		if c.registerErr != nil {
-			w.env.SystemLogger().Error("Activating component failed", "err", c.registerErr, "component", c.info.Name)
-		} else {
-			w.env.SystemLogger().Debug("Activating component succeeded", "component", c.info.Name)
-		}
-	})
-	if c.registerErr != nil {
-		return nil, nil, c.registerErr
+	if err := w.activate(c); err != nil {
+		return nil, nil, err
 	}
 
 	if c.local.Read() {
@@ -322,14 +368,41 @@ func (w *weavelet) getInstance(c *component, requester string) (any, any, error)
 		if err != nil {
 			return nil, nil, err
 		}
-		return c.info.LocalStubFn(impl.impl, impl.component.tracer), impl.impl, nil
+		// A local call is intercepted on both the client and the server side,
+		// and timed out in between, just like a remote call.
+		interceptors := w.interceptors
+		if c.calls != nil {
+			interceptors = append(slices.Clip(interceptors), Interceptor{Client: timeoutLocalCall(c)})
+		}
+		intercept := interceptor(c.info.Name, interceptors, true, true)
+		return c.info.LocalStubFn(impl.impl, impl.component.tracer, intercept), impl.impl, nil
 	}
 
 	stub, err := w.getStub(c)
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.info.ClientStubFn(stub, requester), nil, nil
+	intercept := interceptor(c.info.Name, w.interceptors, true, false)
+	return c.info.ClientStubFn(stub, requester, intercept), nil, nil
+}
+
+// activate activates the provided component, if it hasn't been activated
+// already. Activating a component subscribes the weavelet to the component's
+// routing info.
+func (w *weavelet) activate(c *component) error {
+	c.registerInit.Do(func() {
+		w.env.SystemLogger().Debug("Activating component...", "component", c.info.Name)
+		errMsg := fmt.Sprintf("cannot activate component %q", c.info.Name)
+		c.registerErr = w.repeatedly(errMsg, func() error {
+			return w.env.ActivateComponent(w.ctx, c.info.Name, c.info.Routed)
+		})
+		if c.registerErr != nil {
+			w.env.SystemLogger().Error("Activating component failed", "err", c.registerErr, "component", c.info.Name)
+		} else {
+			w.env.SystemLogger().Debug("Activating component succeeded", "component", c.info.Name)
+		}
+	})
+	return c.registerErr
 }
 
 // getListener returns a network listener with the given name.
@@ -371,8 +444,31 @@ func (w *weavelet) getListener(name string, opts ListenerOptions) (*Listener, er
 // that (1) creates the local component if it hasn't been created yet and (2)
 // calls m.
 func (w *weavelet) addHandlers(handlers *call.HandlerMap, c *component) {
+	streams := map[int]bool{}
+	for _, i := range c.info.Streams {
+		streams[i] = true
+	}
 	for i, n := 0, c.info.Iface.NumMethod(); i < n; i++ {
 		mname := c.info.Iface.Method(i).Name
+		if streams[i] {
+			handlers.SetStream(c.info.Name, mname, func(ctx context.Context, args []byte, stream *call.ServerStream) ([]byte, error) {
+				if !w.calls.start() {
+					return nil, errDraining
+				}
+				defer w.calls.end()
+				impl, err := w.getImpl(c)
+				if err != nil {
+					return nil, err
+				}
+				server, ok := impl.serverStub.(codegen.StreamServer)
+				if !ok {
+					return nil, fmt.Errorf("component %q has no streaming methods", c.info.Name)
+				}
+				fn := server.GetStreamStubFn(mname)
+				return fn(ctx, args, stream)
+			})
+			continue
+		}
 		handler := func(ctx context.Context, args []byte) (res []byte, err error) {
 			// This handler is supposed to invoke the method named mname on the
 			// local component. However, it is possible that the component has not
@@ -380,6 +476,10 @@ func (w *weavelet) addHandlers(handlers *call.HandlerMap, c *component) {
 			// yet taken effect). d.getImpl(c) will start the component if it
 			// hasn't already been started, or it will be a noop if the component
 			// has already been started.
+			if !w.calls.start() {
+				return nil, errDraining
+			}
+			defer w.calls.end()
 			impl, err := w.getImpl(c)
 			if err != nil {
 				return nil, err
@@ -438,6 +538,15 @@ func (w *weavelet) UpdateComponents(req *protos.UpdateComponentsRequest) (*proto
 				w.env.SystemLogger().Error("getImpl", "err", err, "component", component)
 				return
 			}
+			if c.load != nil {
+				// Load is collected with respect to the component's
+				// assignment, so we have to subscribe to its routing info.
+				if err := w.activate(c); err != nil {
+					// TODO(mwhittaker): Propagate errors.
+					w.env.SystemLogger().Error("activate", "err", err, "component", component)
+					return
+				}
+			}
 		}
 	}()
 	return &protos.UpdateComponentsReply{}, nil
@@ -460,18 +569,16 @@ func (w *weavelet) UpdateRoutingInfo(req *protos.UpdateRoutingInfoRequest) (repl
 		}
 	}()
 
-	// Update load collector.
-	for _, c := range w.componentsByName {
-		if c.load != nil && req.RoutingInfo.Assignment != nil {
-			c.load.updateAssignment(req.RoutingInfo.Assignment)
-		}
-	}
-
 	c, err := w.getComponent(req.RoutingInfo.Component)
 	if err != nil {
 		return nil, err
 	}
 
+	// Update load collector.
+	if c.load != nil && req.RoutingInfo.Assignment != nil {
+		c.load.updateAssignment(req.RoutingInfo.Assignment)
+	}
+
 	// Update resolver and balancer.
 	client := w.getClient(c)
 	endpoints, err := parseEndpoints(req.RoutingInfo.Replicas, c.clientTLS)
@@ -536,13 +643,15 @@ func (w *weavelet) getImpl(c *component) (*componentImpl, error) {
 		}
 		w.env.SystemLogger().Debug("Constructing component succeeded", "component", c.info.Name)
 
-		c.impl.serverStub = c.info.ServerStubFn(c.impl.impl, func(key uint64, v float64) {
+		addLoad := func(key uint64, v float64) {
 			if c.info.Routed {
 				if err := c.load.add(key, v); err != nil {
 					c.logger.Error("add load", "err", err, "component", c.info.Name, "key", key)
 				}
 			}
-		})
+		}
+		intercept := interceptor(c.info.Name, w.interceptors, false, true)
+		c.impl.serverStub = c.info.ServerStubFn(c.impl.impl, addLoad, intercept)
 		return nil
 	}
 	c.implInit.Do(func() { c.implErr = init(c) })
@@ -550,6 +659,13 @@ func (w *weavelet) getImpl(c *component) (*componentImpl, error) {
 }
 
 func (w *weavelet) createComponent(ctx context.Context, c *component) error {
+	if c.fake != nil {
+		// Use the fake implementation. Fakes are constructed by the caller,
+		// so they don't have their refs filled or their Init method called.
+		c.impl.impl = c.fake
+		return nil
+	}
+
 	// Create the implementation object.
 	obj := reflect.New(c.info.Impl).Interface()
 
@@ -588,6 +704,10 @@ func (w *weavelet) createComponent(ctx context.Context, c *component) error {
 		}
 	}
 	c.impl.impl = obj
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.created = append(w.created, c)
 	return nil
 }
 
@@ -605,9 +725,14 @@ func (w *weavelet) repeatedly(errMsg string, f func() error) error {
 // getClient returns a component's network client, initializing it if necessary.
 func (w *weavelet) getClient(c *component) *client {
 	c.clientInit.Do(func() {
+		// Pick the balancer configured for the component.
+		var balancer call.Balancer = call.RoundRobin()
+		if c.calls != nil && c.calls.Balancer == runtime.LeastLoadedBalancer {
+			balancer = call.LeastLoaded()
+		}
 		c.client = &client{
 			resolver: newRoutingResolver(),
-			balancer: newRoutingBalancer(c.clientTLS),
+			balancer: newRoutingBalancer(c.info.Name, balancer, c.clientTLS),
 		}
 	})
 	return c.client
@@ -620,8 +745,10 @@ func (w *weavelet) getStub(c *component) (*stub, error) {
 		w.env.SystemLogger().Debug("Creating a connection to a remote component...", "component", c.info.Name)
 		client := w.getClient(c)
 
-		// Create the client connection.
+		// Create the client connection. The client's balancer ejects
+		// unhealthy endpoints, so it has to observe every call.
 		opts := w.transport.clientOpts
+		opts.Balancer = client.balancer
 		conn, err := call.Connect(w.ctx, client.resolver, opts)
 		if err != nil {
 			w.env.SystemLogger().Error("Creating a connection to remote component failed", "err", err, "component", c.info.Name)
@@ -637,20 +764,28 @@ func (w *weavelet) getStub(c *component) (*stub, error) {
 		// Construct the keys for the methods.
 		n := c.info.Iface.NumMethod()
 		methods := make([]call.MethodKey, n)
+		timeouts := make([]time.Duration, n)
+		retriable := make([]bool, n)
+		hedges := make([]*latencyTracker, n)
 		for i := 0; i < n; i++ {
 			mname := c.info.Iface.Method(i).Name
 			methods[i] = call.MakeMethodKey(c.info.Name, mname)
+			timeouts[i] = c.calls.MethodTimeout(mname)
+			if c.calls.MethodHedged(mname) {
+				hedges[i] = &latencyTracker{}
+			}
 		}
-
-		var balancer call.Balancer
-		if c.info.Routed {
-			balancer = client.balancer
+		for _, i := range c.info.Retriable {
+			retriable[i] = true
 		}
+
 		c.stub = &stub{
 			component: c.info.Name,
 			conn:      conn,
 			methods:   methods,
-			balancer:  balancer,
+			timeouts:  timeouts,
+			retriable: retriable,
+			hedges:    hedges,
 			tracer:    w.tracer,
 		}
 		return nil
@@ -687,6 +822,15 @@ func (s *server) Accept() (net.Conn, *call.HandlerMap, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	for s.wlet.calls.isDraining() {
+		// The weavelet is shutting down, so we refuse new connections. Note
+		// that we don't return an error, as that would make call.Serve close
+		// the existing connections, on which calls may still be in progress.
+		conn.Close()
+		if conn, err = s.Listener.Accept(); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	if s.wlet.selfCert == nil {
 		// No security: all components are accessible.
@@ -65,6 +65,19 @@ var (
 	// retries, for example.
 	RemoteCallError = errors.New("Service Weaver remote call error")
 
+	// CallTimeoutError indicates that a component method call did not
+	// complete within the timeout configured for the method in the calls
+	// section of the application config, e.g.:
+	//
+	//	[calls."github.com/my/project/package/Cache".methods.Get]
+	//	timeout = "100ms"
+	//
+	// Calls to co-located components are timed out too. A CallTimeoutError
+	// returned by a remote call is embedded in a RemoteCallError. A call that
+	// fails because the caller's own context expired does not return a
+	// CallTimeoutError.
+	CallTimeoutError = errors.New("Service Weaver call timeout")
+
 	// HealthzHandler is a health-check handler that returns an OK status for
 	// all incoming HTTP requests.
 	HealthzHandler = func(w http.ResponseWriter, _ *http.Request) {
@@ -113,12 +126,16 @@ var healthzInit sync.Once
 // return. Most callers of Run will not do anything (other than
 // possibly logging any returned error) after Run returns.
 //
+// The provided interceptors, if any, intercept every component method call
+// made or executed by this process. See Interceptor for details. Every
+// process of an application should pass the same interceptors to Run.
+//
 //	func main() {
 //	    if err := weaver.Run(context.Background(), app); err != nil {
 //	        log.Fatal(err)
 //	    }
 //	}
-func Run[T MainInstance](ctx context.Context, app func(context.Context, T) error) error {
+func Run[T MainInstance](ctx context.Context, app func(context.Context, T) error, interceptors ...Interceptor) error {
 	// Register HealthzHandler in the default ServerMux.
 	healthzInit.Do(func() {
 		http.HandleFunc(HealthzURL, HealthzHandler)
@@ -133,11 +150,15 @@ func Run[T MainInstance](ctx context.Context, app func(context.Context, T) error
 		}
 		return app(ctx, arg)
 	}
-	return internalRun(ctx, rootType, rootBody)
+	return internalRun(ctx, rootType, rootBody, interceptors)
 }
 
-func internalRun(ctx context.Context, rootType reflect.Type, rootBody func(context.Context, any) error) error {
-	wlet, err := newWeavelet(ctx, codegen.Registered())
+func internalRun(ctx context.Context, rootType reflect.Type, rootBody func(context.Context, any) error, interceptors any) error {
+	is, ok := interceptors.([]Interceptor)
+	if interceptors != nil && !ok {
+		return fmt.Errorf("internal error: interceptors have type %T instead of expected type []weaver.Interceptor", interceptors)
+	}
+	wlet, err := newWeavelet(ctx, codegen.Registered(), is)
 	if err != nil {
 		return fmt.Errorf("error initializating application: %w", err)
 	}
@@ -19,10 +19,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"sync"
 
 	"github.com/ServiceWeaver/weaver/internal/envelope/conn"
 	"github.com/ServiceWeaver/weaver/runtime"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/colors"
 	"github.com/ServiceWeaver/weaver/runtime/envelope"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
@@ -57,6 +59,8 @@ type deployer struct {
 	wlet       *protos.EnvelopeInfo // info for subprocesses
 	config     *protos.AppConfig    // application config
 	colocation map[string]string    // maps component to group
+	fakes      map[reflect.Type]any // fake component implementations
+	faked      map[string]bool      // names of the faked components
 	running    errgroup.Group       // collects errors from goroutines
 	log        func(string)         // logs the passed in string
 
@@ -103,13 +107,19 @@ type connection struct {
 var _ envelope.EnvelopeHandler = &handler{}
 
 // newDeployer returns a new weavertest multiprocess deployer.
-func newDeployer(ctx context.Context, wlet *protos.EnvelopeInfo, config *protos.AppConfig, logWriter func(string)) *deployer {
+func newDeployer(ctx context.Context, wlet *protos.EnvelopeInfo, config *protos.AppConfig, fakes map[reflect.Type]any, logWriter func(string)) *deployer {
 	colocation := map[string]string{}
 	for _, group := range config.Colocate {
 		for _, c := range group.Components {
 			colocation[c] = group.Components[0]
 		}
 	}
+	faked := map[string]bool{}
+	for _, reg := range codegen.Registered() {
+		if _, ok := fakes[reg.Iface]; ok {
+			faked[reg.Name] = true
+		}
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	d := &deployer{
 		ctx:        ctx,
@@ -117,6 +127,8 @@ func newDeployer(ctx context.Context, wlet *protos.EnvelopeInfo, config *protos.
 		wlet:       wlet,
 		config:     config,
 		colocation: colocation,
+		fakes:      fakes,
+		faked:      faked,
 		groups:     map[string]*group{},
 		log:        logWriter,
 	}
@@ -154,6 +166,7 @@ func (d *deployer) start(config string) error {
 		ToWeaveletFile: toWeaveletReader,
 		ToEnvelopeFile: fromWeaveletWriter,
 		TestConfig:     config,
+		Fakes:          d.fakes,
 	}
 	d.ctx = context.WithValue(d.ctx, runtime.BootstrapKey{}, bootstrap)
 
@@ -376,8 +389,9 @@ func (d *deployer) group(component string) *group {
 	if !ok {
 		name = component
 	}
-	// Force testMain into main group
-	if component == "github.com/ServiceWeaver/weaver/weavertest/testMainInterface" {
+	// Force testMain into main group. Faked components are also placed in
+	// the main group, since the fakes live in the test process.
+	if component == "github.com/ServiceWeaver/weaver/weavertest/testMainInterface" || d.faked[component] {
 		name = "main"
 	}
 
@@ -26,6 +26,7 @@ import (
 
 	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/internal/private"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
 )
 
 // Options configure weavertest.Init.
@@ -38,6 +39,45 @@ type Options struct {
 	// Service Weaver config file. It can contain application level as well as component
 	// level configuration. Config is allowed to be empty.
 	Config string
+
+	// Fakes contains fake component implementations that are used instead
+	// of the real component implementations. See Fake.
+	Fakes []FakeComponent
+
+	// Interceptors contains the component method call interceptors that are
+	// passed to weaver.Run, in both single and multiprocess mode.
+	Interceptors []weaver.Interceptor
+}
+
+// FakeComponent is a fake component implementation. See Fake.
+type FakeComponent struct {
+	intf reflect.Type // component interface type
+	impl any          // fake implementation
+}
+
+// Fake returns a fake implementation of the component with interface type T.
+// When passed in Options.Fakes, impl is used instead of the real
+// implementation of T, in both single and multiprocess mode. For example:
+//
+//	fake := weavertest.Fake[Cache](&fakeCache{})
+//	weavertest.Run(t, weavertest.Options{Fakes: []weavertest.FakeComponent{fake}}, func(s Server) {
+//	    // s calls fakeCache instead of the real Cache.
+//	})
+//
+// "weaver generate -fakes" generates a fake implementation for every
+// component interface Foo, called FakeFoo, whose methods call user-provided
+// functions.
+//
+// Note that the fake is used as is: its weaver.Ref fields are not filled in
+// and its Init method is not called. In multiprocess mode, the fake runs in
+// the test process, and calls from other processes are sent to it over the
+// network.
+func Fake[T any](impl T) FakeComponent {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Interface {
+		panic(fmt.Errorf("weavertest.Fake: type %v is not an interface", t))
+	}
+	return FakeComponent{intf: t, impl: impl}
 }
 
 //go:generate ../cmd/weaver/weaver generate
@@ -116,17 +156,22 @@ func Run(t testing.TB, opts Options, testBody any) {
 		}
 	}()
 
+	fakes, err := checkFakes(opts.Fakes)
+	if err != nil {
+		t.Fatal(fmt.Errorf("weavertest.Options.Fakes: %v", err))
+	}
+
 	if opts.SingleProcess {
-		ctx = initSingleProcess(ctx, opts.Config)
+		ctx = initSingleProcess(ctx, opts.Config, fakes)
 	} else {
-		multiCtx, multiCleanup, err := initMultiProcess(ctx, t.Name(), opts.Config, logWriter)
+		multiCtx, multiCleanup, err := initMultiProcess(ctx, t.Name(), opts.Config, fakes, opts.Interceptors, logWriter)
 		if err != nil {
 			t.Fatal(err)
 		}
 		ctx, cleanup = multiCtx, multiCleanup
 	}
 
-	if err := runWeaver(ctx, runner); err != nil {
+	if err := runWeaver(ctx, runner, opts.Interceptors); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -165,8 +210,31 @@ func checkRunFunc(fn any) (func(context.Context, any) error, error) {
 	}, nil
 }
 
-func runWeaver(ctx context.Context, body func(context.Context, any) error) error {
-	return private.Run(ctx, reflect.TypeOf((*testMainInterface)(nil)).Elem(), body)
+// checkFakes checks that the provided fakes are fakes of registered
+// components and returns them keyed by component interface type.
+func checkFakes(fakes []FakeComponent) (map[reflect.Type]any, error) {
+	registered := map[reflect.Type]bool{}
+	for _, reg := range codegen.Registered() {
+		registered[reg.Iface] = true
+	}
+	m := map[reflect.Type]any{}
+	for _, fake := range fakes {
+		if fake.intf == nil {
+			return nil, fmt.Errorf("fake not created with weavertest.Fake")
+		}
+		if !registered[fake.intf] {
+			return nil, fmt.Errorf("fake for %v, which is not a registered component", fake.intf)
+		}
+		if _, ok := m[fake.intf]; ok {
+			return nil, fmt.Errorf("multiple fakes for %v", fake.intf)
+		}
+		m[fake.intf] = fake.impl
+	}
+	return m, nil
+}
+
+func runWeaver(ctx context.Context, body func(context.Context, any) error, interceptors []weaver.Interceptor) error {
+	return private.Run(ctx, reflect.TypeOf((*testMainInterface)(nil)).Elem(), body, interceptors)
 }
 
 // logStacks prints the stacks of live goroutines. This functionality
@@ -16,41 +16,64 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Started",
-		Iface: reflect.TypeOf((*Started)(nil)).Elem(),
-		Impl:  reflect.TypeOf(started{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return started_local_stub{impl: impl.(Started), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Started",
+		Iface:   reflect.TypeOf((*Started)(nil)).Elem(),
+		Impl:    reflect.TypeOf(started{}),
+		RefData: "⟦1d75d5d5:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Started:MarkStarted⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return started_local_stub{impl: impl.(Started), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return started_client_stub{stub: stub, markStartedMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Started", Method: "MarkStarted"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return started_client_stub{stub: stub, interceptor: interceptor, markStartedMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Started", Method: "MarkStarted"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return started_server_stub{impl: impl.(Started), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return started_server_stub{impl: impl.(Started), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Widget",
-		Iface:       reflect.TypeOf((*Widget)(nil)).Elem(),
-		Impl:        reflect.TypeOf(widget{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return widget_local_stub{impl: impl.(Widget), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return widget_client_stub{stub: stub, useMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Widget", Method: "Use"})}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Widget",
+		Iface:   reflect.TypeOf((*Widget)(nil)).Elem(),
+		Impl:    reflect.TypeOf(widget{}),
+		RefData: "⟦99d2fd07:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Widget:Use⟧\n⟦f3fa3c18:wEaVeReDgE:github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Widget→github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Started⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return widget_local_stub{impl: impl.(Widget), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return widget_server_stub{impl: impl.(Widget), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return widget_client_stub{stub: stub, interceptor: interceptor, useMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Widget", Method: "Use"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return widget_server_stub{impl: impl.(Widget), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦422b7d1e:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Started\",\"methods\":[{\"name\":\"MarkStarted\",\"args\":[{\"kind\":\"string\"}],\"results\":[]}]},{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/deploy/Widget\",\"methods\":[{\"name\":\"Use\",\"args\":[{\"kind\":\"string\"}],\"results\":[]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type started_local_stub struct {
-	impl   Started
-	tracer trace.Tracer
+	impl        Started
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s started_local_stub) MarkStarted(ctx context.Context, a0 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		err := intercept(ctx, "MarkStarted", func(ctx context.Context) (err error) {
+			err = s.MarkStarted(ctx, a0)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -68,11 +91,26 @@ func (s started_local_stub) MarkStarted(ctx context.Context, a0 string) (err err
 }
 
 type widget_local_stub struct {
-	impl   Widget
-	tracer trace.Tracer
+	impl        Widget
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s widget_local_stub) Use(ctx context.Context, a0 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		err := intercept(ctx, "Use", func(ctx context.Context) (err error) {
+			err = s.Use(ctx, a0)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -93,10 +131,25 @@ func (s widget_local_stub) Use(ctx context.Context, a0 string) (err error) {
 
 type started_client_stub struct {
 	stub               codegen.Stub
+	interceptor        codegen.Interceptor
 	markStartedMetrics *codegen.MethodMetrics
 }
 
 func (s started_client_stub) MarkStarted(ctx context.Context, a0 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		err := intercept(ctx, "MarkStarted", func(ctx context.Context) (err error) {
+			err = s.MarkStarted(ctx, a0)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.markStartedMetrics.Count.Add(1)
@@ -129,8 +182,8 @@ func (s started_client_stub) MarkStarted(ctx context.Context, a0 string) (err er
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -147,17 +200,32 @@ func (s started_client_stub) MarkStarted(ctx context.Context, a0 string) (err er
 	s.markStartedMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 type widget_client_stub struct {
-	stub       codegen.Stub
-	useMetrics *codegen.MethodMetrics
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
+	useMetrics  *codegen.MethodMetrics
 }
 
 func (s widget_client_stub) Use(ctx context.Context, a0 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		err := intercept(ctx, "Use", func(ctx context.Context) (err error) {
+			err = s.Use(ctx, a0)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.useMetrics.Count.Add(1)
@@ -190,8 +258,8 @@ func (s widget_client_stub) Use(ctx context.Context, a0 string) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -208,7 +276,7 @@ func (s widget_client_stub) Use(ctx context.Context, a0 string) (err error) {
 	s.useMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -216,8 +284,9 @@ func (s widget_client_stub) Use(ctx context.Context, a0 string) (err error) {
 // Server stub implementations.
 
 type started_server_stub struct {
-	impl    Started
-	addLoad func(key uint64, load float64)
+	impl        Started
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -239,14 +308,25 @@ func (s started_server_stub) markStarted(ctx context.Context, args []byte) (res
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.MarkStarted(ctx, a0)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.MarkStarted(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		appErr = s.interceptor(ctx, "MarkStarted", func(ctx context.Context) (err error) {
+			err = s.impl.MarkStarted(ctx, a0)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -255,8 +335,9 @@ func (s started_server_stub) markStarted(ctx context.Context, args []byte) (res
 }
 
 type widget_server_stub struct {
-	impl    Widget
-	addLoad func(key uint64, load float64)
+	impl        Widget
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -278,14 +359,25 @@ func (s widget_server_stub) use(ctx context.Context, args []byte) (res []byte, e
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.Use(ctx, a0)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.Use(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		appErr = s.interceptor(ctx, "Use", func(ctx context.Context) (err error) {
+			err = s.impl.Use(ctx, a0)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -17,53 +17,79 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Errer",
-		Iface:       reflect.TypeOf((*Errer)(nil)).Elem(),
-		Impl:        reflect.TypeOf(errer{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return errer_local_stub{impl: impl.(Errer), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return errer_client_stub{stub: stub, errMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Errer", Method: "Err"})}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Errer",
+		Iface:   reflect.TypeOf((*Errer)(nil)).Elem(),
+		Impl:    reflect.TypeOf(errer{}),
+		RefData: "⟦dd1ba6a7:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Errer:Err⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return errer_local_stub{impl: impl.(Errer), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return errer_server_stub{impl: impl.(Errer), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return errer_client_stub{stub: stub, interceptor: interceptor, errMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Errer", Method: "Err"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return errer_server_stub{impl: impl.(Errer), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Failer",
-		Iface:       reflect.TypeOf((*Failer)(nil)).Elem(),
-		Impl:        reflect.TypeOf(failer{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return failer_local_stub{impl: impl.(Failer), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return failer_client_stub{stub: stub, imJustHereSoWeaverGenerateDoesntComplainMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Failer", Method: "ImJustHereSoWeaverGenerateDoesntComplain"})}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Failer",
+		Iface:   reflect.TypeOf((*Failer)(nil)).Elem(),
+		Impl:    reflect.TypeOf(failer{}),
+		RefData: "⟦d5306a36:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Failer:ImJustHereSoWeaverGenerateDoesntComplain⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return failer_local_stub{impl: impl.(Failer), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return failer_client_stub{stub: stub, interceptor: interceptor, imJustHereSoWeaverGenerateDoesntComplainMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Failer", Method: "ImJustHereSoWeaverGenerateDoesntComplain"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return failer_server_stub{impl: impl.(Failer), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return failer_server_stub{impl: impl.(Failer), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Pointer",
-		Iface: reflect.TypeOf((*Pointer)(nil)).Elem(),
-		Impl:  reflect.TypeOf(pointer{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return pointer_local_stub{impl: impl.(Pointer), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Pointer",
+		Iface:   reflect.TypeOf((*Pointer)(nil)).Elem(),
+		Impl:    reflect.TypeOf(pointer{}),
+		RefData: "⟦e1fb37c6:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Pointer:Get⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return pointer_local_stub{impl: impl.(Pointer), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return pointer_client_stub{stub: stub, getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Pointer", Method: "Get"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return pointer_client_stub{stub: stub, interceptor: interceptor, getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Pointer", Method: "Get"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return pointer_server_stub{impl: impl.(Pointer), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return pointer_server_stub{impl: impl.(Pointer), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦e6f0f3b1:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Errer\",\"methods\":[{\"name\":\"Err\",\"args\":[{\"kind\":\"int\"}],\"results\":[]}]},{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Failer\",\"methods\":[{\"name\":\"ImJustHereSoWeaverGenerateDoesntComplain\",\"args\":[],\"results\":[]}]},{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Pointer\",\"methods\":[{\"name\":\"Get\",\"args\":[],\"results\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/diverge.Pair\",\"fields\":[{\"name\":\"X\",\"type\":{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int\"}}},{\"name\":\"Y\",\"type\":{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int\"}}}]}]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/diverge.Pair\",\"fields\":[{\"name\":\"X\",\"type\":{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int\"}}},{\"name\":\"Y\",\"type\":{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int\"}}}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type errer_local_stub struct {
-	impl   Errer
-	tracer trace.Tracer
+	impl        Errer
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s errer_local_stub) Err(ctx context.Context, a0 int) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		err := intercept(ctx, "Err", func(ctx context.Context) (err error) {
+			err = s.Err(ctx, a0)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -81,11 +107,25 @@ func (s errer_local_stub) Err(ctx context.Context, a0 int) (err error) {
 }
 
 type failer_local_stub struct {
-	impl   Failer
-	tracer trace.Tracer
+	impl        Failer
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s failer_local_stub) ImJustHereSoWeaverGenerateDoesntComplain(ctx context.Context) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		err := intercept(ctx, "ImJustHereSoWeaverGenerateDoesntComplain", func(ctx context.Context) (err error) {
+			err = s.ImJustHereSoWeaverGenerateDoesntComplain(ctx)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -103,11 +143,26 @@ func (s failer_local_stub) ImJustHereSoWeaverGenerateDoesntComplain(ctx context.
 }
 
 type pointer_local_stub struct {
-	impl   Pointer
-	tracer trace.Tracer
+	impl        Pointer
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s pointer_local_stub) Get(ctx context.Context) (r0 Pair, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 Pair
+		err := intercept(ctx, "Get", func(ctx context.Context) (err error) {
+			r0, err = s.Get(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -127,11 +182,26 @@ func (s pointer_local_stub) Get(ctx context.Context) (r0 Pair, err error) {
 // Client stub implementations.
 
 type errer_client_stub struct {
-	stub       codegen.Stub
-	errMetrics *codegen.MethodMetrics
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
+	errMetrics  *codegen.MethodMetrics
 }
 
 func (s errer_client_stub) Err(ctx context.Context, a0 int) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		err := intercept(ctx, "Err", func(ctx context.Context) (err error) {
+			err = s.Err(ctx, a0)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.errMetrics.Count.Add(1)
@@ -164,8 +234,8 @@ func (s errer_client_stub) Err(ctx context.Context, a0 int) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.Int(a0)
@@ -182,17 +252,31 @@ func (s errer_client_stub) Err(ctx context.Context, a0 int) (err error) {
 	s.errMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 type failer_client_stub struct {
 	stub                                            codegen.Stub
+	interceptor                                     codegen.Interceptor
 	imJustHereSoWeaverGenerateDoesntComplainMetrics *codegen.MethodMetrics
 }
 
 func (s failer_client_stub) ImJustHereSoWeaverGenerateDoesntComplain(ctx context.Context) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		err := intercept(ctx, "ImJustHereSoWeaverGenerateDoesntComplain", func(ctx context.Context) (err error) {
+			err = s.ImJustHereSoWeaverGenerateDoesntComplain(ctx)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.imJustHereSoWeaverGenerateDoesntComplainMetrics.Count.Add(1)
@@ -235,17 +319,32 @@ func (s failer_client_stub) ImJustHereSoWeaverGenerateDoesntComplain(ctx context
 	s.imJustHereSoWeaverGenerateDoesntComplainMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 type pointer_client_stub struct {
-	stub       codegen.Stub
-	getMetrics *codegen.MethodMetrics
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
+	getMetrics  *codegen.MethodMetrics
 }
 
 func (s pointer_client_stub) Get(ctx context.Context) (r0 Pair, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 Pair
+		err := intercept(ctx, "Get", func(ctx context.Context) (err error) {
+			r0, err = s.Get(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getMetrics.Count.Add(1)
@@ -288,7 +387,7 @@ func (s pointer_client_stub) Get(ctx context.Context) (r0 Pair, err error) {
 	s.getMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	(&r0).WeaverUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -297,8 +396,9 @@ func (s pointer_client_stub) Get(ctx context.Context) (r0 Pair, err error) {
 // Server stub implementations.
 
 type errer_server_stub struct {
-	impl    Errer
-	addLoad func(key uint64, load float64)
+	impl        Errer
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -320,14 +420,25 @@ func (s errer_server_stub) err(ctx context.Context, args []byte) (res []byte, er
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 int
 	a0 = dec.Int()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.Err(ctx, a0)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.Err(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		appErr = s.interceptor(ctx, "Err", func(ctx context.Context) (err error) {
+			err = s.impl.Err(ctx, a0)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -336,8 +447,9 @@ func (s errer_server_stub) err(ctx context.Context, args []byte) (res []byte, er
 }
 
 type failer_server_stub struct {
-	impl    Failer
-	addLoad func(key uint64, load float64)
+	impl        Failer
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -361,7 +473,17 @@ func (s failer_server_stub) imJustHereSoWeaverGenerateDoesntComplain(ctx context
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.ImJustHereSoWeaverGenerateDoesntComplain(ctx)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.ImJustHereSoWeaverGenerateDoesntComplain(ctx)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		appErr = s.interceptor(ctx, "ImJustHereSoWeaverGenerateDoesntComplain", func(ctx context.Context) (err error) {
+			err = s.impl.ImJustHereSoWeaverGenerateDoesntComplain(ctx)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -370,8 +492,9 @@ func (s failer_server_stub) imJustHereSoWeaverGenerateDoesntComplain(ctx context
 }
 
 type pointer_server_stub struct {
-	impl    Pointer
-	addLoad func(key uint64, load float64)
+	impl        Pointer
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -395,7 +518,20 @@ func (s pointer_server_stub) get(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Get(ctx)
+	var r0 Pair
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Get(ctx)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		var ir0 Pair
+		appErr = s.interceptor(ctx, "Get", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Get(ctx)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -441,3 +577,7 @@ func serviceweaver_dec_ptr_int_98a2a745(dec *codegen.Decoder) *int {
 	res = dec.Int()
 	return &res
 }
+
+func init() {
+	codegen.RegisterType[Pair]()
+}
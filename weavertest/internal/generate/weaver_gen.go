@@ -16,29 +16,51 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp",
-		Iface: reflect.TypeOf((*testApp)(nil)).Elem(),
-		Impl:  reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return testApp_local_stub{impl: impl.(testApp), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp",
+		Iface:   reflect.TypeOf((*testApp)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦be7c1622:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp:Get,IncPointer⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return testApp_local_stub{impl: impl.(testApp), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return testApp_client_stub{stub: stub, getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Get"}), incPointerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "IncPointer"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return testApp_client_stub{stub: stub, interceptor: interceptor, getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Get"}), incPointerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "IncPointer"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return testApp_server_stub{impl: impl.(testApp), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return testApp_server_stub{impl: impl.(testApp), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦f1500dbf:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp\",\"methods\":[{\"name\":\"Get\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"int\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/generate.behaviorType\"}],\"results\":[{\"kind\":\"int\"}]},{\"name\":\"IncPointer\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int\"}}]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type testApp_local_stub struct {
-	impl   testApp
-	tracer trace.Tracer
+	impl        testApp
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s testApp_local_stub) Get(ctx context.Context, a0 string, a1 behaviorType) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 int
+		err := intercept(ctx, "Get", func(ctx context.Context) (err error) {
+			r0, err = s.Get(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -56,6 +78,21 @@ func (s testApp_local_stub) Get(ctx context.Context, a0 string, a1 behaviorType)
 }
 
 func (s testApp_local_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 *int
+		err := intercept(ctx, "IncPointer", func(ctx context.Context) (err error) {
+			r0, err = s.IncPointer(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -76,11 +113,28 @@ func (s testApp_local_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int, e
 
 type testApp_client_stub struct {
 	stub              codegen.Stub
+	interceptor       codegen.Interceptor
 	getMetrics        *codegen.MethodMetrics
 	incPointerMetrics *codegen.MethodMetrics
 }
 
 func (s testApp_client_stub) Get(ctx context.Context, a0 string, a1 behaviorType) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 int
+		err := intercept(ctx, "Get", func(ctx context.Context) (err error) {
+			r0, err = s.Get(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getMetrics.Count.Add(1)
@@ -114,8 +168,8 @@ func (s testApp_client_stub) Get(ctx context.Context, a0 string, a1 behaviorType
 	size := 0
 	size += (4 + len(a0))
 	size += 8
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -133,13 +187,28 @@ func (s testApp_client_stub) Get(ctx context.Context, a0 string, a1 behaviorType
 	s.getMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
 }
 
 func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 *int
+		err := intercept(ctx, "IncPointer", func(ctx context.Context) (err error) {
+			r0, err = s.IncPointer(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.incPointerMetrics.Count.Add(1)
@@ -172,8 +241,8 @@ func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += serviceweaver_size_ptr_int_98a2a745(a0)
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	serviceweaver_enc_ptr_int_98a2a745(enc, a0)
@@ -190,7 +259,7 @@ func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 	s.incPointerMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_ptr_int_98a2a745(dec)
 	err = dec.Error()
 	return
@@ -199,8 +268,9 @@ func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 // Server stub implementations.
 
 type testApp_server_stub struct {
-	impl    testApp
-	addLoad func(key uint64, load float64)
+	impl        testApp
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -224,7 +294,7 @@ func (s testApp_server_stub) get(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 behaviorType
@@ -233,7 +303,22 @@ func (s testApp_server_stub) get(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Get(ctx, a0, a1)
+	var r0 int
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Get(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 int
+		appErr = s.interceptor(ctx, "Get", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Get(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -251,14 +336,28 @@ func (s testApp_server_stub) incPointer(ctx context.Context, args []byte) (res [
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 *int
 	a0 = serviceweaver_dec_ptr_int_98a2a745(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.IncPointer(ctx, a0)
+	var r0 *int
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.IncPointer(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 *int
+		appErr = s.interceptor(ctx, "IncPointer", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.IncPointer(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -16,29 +16,50 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/weavertest/internal/protos/PingPonger",
-		Iface: reflect.TypeOf((*PingPonger)(nil)).Elem(),
-		Impl:  reflect.TypeOf(impl{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return pingPonger_local_stub{impl: impl.(PingPonger), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/protos/PingPonger",
+		Iface:   reflect.TypeOf((*PingPonger)(nil)).Elem(),
+		Impl:    reflect.TypeOf(impl{}),
+		RefData: "⟦5e7abefc:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/protos/PingPonger:Ping⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return pingPonger_local_stub{impl: impl.(PingPonger), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return pingPonger_client_stub{stub: stub, pingMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/protos/PingPonger", Method: "Ping"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return pingPonger_client_stub{stub: stub, interceptor: interceptor, pingMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/protos/PingPonger", Method: "Ping"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return pingPonger_server_stub{impl: impl.(PingPonger), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return pingPonger_server_stub{impl: impl.(PingPonger), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦b61ce5a3:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/protos/PingPonger\",\"methods\":[{\"name\":\"Ping\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/protos.Ping\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/protos.Pong\"}}]}]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type pingPonger_local_stub struct {
-	impl   PingPonger
-	tracer trace.Tracer
+	impl        PingPonger
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s pingPonger_local_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 *Pong
+		err := intercept(ctx, "Ping", func(ctx context.Context) (err error) {
+			r0, err = s.Ping(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -59,10 +80,26 @@ func (s pingPonger_local_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, er
 
 type pingPonger_client_stub struct {
 	stub        codegen.Stub
+	interceptor codegen.Interceptor
 	pingMetrics *codegen.MethodMetrics
 }
 
 func (s pingPonger_client_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 *Pong
+		err := intercept(ctx, "Ping", func(ctx context.Context) (err error) {
+			r0, err = s.Ping(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.pingMetrics.Count.Add(1)
@@ -93,7 +130,8 @@ func (s pingPonger_client_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, e
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
 	serviceweaver_enc_ptr_Ping_53efca65(enc, a0)
 	var shardKey uint64
 
@@ -108,7 +146,7 @@ func (s pingPonger_client_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, e
 	s.pingMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_ptr_Pong_10ae1a4e(dec)
 	err = dec.Error()
 	return
@@ -117,8 +155,9 @@ func (s pingPonger_client_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, e
 // Server stub implementations.
 
 type pingPonger_server_stub struct {
-	impl    PingPonger
-	addLoad func(key uint64, load float64)
+	impl        PingPonger
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -140,14 +179,28 @@ func (s pingPonger_server_stub) ping(ctx context.Context, args []byte) (res []by
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 *Ping
 	a0 = serviceweaver_dec_ptr_Ping_53efca65(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Ping(ctx, a0)
+	var r0 *Pong
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Ping(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 *Pong
+		appErr = s.interceptor(ctx, "Ping", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Ping(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -22,15 +22,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
 	"sync"
 
 	"github.com/ServiceWeaver/weaver"
+	"github.com/ServiceWeaver/weaver/metadata"
 )
 
-//go:generate ../../../cmd/weaver/weaver generate
+//go:generate ../../../cmd/weaver/weaver generate -fakes
 
 type Source interface {
 	Emit(ctx context.Context, file, msg string) error
@@ -50,6 +52,10 @@ type Destination interface {
 	Record(_ context.Context, file, msg string) error
 	GetAll(_ context.Context, file string) ([]string, error)
 	RoutedRecord(_ context.Context, file, msg string) error
+	RecordAll(_ context.Context, file string, msgs *weaver.Stream[string]) (int, error)
+	Scan(_ context.Context, file string) (*weaver.Stream[string], error)
+	Apply(_ context.Context, file string, events []Event) (Event, error)
+	Metadata(_ context.Context, key string) (string, error)
 }
 
 type destRouter struct{}
@@ -82,23 +88,146 @@ func (d *destination) Record(_ context.Context, file, msg string) error {
 	return err
 }
 
+// RecordAll adds all messages in msgs, returning the number of added messages.
+func (d *destination) RecordAll(ctx context.Context, file string, msgs *weaver.Stream[string]) (int, error) {
+	defer msgs.Close()
+	n := 0
+	for {
+		msg, err := msgs.Next()
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		if err := d.Record(ctx, file, msg); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// Scan returns a stream of all added messages.
+func (d *destination) Scan(ctx context.Context, file string) (*weaver.Stream[string], error) {
+	msgs, err := d.GetAll(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	return weaver.StreamOf(msgs...), nil
+}
+
+// Metadata returns the value of the provided key in the context metadata, or
+// "" if the key is not present.
+func (d *destination) Metadata(ctx context.Context, key string) (string, error) {
+	value, _ := metadata.Value(ctx, key)
+	return value, nil
+}
+
 func (d *destination) RoutedRecord(ctx context.Context, file, msg string) error {
 	return d.Record(ctx, file, "routed: "+msg)
 }
 
+// Event is an update to a file.
+type Event interface {
+	isEvent()
+}
+
+// Append appends a message to a file. Its fields are numbered, so fields can
+// be added to it without breaking compatibility.
+type Append struct {
+	weaver.AutoMarshal
+	Msg string `weaver:"1"`
+}
+
+// Truncate removes all messages from a file.
+type Truncate struct {
+	weaver.AutoMarshal
+}
+
+func (Append) isEvent()    {}
+func (*Truncate) isEvent() {}
+
+// Apply applies events to a file, returning the last applied event.
+func (d *destination) Apply(ctx context.Context, file string, events []Event) (Event, error) {
+	var last Event
+	for _, event := range events {
+		switch e := event.(type) {
+		case Append:
+			if err := d.Record(ctx, file, e.Msg); err != nil {
+				return nil, err
+			}
+		case *Truncate:
+			d.mu.Lock()
+			err := os.Truncate(file, 0)
+			d.mu.Unlock()
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected event %T", event)
+		}
+		last = event
+	}
+	return last, nil
+}
+
+// NotFoundError is returned when reading messages from a missing file.
+type NotFoundError struct {
+	weaver.AutoMarshal
+	File string
+}
+
+// Error implements the error interface.
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", e.File)
+}
+
 // GetAll returns all added messages.
 func (d *destination) GetAll(_ context.Context, file string) ([]string, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	data, err := os.ReadFile(file)
-	if err != nil {
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, NotFoundError{File: file}
+	} else if err != nil {
 		return nil, err
 	}
 	str := strings.TrimSpace(string(data))
 	return strings.Split(str, "\n"), nil
 }
 
+// Paginator splits values into pages. Components implement instantiations of
+// Paginator, like Paginator[string].
+type Paginator[T any] interface {
+	Paginate(_ context.Context, values []T, size int) ([]Page[T], error)
+}
+
+// Page is a page of values.
+type Page[T any] struct {
+	weaver.AutoMarshal
+	Number int
+	Values []T
+}
+
+type stringPaginator struct {
+	weaver.Implements[Paginator[string]]
+}
+
+func (*stringPaginator) Paginate(_ context.Context, values []string, size int) ([]Page[string], error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid page size %d", size)
+	}
+	var pages []Page[string]
+	for i := 0; i < len(values); i += size {
+		end := i + size
+		if end > len(values) {
+			end = len(values)
+		}
+		pages = append(pages, Page[string]{Number: len(pages), Values: values[i:end]})
+	}
+	return pages, nil
+}
+
 // Server is a component used to test Service Weaver listener handling.
 // An HTTP server is started when this component is initialized.
 // simple_test.go checks the functionality of the HTTP server by fetching
@@ -16,16 +16,21 @@ package simple_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ServiceWeaver/weaver"
+	"github.com/ServiceWeaver/weaver/metadata"
 	"github.com/ServiceWeaver/weaver/weavertest"
 	"github.com/ServiceWeaver/weaver/weavertest/internal/simple"
 	"github.com/google/uuid"
@@ -100,6 +105,235 @@ func TestTwoComponents(t *testing.T) {
 	}
 }
 
+func TestMetadata(t *testing.T) {
+	// Call dst with and without context metadata, and verify that the
+	// metadata reaches dst.
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			weavertest.Run(t, weavertest.Options{SingleProcess: single}, func(dst simple.Destination) {
+				ctx := metadata.NewContext(context.Background(), map[string]string{"tag": "tagged"})
+				got, err := dst.Metadata(ctx, "tag")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := "tagged"; got != want {
+					t.Fatalf("Metadata() = %q; expecting %q", got, want)
+				}
+
+				got, err = dst.Metadata(context.Background(), "tag")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != "" {
+					t.Fatalf("Metadata() = %q; expecting \"\"", got)
+				}
+			})
+		})
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	// The client interceptor tags every call with the name of the called
+	// method, and the server interceptor rejects calls to Getpid. Verify that
+	// both interceptors behave the same way in single and multiprocess mode.
+	interceptor := weaver.Interceptor{
+		Client: func(ctx context.Context, info weaver.CallInfo, invoke func(context.Context) error) error {
+			tag := path.Base(info.Component) + "." + info.Method
+			return invoke(metadata.NewContext(ctx, map[string]string{"tag": tag}))
+		},
+		Server: func(ctx context.Context, info weaver.CallInfo, invoke func(context.Context) error) error {
+			if info.Method == "Getpid" {
+				return fmt.Errorf("%s denied", info.Method)
+			}
+			return invoke(ctx)
+		},
+	}
+	ctx := context.Background()
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			opts := weavertest.Options{
+				SingleProcess: single,
+				Interceptors:  []weaver.Interceptor{interceptor},
+			}
+			weavertest.Run(t, opts, func(dst simple.Destination) {
+				got, err := dst.Metadata(ctx, "tag")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := "Destination.Metadata"; got != want {
+					t.Fatalf("Metadata() = %q; expecting %q", got, want)
+				}
+
+				_, err = dst.Getpid(ctx)
+				if err == nil || !strings.Contains(err.Error(), "Getpid denied") {
+					t.Fatalf("Getpid: got error %v, want Getpid denied", err)
+				}
+				if errors.Is(err, weaver.RemoteCallError) {
+					t.Fatalf("Getpid: unexpected RemoteCallError: %v", err)
+				}
+			})
+		})
+	}
+}
+
+func TestStreams(t *testing.T) {
+	ctx := context.Background()
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			weavertest.Run(t, weavertest.Options{SingleProcess: single}, func(dst simple.Destination) {
+				file := filepath.Join(t.TempDir(), fmt.Sprintf("simple_%s", uuid.New().String()))
+				var want []string
+				for i := 0; i < 100; i++ {
+					want = append(want, fmt.Sprint(i))
+				}
+				n, err := dst.RecordAll(ctx, file, weaver.StreamOf(want...))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != len(want) {
+					t.Fatalf("RecordAll() = %d; expecting %d", n, len(want))
+				}
+
+				msgs, err := dst.Scan(ctx, file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer msgs.Close()
+				var got []string
+				for {
+					msg, err := msgs.Next()
+					if err == io.EOF {
+						break
+					} else if err != nil {
+						t.Fatal(err)
+					}
+					got = append(got, msg)
+				}
+				if !reflect.DeepEqual(want, got) {
+					t.Fatalf("Scan() = %v; expecting %v", got, want)
+				}
+
+				// Errors returned by a streaming method are propagated.
+				if _, err := dst.Scan(ctx, filepath.Join(t.TempDir(), "missing")); err == nil {
+					t.Fatal("Scan() of missing file unexpectedly succeeded")
+				}
+			})
+		})
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	ctx := context.Background()
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			weavertest.Run(t, weavertest.Options{SingleProcess: single}, func(dst simple.Destination) {
+				file := filepath.Join(t.TempDir(), "missing")
+				_, err := dst.GetAll(ctx, file)
+				var notFound simple.NotFoundError
+				if !errors.As(err, &notFound) {
+					t.Fatalf("GetAll() = %v; expecting a simple.NotFoundError", err)
+				}
+				if notFound.File != file {
+					t.Fatalf("NotFoundError.File = %q; expecting %q", notFound.File, file)
+				}
+			})
+		})
+	}
+}
+
+func TestInterfaceArgs(t *testing.T) {
+	ctx := context.Background()
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			weavertest.Run(t, weavertest.Options{SingleProcess: single}, func(dst simple.Destination) {
+				file := filepath.Join(t.TempDir(), fmt.Sprintf("simple_%s", uuid.New().String()))
+				events := []simple.Event{
+					simple.Append{Msg: "a"},
+					&simple.Truncate{},
+					simple.Append{Msg: "b"},
+				}
+				last, err := dst.Apply(ctx, file, events)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := (simple.Append{Msg: "b"}); last != want {
+					t.Fatalf("Apply() = %v; expecting %v", last, want)
+				}
+				msgs, err := dst.GetAll(ctx, file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := []string{"b"}; !reflect.DeepEqual(msgs, want) {
+					t.Fatalf("GetAll() = %v; expecting %v", msgs, want)
+				}
+			})
+		})
+	}
+}
+
+func TestGenericComponent(t *testing.T) {
+	ctx := context.Background()
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			weavertest.Run(t, weavertest.Options{SingleProcess: single}, func(paginator simple.Paginator[string]) {
+				got, err := paginator.Paginate(ctx, []string{"a", "b", "c"}, 2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := []simple.Page[string]{
+					{Number: 0, Values: []string{"a", "b"}},
+					{Number: 1, Values: []string{"c"}},
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("Paginate() = %v; expecting %v", got, want)
+				}
+			})
+		})
+	}
+}
+
+func TestFakes(t *testing.T) {
+	ctx := context.Background()
+	for _, single := range []bool{true, false} {
+		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
+			var mu sync.Mutex
+			var got []string
+			fake := simple.FakeDestination{
+				RecordFn: func(_ context.Context, file, msg string) error {
+					mu.Lock()
+					defer mu.Unlock()
+					got = append(got, msg)
+					return nil
+				},
+			}
+			opts := weavertest.Options{
+				SingleProcess: single,
+				Fakes:         []weavertest.FakeComponent{weavertest.Fake[simple.Destination](fake)},
+			}
+			weavertest.Run(t, opts, func(src simple.Source, dst simple.Destination) {
+				// Calls made by the test and by other components should
+				// be handled by the fake.
+				if err := src.Emit(ctx, "file", "a"); err != nil {
+					t.Fatal(err)
+				}
+				if err := dst.Record(ctx, "file", "b"); err != nil {
+					t.Fatal(err)
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+					t.Fatalf("recorded %v; expecting %v", got, want)
+				}
+
+				// Methods without a function return zero values.
+				if pid, err := dst.Getpid(ctx); pid != 0 || err != nil {
+					t.Fatalf("Getpid() = %d, %v; expecting 0, nil", pid, err)
+				}
+			})
+		})
+	}
+}
+
 func TestServer(t *testing.T) {
 	for _, single := range []bool{true, false} {
 		t.Run(fmt.Sprintf("Single=%t", single), func(t *testing.T) {
@@ -6,64 +6,142 @@ package simple
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"io"
 	"reflect"
 	"time"
 )
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:   "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination",
-		Iface:  reflect.TypeOf((*Destination)(nil)).Elem(),
-		Impl:   reflect.TypeOf(destination{}),
-		Routed: true,
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return destination_local_stub{impl: impl.(Destination), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination",
+		Iface:   reflect.TypeOf((*Destination)(nil)).Elem(),
+		Impl:    reflect.TypeOf(destination{}),
+		Routed:  true,
+		Streams: []int{5, 7},
+		RefData: "⟦53a6cf3c:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination:Apply,GetAll,Getpid,Metadata,Record,RecordAll,RoutedRecord,Scan⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return destination_local_stub{impl: impl.(Destination), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return destination_client_stub{stub: stub, getAllMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "GetAll"}), getpidMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Getpid"}), recordMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Record"}), routedRecordMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "RoutedRecord"})}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return destination_client_stub{stub: stub, interceptor: interceptor, applyMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Apply"}), getAllMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "GetAll"}), getpidMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Getpid"}), metadataMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Metadata"}), recordMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Record"}), recordAllMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "RecordAll"}), routedRecordMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "RoutedRecord"}), scanMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination", Method: "Scan"})}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return destination_server_stub{impl: impl.(Destination), addLoad: addLoad}
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return destination_server_stub{impl: impl.(Destination), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server",
-		Iface:       reflect.TypeOf((*Server)(nil)).Elem(),
-		Impl:        reflect.TypeOf(server{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return server_local_stub{impl: impl.(Server), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return server_client_stub{stub: stub, addressMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server", Method: "Address"}), proxyAddressMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server", Method: "ProxyAddress"}), shutdownMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server", Method: "Shutdown"})}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Paginator[string]",
+		Iface:   reflect.TypeOf((*Paginator[string])(nil)).Elem(),
+		Impl:    reflect.TypeOf(stringPaginator{}),
+		RefData: "⟦6a0e01f1:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Paginator[string]:Paginate⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return paginator_string_03f34b4e_local_stub{impl: impl.(Paginator[string]), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return server_server_stub{impl: impl.(Server), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return paginator_string_03f34b4e_client_stub{stub: stub, interceptor: interceptor, paginateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Paginator[string]", Method: "Paginate"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return paginator_string_03f34b4e_server_stub{impl: impl.(Paginator[string]), addLoad: addLoad, interceptor: interceptor}
+		},
+	})
+	codegen.Register(codegen.Registration{
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server",
+		Iface:   reflect.TypeOf((*Server)(nil)).Elem(),
+		Impl:    reflect.TypeOf(server{}),
+		RefData: "⟦3736c633:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server:Address,ProxyAddress,Shutdown⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return server_local_stub{impl: impl.(Server), tracer: tracer, interceptor: interceptor}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return server_client_stub{stub: stub, interceptor: interceptor, addressMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server", Method: "Address"}), proxyAddressMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server", Method: "ProxyAddress"}), shutdownMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server", Method: "Shutdown"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return server_server_stub{impl: impl.(Server), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 	codegen.Register(codegen.Registration{
-		Name:        "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source",
-		Iface:       reflect.TypeOf((*Source)(nil)).Elem(),
-		Impl:        reflect.TypeOf(source{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any { return source_local_stub{impl: impl.(Source), tracer: tracer} },
-		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return source_client_stub{stub: stub, emitMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source", Method: "Emit"})}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source",
+		Iface:   reflect.TypeOf((*Source)(nil)).Elem(),
+		Impl:    reflect.TypeOf(source{}),
+		RefData: "⟦709525a8:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source:Emit⟧\n⟦bf914175:wEaVeReDgE:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source→github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return source_local_stub{impl: impl.(Source), tracer: tracer, interceptor: interceptor}
 		},
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return source_server_stub{impl: impl.(Source), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return source_client_stub{stub: stub, interceptor: interceptor, emitMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source", Method: "Emit"})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return source_server_stub{impl: impl.(Source), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦97499669:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination\",\"methods\":[{\"name\":\"Apply\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"slice\",\"elem\":{\"kind\":\"interface\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple.Event\"}}],\"results\":[{\"kind\":\"interface\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple.Event\"}]},{\"name\":\"GetAll\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}]},{\"name\":\"Getpid\",\"args\":[],\"results\":[{\"kind\":\"int\"}]},{\"name\":\"Metadata\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"string\"}]},{\"name\":\"Record\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"}],\"results\":[]},{\"name\":\"RecordAll\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"stream\",\"elem\":{\"kind\":\"string\"}}],\"results\":[{\"kind\":\"int\"}]},{\"name\":\"RoutedRecord\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"}],\"results\":[]},{\"name\":\"Scan\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"stream\",\"elem\":{\"kind\":\"string\"}}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple/Paginator[string]\",\"methods\":[{\"name\":\"Paginate\",\"args\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}},{\"kind\":\"int\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple.Page[string]\",\"fields\":[{\"name\":\"Number\",\"type\":{\"kind\":\"int\"}},{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}}]}]},{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server\",\"methods\":[{\"name\":\"Address\",\"args\":[],\"results\":[{\"kind\":\"string\"}]},{\"name\":\"ProxyAddress\",\"args\":[],\"results\":[{\"kind\":\"string\"}]},{\"name\":\"Shutdown\",\"args\":[],\"results\":[]}]},{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source\",\"methods\":[{\"name\":\"Emit\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"}],\"results\":[]}]}],\"types\":[{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple.Append\",\"fields\":[{\"name\":\"Msg\",\"number\":1,\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple.NotFoundError\",\"fields\":[{\"name\":\"File\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"struct\",\"name\":\"github.com/ServiceWeaver/weaver/weavertest/internal/simple.Truncate\"}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type destination_local_stub struct {
-	impl   Destination
-	tracer trace.Tracer
+	impl        Destination
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
+}
+
+func (s destination_local_stub) Apply(ctx context.Context, a0 string, a1 []Event) (r0 Event, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 Event
+		err := intercept(ctx, "Apply", func(ctx context.Context) (err error) {
+			r0, err = s.Apply(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Apply", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Apply(ctx, a0, a1)
 }
 
 func (s destination_local_stub) GetAll(ctx context.Context, a0 string) (r0 []string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []string
+		err := intercept(ctx, "GetAll", func(ctx context.Context) (err error) {
+			r0, err = s.GetAll(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -81,6 +159,20 @@ func (s destination_local_stub) GetAll(ctx context.Context, a0 string) (r0 []str
 }
 
 func (s destination_local_stub) Getpid(ctx context.Context) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 int
+		err := intercept(ctx, "Getpid", func(ctx context.Context) (err error) {
+			r0, err = s.Getpid(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -97,7 +189,54 @@ func (s destination_local_stub) Getpid(ctx context.Context) (r0 int, err error)
 	return s.impl.Getpid(ctx)
 }
 
+func (s destination_local_stub) Metadata(ctx context.Context, a0 string) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 string
+		err := intercept(ctx, "Metadata", func(ctx context.Context) (err error) {
+			r0, err = s.Metadata(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Metadata", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Metadata(ctx, a0)
+}
+
 func (s destination_local_stub) Record(ctx context.Context, a0 string, a1 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "Record", func(ctx context.Context) (err error) {
+			err = s.Record(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -114,7 +253,55 @@ func (s destination_local_stub) Record(ctx context.Context, a0 string, a1 string
 	return s.impl.Record(ctx, a0, a1)
 }
 
+func (s destination_local_stub) RecordAll(ctx context.Context, a0 string, a1 *weaver.Stream[string]) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 int
+		err := intercept(ctx, "RecordAll", func(ctx context.Context) (err error) {
+			r0, err = s.RecordAll(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.RecordAll", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.RecordAll(ctx, a0, a1)
+}
+
 func (s destination_local_stub) RoutedRecord(ctx context.Context, a0 string, a1 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "RoutedRecord", func(ctx context.Context) (err error) {
+			err = s.RoutedRecord(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -131,12 +318,98 @@ func (s destination_local_stub) RoutedRecord(ctx context.Context, a0 string, a1
 	return s.impl.RoutedRecord(ctx, a0, a1)
 }
 
+func (s destination_local_stub) Scan(ctx context.Context, a0 string) (r0 *weaver.Stream[string], err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 *weaver.Stream[string]
+		err := intercept(ctx, "Scan", func(ctx context.Context) (err error) {
+			r0, err = s.Scan(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Destination.Scan", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Scan(ctx, a0)
+}
+
+type paginator_string_03f34b4e_local_stub struct {
+	impl        Paginator[string]
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
+}
+
+func (s paginator_string_03f34b4e_local_stub) Paginate(ctx context.Context, a0 []string, a1 int) (r0 []Page[string], err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 []Page[string]
+		err := intercept(ctx, "Paginate", func(ctx context.Context) (err error) {
+			r0, err = s.Paginate(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Paginator[string].Paginate", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Paginate(ctx, a0, a1)
+}
+
 type server_local_stub struct {
-	impl   Server
-	tracer trace.Tracer
+	impl        Server
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s server_local_stub) Address(ctx context.Context) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 string
+		err := intercept(ctx, "Address", func(ctx context.Context) (err error) {
+			r0, err = s.Address(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -154,6 +427,20 @@ func (s server_local_stub) Address(ctx context.Context) (r0 string, err error) {
 }
 
 func (s server_local_stub) ProxyAddress(ctx context.Context) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 string
+		err := intercept(ctx, "ProxyAddress", func(ctx context.Context) (err error) {
+			r0, err = s.ProxyAddress(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -171,6 +458,19 @@ func (s server_local_stub) ProxyAddress(ctx context.Context) (r0 string, err err
 }
 
 func (s server_local_stub) Shutdown(ctx context.Context) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		err := intercept(ctx, "Shutdown", func(ctx context.Context) (err error) {
+			err = s.Shutdown(ctx)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -188,11 +488,27 @@ func (s server_local_stub) Shutdown(ctx context.Context) (err error) {
 }
 
 type source_local_stub struct {
-	impl   Source
-	tracer trace.Tracer
+	impl        Source
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 func (s source_local_stub) Emit(ctx context.Context, a0 string, a1 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "Emit", func(ctx context.Context) (err error) {
+			err = s.Emit(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
@@ -213,13 +529,103 @@ func (s source_local_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 
 type destination_client_stub struct {
 	stub                codegen.Stub
+	interceptor         codegen.Interceptor
+	applyMetrics        *codegen.MethodMetrics
 	getAllMetrics       *codegen.MethodMetrics
 	getpidMetrics       *codegen.MethodMetrics
+	metadataMetrics     *codegen.MethodMetrics
 	recordMetrics       *codegen.MethodMetrics
+	recordAllMetrics    *codegen.MethodMetrics
 	routedRecordMetrics *codegen.MethodMetrics
+	scanMetrics         *codegen.MethodMetrics
+}
+
+func (s destination_client_stub) Apply(ctx context.Context, a0 string, a1 []Event) (r0 Event, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 Event
+		err := intercept(ctx, "Apply", func(ctx context.Context) (err error) {
+			r0, err = s.Apply(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
+	// Update metrics.
+	start := time.Now()
+	s.applyMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Apply", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.applyMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.applyMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Encode arguments.
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
+	enc.String(a0)
+	serviceweaver_enc_slice_Event_8f06820c(enc, a1)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.applyMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.applyMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewAliasingDecoder(results)
+	r0 = codegen.DecodeInterface[Event](dec)
+	err = dec.Error()
+	return
 }
 
 func (s destination_client_stub) GetAll(ctx context.Context, a0 string) (r0 []string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 []string
+		err := intercept(ctx, "GetAll", func(ctx context.Context) (err error) {
+			r0, err = s.GetAll(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getAllMetrics.Count.Add(1)
@@ -252,8 +658,8 @@ func (s destination_client_stub) GetAll(ctx context.Context, a0 string) (r0 []st
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -262,7 +668,7 @@ func (s destination_client_stub) GetAll(ctx context.Context, a0 string) (r0 []st
 	// Call the remote method.
 	s.getAllMetrics.BytesRequest.Put(float64(len(enc.Data())))
 	var results []byte
-	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 1, enc.Data(), shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
@@ -270,13 +676,27 @@ func (s destination_client_stub) GetAll(ctx context.Context, a0 string) (r0 []st
 	s.getAllMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = serviceweaver_dec_slice_string_4af10117(dec)
 	err = dec.Error()
 	return
 }
 
 func (s destination_client_stub) Getpid(ctx context.Context) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 int
+		err := intercept(ctx, "Getpid", func(ctx context.Context) (err error) {
+			r0, err = s.Getpid(ctx)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.getpidMetrics.Count.Add(1)
@@ -311,7 +731,7 @@ func (s destination_client_stub) Getpid(ctx context.Context) (r0 int, err error)
 	// Call the remote method.
 	s.getpidMetrics.BytesRequest.Put(0)
 	var results []byte
-	results, err = s.stub.Run(ctx, 1, nil, shardKey)
+	results, err = s.stub.Run(ctx, 2, nil, shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
@@ -319,21 +739,36 @@ func (s destination_client_stub) Getpid(ctx context.Context) (r0 int, err error)
 	s.getpidMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
 }
 
-func (s destination_client_stub) Record(ctx context.Context, a0 string, a1 string) (err error) {
+func (s destination_client_stub) Metadata(ctx context.Context, a0 string) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 string
+		err := intercept(ctx, "Metadata", func(ctx context.Context) (err error) {
+			r0, err = s.Metadata(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
 	// Update metrics.
 	start := time.Now()
-	s.recordMetrics.Count.Add(1)
+	s.metadataMetrics.Count.Add(1)
 
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Record", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Metadata", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -348,50 +783,64 @@ func (s destination_client_stub) Record(ctx context.Context, a0 string, a1 strin
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
-			s.recordMetrics.ErrorCount.Add(1)
+			s.metadataMetrics.ErrorCount.Add(1)
 		}
 		span.End()
 
-		s.recordMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+		s.metadataMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
 	}()
 
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	size += (4 + len(a1))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
-	enc.String(a1)
 	var shardKey uint64
 
 	// Call the remote method.
-	s.recordMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	s.metadataMetrics.BytesRequest.Put(float64(len(enc.Data())))
 	var results []byte
-	results, err = s.stub.Run(ctx, 2, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 3, enc.Data(), shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
 	}
-	s.recordMetrics.BytesReply.Put(float64(len(results)))
+	s.metadataMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
+	r0 = dec.String()
 	err = dec.Error()
 	return
 }
 
-func (s destination_client_stub) RoutedRecord(ctx context.Context, a0 string, a1 string) (err error) {
+func (s destination_client_stub) Record(ctx context.Context, a0 string, a1 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "Record", func(ctx context.Context) (err error) {
+			err = s.Record(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
-	s.routedRecordMetrics.Count.Add(1)
+	s.recordMetrics.Count.Add(1)
 
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.RoutedRecord", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Record", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -406,60 +855,66 @@ func (s destination_client_stub) RoutedRecord(ctx context.Context, a0 string, a1
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
-			s.routedRecordMetrics.ErrorCount.Add(1)
+			s.recordMetrics.ErrorCount.Add(1)
 		}
 		span.End()
 
-		s.routedRecordMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+		s.recordMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
 	}()
 
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
 	size += (4 + len(a1))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
 	enc.String(a1)
-
-	// Set the shardKey.
-	var r destRouter
-	shardKey := _hashDestination(r.RoutedRecord(ctx, a0, a1))
+	var shardKey uint64
 
 	// Call the remote method.
-	s.routedRecordMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	s.recordMetrics.BytesRequest.Put(float64(len(enc.Data())))
 	var results []byte
-	results, err = s.stub.Run(ctx, 3, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 4, enc.Data(), shardKey)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
 	}
-	s.routedRecordMetrics.BytesReply.Put(float64(len(results)))
+	s.recordMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
-type server_client_stub struct {
-	stub                codegen.Stub
-	addressMetrics      *codegen.MethodMetrics
-	proxyAddressMetrics *codegen.MethodMetrics
-	shutdownMetrics     *codegen.MethodMetrics
-}
+func (s destination_client_stub) RecordAll(ctx context.Context, a0 string, a1 *weaver.Stream[string]) (r0 int, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 int
+		err := intercept(ctx, "RecordAll", func(ctx context.Context) (err error) {
+			r0, err = s.RecordAll(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
 
-func (s server_client_stub) Address(ctx context.Context) (r0 string, err error) {
 	// Update metrics.
 	start := time.Now()
-	s.addressMetrics.Count.Add(1)
+	s.recordAllMetrics.Count.Add(1)
 
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "simple.Server.Address", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.RecordAll", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -474,41 +929,81 @@ func (s server_client_stub) Address(ctx context.Context) (r0 string, err error)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
-			s.addressMetrics.ErrorCount.Add(1)
+			s.recordAllMetrics.ErrorCount.Add(1)
 		}
 		span.End()
 
-		s.addressMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+		s.recordAllMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
 	}()
 
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += (4 + len(a0))
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
+
+	// Encode arguments.
+	enc.String(a0)
 	var shardKey uint64
 
 	// Call the remote method.
-	s.addressMetrics.BytesRequest.Put(0)
-	var results []byte
-	results, err = s.stub.Run(ctx, 0, nil, shardKey)
+	s.recordAllMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var stream codegen.ClientStream
+	stream, err = s.stub.Stream(ctx, 5, enc.Data(), shardKey)
 	if err != nil {
+		a1.Close()
 		err = errors.Join(weaver.RemoteCallError, err)
 		return
 	}
-	s.addressMetrics.BytesReply.Put(float64(len(results)))
+
+	// Send the stream argument.
+	argErr := codegen.SendArgs(stream, a1.Next, a1.Close, func(enc *codegen.Encoder, v string) {
+		enc.String(v)
+	})
+
+	// Wait for the results.
+	var results []byte
+	results, err = stream.Result()
+	if err != nil {
+		if argErr := argErr(); argErr != nil {
+			// The call was canceled because the stream argument failed.
+			err = argErr
+		}
+		return
+	}
+	s.recordAllMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = dec.String()
+	dec := codegen.NewAliasingDecoder(results)
+	r0 = dec.Int()
 	err = dec.Error()
 	return
 }
 
-func (s server_client_stub) ProxyAddress(ctx context.Context) (r0 string, err error) {
+func (s destination_client_stub) RoutedRecord(ctx context.Context, a0 string, a1 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "RoutedRecord", func(ctx context.Context) (err error) {
+			err = s.RoutedRecord(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
-	s.proxyAddressMetrics.Count.Add(1)
+	s.routedRecordMetrics.Count.Add(1)
 
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "simple.Server.ProxyAddress", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.RoutedRecord", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -523,17 +1018,325 @@ func (s server_client_stub) ProxyAddress(ctx context.Context) (r0 string, err er
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
-			s.proxyAddressMetrics.ErrorCount.Add(1)
+			s.routedRecordMetrics.ErrorCount.Add(1)
 		}
 		span.End()
 
-		s.proxyAddressMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+		s.routedRecordMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
 	}()
 
-	var shardKey uint64
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += (4 + len(a0))
+	size += (4 + len(a1))
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
+
+	// Encode arguments.
+	enc.String(a0)
+	enc.String(a1)
+
+	// Set the shardKey.
+	var r destRouter
+	shardKey := _hashDestination(r.RoutedRecord(ctx, a0, a1))
 
 	// Call the remote method.
-	s.proxyAddressMetrics.BytesRequest.Put(0)
+	s.routedRecordMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 6, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.routedRecordMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewAliasingDecoder(results)
+	err = dec.Error()
+	return
+}
+
+func (s destination_client_stub) Scan(ctx context.Context, a0 string) (r0 *weaver.Stream[string], err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		var r0 *weaver.Stream[string]
+		err := intercept(ctx, "Scan", func(ctx context.Context) (err error) {
+			r0, err = s.Scan(ctx, a0)
+			return
+		})
+		return r0, err
+	}
+
+	// Update metrics.
+	start := time.Now()
+	s.scanMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Destination.Scan", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.scanMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.scanMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += (4 + len(a0))
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
+
+	// Encode arguments.
+	enc.String(a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.scanMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var stream codegen.ClientStream
+	stream, err = s.stub.Stream(ctx, 7, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	var header []byte
+	header, err = stream.Recv()
+	if err == io.EOF {
+		err = errors.Join(weaver.RemoteCallError, io.ErrUnexpectedEOF)
+	}
+	if err != nil {
+		stream.Close()
+		return
+	}
+	s.scanMetrics.BytesReply.Put(float64(len(header)))
+	if err = codegen.NewDecoder(header).Error(); err != nil {
+		stream.Close()
+		return
+	}
+	r0 = weaver.NewStream(codegen.RecvStream(codegen.RecvResults(stream), func(dec *codegen.Decoder) (v string) {
+		v = dec.String()
+		return
+	}), stream.Close)
+	return
+}
+
+type paginator_string_03f34b4e_client_stub struct {
+	stub            codegen.Stub
+	interceptor     codegen.Interceptor
+	paginateMetrics *codegen.MethodMetrics
+}
+
+func (s paginator_string_03f34b4e_client_stub) Paginate(ctx context.Context, a0 []string, a1 int) (r0 []Page[string], err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		var r0 []Page[string]
+		err := intercept(ctx, "Paginate", func(ctx context.Context) (err error) {
+			r0, err = s.Paginate(ctx, a0, a1)
+			return
+		})
+		return r0, err
+	}
+
+	// Update metrics.
+	start := time.Now()
+	s.paginateMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Paginator[string].Paginate", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.paginateMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.paginateMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	// Encode arguments.
+	enc := codegen.GetEncoder(0)
+	defer codegen.PutEncoder(enc)
+	serviceweaver_enc_slice_string_4af10117(enc, a0)
+	enc.Int(a1)
+	var shardKey uint64
+
+	// Call the remote method.
+	s.paginateMetrics.BytesRequest.Put(float64(len(enc.Data())))
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.paginateMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewAliasingDecoder(results)
+	r0 = serviceweaver_dec_slice_Page_string_1d5accc4(dec)
+	err = dec.Error()
+	return
+}
+
+type server_client_stub struct {
+	stub                codegen.Stub
+	interceptor         codegen.Interceptor
+	addressMetrics      *codegen.MethodMetrics
+	proxyAddressMetrics *codegen.MethodMetrics
+	shutdownMetrics     *codegen.MethodMetrics
+}
+
+func (s server_client_stub) Address(ctx context.Context) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 string
+		err := intercept(ctx, "Address", func(ctx context.Context) (err error) {
+			r0, err = s.Address(ctx)
+			return
+		})
+		return r0, err
+	}
+
+	// Update metrics.
+	start := time.Now()
+	s.addressMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Server.Address", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.addressMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.addressMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	var shardKey uint64
+
+	// Call the remote method.
+	s.addressMetrics.BytesRequest.Put(0)
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, nil, shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+	s.addressMetrics.BytesReply.Put(float64(len(results)))
+
+	// Decode the results.
+	dec := codegen.NewAliasingDecoder(results)
+	r0 = dec.String()
+	err = dec.Error()
+	return
+}
+
+func (s server_client_stub) ProxyAddress(ctx context.Context) (r0 string, err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		var r0 string
+		err := intercept(ctx, "ProxyAddress", func(ctx context.Context) (err error) {
+			r0, err = s.ProxyAddress(ctx)
+			return
+		})
+		return r0, err
+	}
+
+	// Update metrics.
+	start := time.Now()
+	s.proxyAddressMetrics.Count.Add(1)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Server.ProxyAddress", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.proxyAddressMetrics.ErrorCount.Add(1)
+		}
+		span.End()
+
+		s.proxyAddressMetrics.Latency.Put(float64(time.Since(start).Microseconds()))
+	}()
+
+	var shardKey uint64
+
+	// Call the remote method.
+	s.proxyAddressMetrics.BytesRequest.Put(0)
 	var results []byte
 	results, err = s.stub.Run(ctx, 1, nil, shardKey)
 	if err != nil {
@@ -543,13 +1346,26 @@ func (s server_client_stub) ProxyAddress(ctx context.Context) (r0 string, err er
 	s.proxyAddressMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
 }
 
 func (s server_client_stub) Shutdown(ctx context.Context) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		err := intercept(ctx, "Shutdown", func(ctx context.Context) (err error) {
+			err = s.Shutdown(ctx)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.shutdownMetrics.Count.Add(1)
@@ -592,17 +1408,33 @@ func (s server_client_stub) Shutdown(ctx context.Context) (err error) {
 	s.shutdownMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
 
 type source_client_stub struct {
 	stub        codegen.Stub
+	interceptor codegen.Interceptor
 	emitMetrics *codegen.MethodMetrics
 }
 
 func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err error) {
+	if s.interceptor != nil {
+		// Run the call through the interceptor. The arguments and results
+		// are copied, so that only the copies escape to the heap.
+		intercept := s.interceptor
+		s := s
+		s.interceptor = nil
+		a0 := a0
+		a1 := a1
+		err := intercept(ctx, "Emit", func(ctx context.Context) (err error) {
+			err = s.Emit(ctx, a0, a1)
+			return
+		})
+		return err
+	}
+
 	// Update metrics.
 	start := time.Now()
 	s.emitMetrics.Count.Add(1)
@@ -636,8 +1468,8 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 	size := 0
 	size += (4 + len(a0))
 	size += (4 + len(a1))
-	enc := codegen.NewEncoder()
-	enc.Reset(size)
+	enc := codegen.GetEncoder(size)
+	defer codegen.PutEncoder(enc)
 
 	// Encode arguments.
 	enc.String(a0)
@@ -655,7 +1487,7 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 	s.emitMetrics.BytesReply.Put(float64(len(results)))
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewAliasingDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -663,17 +1495,22 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 // Server stub implementations.
 
 type destination_server_stub struct {
-	impl    Destination
-	addLoad func(key uint64, load float64)
+	impl        Destination
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
 func (s destination_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
 	switch method {
+	case "Apply":
+		return s.apply
 	case "GetAll":
 		return s.getAll
 	case "Getpid":
 		return s.getpid
+	case "Metadata":
+		return s.metadata
 	case "Record":
 		return s.record
 	case "RoutedRecord":
@@ -683,6 +1520,60 @@ func (s destination_server_stub) GetStubFn(method string) func(ctx context.Conte
 	}
 }
 
+// GetStreamStubFn implements the codegen.StreamServer interface.
+func (s destination_server_stub) GetStreamStubFn(method string) func(ctx context.Context, args []byte, stream codegen.ServerStream) ([]byte, error) {
+	switch method {
+	case "RecordAll":
+		return s.recordAll
+	case "Scan":
+		return s.scan
+	default:
+		return nil
+	}
+}
+
+func (s destination_server_stub) apply(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewAliasingDecoder(args)
+	var a0 string
+	a0 = dec.String()
+	var a1 []Event
+	a1 = serviceweaver_dec_slice_Event_8f06820c(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	var r0 Event
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Apply(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 Event
+		appErr = s.interceptor(ctx, "Apply", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Apply(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.EncodeInterface(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
 func (s destination_server_stub) getAll(ctx context.Context, args []byte) (res []byte, err error) {
 	// Catch and return any panics detected during encoding/decoding/rpc.
 	defer func() {
@@ -692,14 +1583,28 @@ func (s destination_server_stub) getAll(ctx context.Context, args []byte) (res [
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.GetAll(ctx, a0)
+	var r0 []string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.GetAll(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 []string
+		appErr = s.interceptor(ctx, "GetAll", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.GetAll(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -719,7 +1624,20 @@ func (s destination_server_stub) getpid(ctx context.Context, args []byte) (res [
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Getpid(ctx)
+	var r0 int
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Getpid(ctx)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		var ir0 int
+		appErr = s.interceptor(ctx, "Getpid", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Getpid(ctx)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -728,6 +1646,45 @@ func (s destination_server_stub) getpid(ctx context.Context, args []byte) (res [
 	return enc.Data(), nil
 }
 
+func (s destination_server_stub) metadata(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewAliasingDecoder(args)
+	var a0 string
+	a0 = dec.String()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	var r0 string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Metadata(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 string
+		appErr = s.interceptor(ctx, "Metadata", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Metadata(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.String(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
 func (s destination_server_stub) record(ctx context.Context, args []byte) (res []byte, err error) {
 	// Catch and return any panics detected during encoding/decoding/rpc.
 	defer func() {
@@ -737,7 +1694,7 @@ func (s destination_server_stub) record(ctx context.Context, args []byte) (res [
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 string
@@ -746,7 +1703,19 @@ func (s destination_server_stub) record(ctx context.Context, args []byte) (res [
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.Record(ctx, a0, a1)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.Record(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		appErr = s.interceptor(ctx, "Record", func(ctx context.Context) (err error) {
+			err = s.impl.Record(ctx, a0, a1)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -754,6 +1723,52 @@ func (s destination_server_stub) record(ctx context.Context, args []byte) (res [
 	return enc.Data(), nil
 }
 
+func (s destination_server_stub) recordAll(ctx context.Context, args []byte, stream codegen.ServerStream) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewAliasingDecoder(args)
+	var a0 string
+	a0 = dec.String()
+
+	// Receive the stream argument.
+	a1 := weaver.NewStream(codegen.RecvStream(stream.Recv, func(dec *codegen.Decoder) (v string) {
+		v = dec.String()
+		return
+	}), nil)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	var r0 int
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.RecordAll(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 int
+		appErr = s.interceptor(ctx, "RecordAll", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.RecordAll(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
 func (s destination_server_stub) routedRecord(ctx context.Context, args []byte) (res []byte, err error) {
 	// Catch and return any panics detected during encoding/decoding/rpc.
 	defer func() {
@@ -763,7 +1778,7 @@ func (s destination_server_stub) routedRecord(ctx context.Context, args []byte)
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 string
@@ -774,7 +1789,19 @@ func (s destination_server_stub) routedRecord(ctx context.Context, args []byte)
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.RoutedRecord(ctx, a0, a1)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.RoutedRecord(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		appErr = s.interceptor(ctx, "RoutedRecord", func(ctx context.Context) (err error) {
+			err = s.impl.RoutedRecord(ctx, a0, a1)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -782,9 +1809,118 @@ func (s destination_server_stub) routedRecord(ctx context.Context, args []byte)
 	return enc.Data(), nil
 }
 
+func (s destination_server_stub) scan(ctx context.Context, args []byte, stream codegen.ServerStream) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewAliasingDecoder(args)
+	var a0 string
+	a0 = dec.String()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	var r0 *weaver.Stream[string]
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Scan(ctx, a0)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		var ir0 *weaver.Stream[string]
+		appErr = s.interceptor(ctx, "Scan", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Scan(ctx, a0)
+			return
+		})
+		r0 = ir0
+	}
+	defer r0.Close()
+
+	// Send the results.
+	enc := codegen.NewEncoder()
+	enc.Error(appErr)
+	if err := stream.Send(enc.Data()); err != nil || appErr != nil {
+		return nil, err
+	}
+	streamErr, err := codegen.SendStream(stream.Send, r0.Next, func(enc *codegen.Encoder, v string) {
+		enc.String(v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	enc = codegen.NewEncoder()
+	enc.Error(streamErr)
+	return enc.Data(), nil
+}
+
+type paginator_string_03f34b4e_server_stub struct {
+	impl        Paginator[string]
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
+}
+
+// GetStubFn implements the stub.Server interface.
+func (s paginator_string_03f34b4e_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	case "Paginate":
+		return s.paginate
+	default:
+		return nil
+	}
+}
+
+func (s paginator_string_03f34b4e_server_stub) paginate(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewAliasingDecoder(args)
+	var a0 []string
+	a0 = serviceweaver_dec_slice_string_4af10117(dec)
+	var a1 int
+	a1 = dec.Int()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	var r0 []Page[string]
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Paginate(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		var ir0 []Page[string]
+		appErr = s.interceptor(ctx, "Paginate", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Paginate(ctx, a0, a1)
+			return
+		})
+		r0 = ir0
+	}
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_slice_Page_string_1d5accc4(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
 type server_server_stub struct {
-	impl    Server
-	addLoad func(key uint64, load float64)
+	impl        Server
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -812,7 +1948,20 @@ func (s server_server_stub) address(ctx context.Context, args []byte) (res []byt
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.Address(ctx)
+	var r0 string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.Address(ctx)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		var ir0 string
+		appErr = s.interceptor(ctx, "Address", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.Address(ctx)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -832,7 +1981,20 @@ func (s server_server_stub) proxyAddress(ctx context.Context, args []byte) (res
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	r0, appErr := s.impl.ProxyAddress(ctx)
+	var r0 string
+	var appErr error
+	if s.interceptor == nil {
+		r0, appErr = s.impl.ProxyAddress(ctx)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		var ir0 string
+		appErr = s.interceptor(ctx, "ProxyAddress", func(ctx context.Context) (err error) {
+			ir0, err = s.impl.ProxyAddress(ctx)
+			return
+		})
+		r0 = ir0
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -852,7 +2014,17 @@ func (s server_server_stub) shutdown(ctx context.Context, args []byte) (res []by
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.Shutdown(ctx)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.Shutdown(ctx)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		appErr = s.interceptor(ctx, "Shutdown", func(ctx context.Context) (err error) {
+			err = s.impl.Shutdown(ctx)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -861,8 +2033,9 @@ func (s server_server_stub) shutdown(ctx context.Context, args []byte) (res []by
 }
 
 type source_server_stub struct {
-	impl    Source
-	addLoad func(key uint64, load float64)
+	impl        Source
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.
@@ -884,7 +2057,7 @@ func (s source_server_stub) emit(ctx context.Context, args []byte) (res []byte,
 	}()
 
 	// Decode arguments.
-	dec := codegen.NewDecoder(args)
+	dec := codegen.NewAliasingDecoder(args)
 	var a0 string
 	a0 = dec.String()
 	var a1 string
@@ -893,7 +2066,19 @@ func (s source_server_stub) emit(ctx context.Context, args []byte) (res []byte,
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
 	// Call the local method.
-	appErr := s.impl.Emit(ctx, a0, a1)
+	var appErr error
+	if s.interceptor == nil {
+		appErr = s.impl.Emit(ctx, a0, a1)
+	} else {
+		// Run the call through the interceptor. The arguments and
+		// results are copied, so that only the copies escape to the heap.
+		a0 := a0
+		a1 := a1
+		appErr = s.interceptor(ctx, "Emit", func(ctx context.Context) (err error) {
+			err = s.impl.Emit(ctx, a0, a1)
+			return
+		})
+	}
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
@@ -901,6 +2086,199 @@ func (s source_server_stub) emit(ctx context.Context, args []byte) (res []byte,
 	return enc.Data(), nil
 }
 
+// Fake implementations.
+
+// FakeDestination is a fake implementation of the Destination component.
+// It is meant to be used with weavertest.Fake. Every method calls the
+// corresponding function field. Methods whose function field is nil return
+// zero values.
+type FakeDestination struct {
+	ApplyFn        func(ctx context.Context, a0 string, a1 []Event) (r0 Event, err error)
+	GetAllFn       func(ctx context.Context, a0 string) (r0 []string, err error)
+	GetpidFn       func(ctx context.Context) (r0 int, err error)
+	MetadataFn     func(ctx context.Context, a0 string) (r0 string, err error)
+	RecordFn       func(ctx context.Context, a0 string, a1 string) (err error)
+	RecordAllFn    func(ctx context.Context, a0 string, a1 *weaver.Stream[string]) (r0 int, err error)
+	RoutedRecordFn func(ctx context.Context, a0 string, a1 string) (err error)
+	ScanFn         func(ctx context.Context, a0 string) (r0 *weaver.Stream[string], err error)
+}
+
+var _ Destination = FakeDestination{}
+
+func (f FakeDestination) Apply(ctx context.Context, a0 string, a1 []Event) (r0 Event, err error) {
+	if f.ApplyFn == nil {
+		return
+	}
+	return f.ApplyFn(ctx, a0, a1)
+}
+
+func (f FakeDestination) GetAll(ctx context.Context, a0 string) (r0 []string, err error) {
+	if f.GetAllFn == nil {
+		return
+	}
+	return f.GetAllFn(ctx, a0)
+}
+
+func (f FakeDestination) Getpid(ctx context.Context) (r0 int, err error) {
+	if f.GetpidFn == nil {
+		return
+	}
+	return f.GetpidFn(ctx)
+}
+
+func (f FakeDestination) Metadata(ctx context.Context, a0 string) (r0 string, err error) {
+	if f.MetadataFn == nil {
+		return
+	}
+	return f.MetadataFn(ctx, a0)
+}
+
+func (f FakeDestination) Record(ctx context.Context, a0 string, a1 string) (err error) {
+	if f.RecordFn == nil {
+		return
+	}
+	return f.RecordFn(ctx, a0, a1)
+}
+
+func (f FakeDestination) RecordAll(ctx context.Context, a0 string, a1 *weaver.Stream[string]) (r0 int, err error) {
+	if f.RecordAllFn == nil {
+		return
+	}
+	return f.RecordAllFn(ctx, a0, a1)
+}
+
+func (f FakeDestination) RoutedRecord(ctx context.Context, a0 string, a1 string) (err error) {
+	if f.RoutedRecordFn == nil {
+		return
+	}
+	return f.RoutedRecordFn(ctx, a0, a1)
+}
+
+func (f FakeDestination) Scan(ctx context.Context, a0 string) (r0 *weaver.Stream[string], err error) {
+	if f.ScanFn == nil {
+		return
+	}
+	return f.ScanFn(ctx, a0)
+}
+
+// FakeServer is a fake implementation of the Server component.
+// It is meant to be used with weavertest.Fake. Every method calls the
+// corresponding function field. Methods whose function field is nil return
+// zero values.
+type FakeServer struct {
+	AddressFn      func(ctx context.Context) (r0 string, err error)
+	ProxyAddressFn func(ctx context.Context) (r0 string, err error)
+	ShutdownFn     func(ctx context.Context) (err error)
+}
+
+var _ Server = FakeServer{}
+
+func (f FakeServer) Address(ctx context.Context) (r0 string, err error) {
+	if f.AddressFn == nil {
+		return
+	}
+	return f.AddressFn(ctx)
+}
+
+func (f FakeServer) ProxyAddress(ctx context.Context) (r0 string, err error) {
+	if f.ProxyAddressFn == nil {
+		return
+	}
+	return f.ProxyAddressFn(ctx)
+}
+
+func (f FakeServer) Shutdown(ctx context.Context) (err error) {
+	if f.ShutdownFn == nil {
+		return
+	}
+	return f.ShutdownFn(ctx)
+}
+
+// FakeSource is a fake implementation of the Source component.
+// It is meant to be used with weavertest.Fake. Every method calls the
+// corresponding function field. Methods whose function field is nil return
+// zero values.
+type FakeSource struct {
+	EmitFn func(ctx context.Context, a0 string, a1 string) (err error)
+}
+
+var _ Source = FakeSource{}
+
+func (f FakeSource) Emit(ctx context.Context, a0 string, a1 string) (err error) {
+	if f.EmitFn == nil {
+		return
+	}
+	return f.EmitFn(ctx, a0, a1)
+}
+
+// AutoMarshal implementations.
+
+var _ codegen.AutoMarshal = &Append{}
+
+func (x *Append) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("Append.WeaverMarshal: nil receiver"))
+	}
+	var start int
+	start = enc.BeginField(1)
+	enc.String(x.Msg)
+	enc.EndField(start)
+	enc.EndFields()
+}
+
+func (x *Append) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("Append.WeaverUnmarshal: nil receiver"))
+	}
+	*x = Append{}
+	for {
+		num, field := dec.Field()
+		switch num {
+		case 0:
+			return
+		case 1:
+			x.Msg = field.String()
+		}
+	}
+}
+
+var _ codegen.AutoMarshal = &NotFoundError{}
+
+func (x *NotFoundError) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("NotFoundError.WeaverMarshal: nil receiver"))
+	}
+	enc.String(x.File)
+}
+
+func (x *NotFoundError) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("NotFoundError.WeaverUnmarshal: nil receiver"))
+	}
+	x.File = dec.String()
+}
+
+var _ codegen.AutoMarshal = &Truncate{}
+
+func (x *Truncate) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("Truncate.WeaverMarshal: nil receiver"))
+	}
+}
+
+func (x *Truncate) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("Truncate.WeaverUnmarshal: nil receiver"))
+	}
+}
+
+func init() {
+	codegen.RegisterType[Append]()
+	codegen.RegisterType[NotFoundError]()
+	codegen.RegisterSerializableError[NotFoundError]()
+	codegen.RegisterType[Truncate]()
+}
+
 // Router methods.
 
 // _hashDestination returns a 64 bit hash of the provided value.
@@ -919,6 +2297,29 @@ func _orderedCodeDestination(r string) codegen.OrderedCode {
 
 // Encoding/decoding implementations.
 
+func serviceweaver_enc_slice_Event_8f06820c(enc *codegen.Encoder, arg []Event) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for i := 0; i < len(arg); i++ {
+		enc.EncodeInterface(arg[i])
+	}
+}
+
+func serviceweaver_dec_slice_Event_8f06820c(dec *codegen.Decoder) []Event {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make([]Event, n)
+	for i := 0; i < n; i++ {
+		res[i] = codegen.DecodeInterface[Event](dec)
+	}
+	return res
+}
+
 func serviceweaver_enc_slice_string_4af10117(enc *codegen.Encoder, arg []string) {
 	if arg == nil {
 		enc.Len(-1)
@@ -941,3 +2342,36 @@ func serviceweaver_dec_slice_string_4af10117(dec *codegen.Decoder) []string {
 	}
 	return res
 }
+
+func serviceweaver_enc_Page_string_dfe97b55(enc *codegen.Encoder, x *Page[string]) {
+	enc.Int(x.Number)
+	serviceweaver_enc_slice_string_4af10117(enc, x.Values)
+}
+
+func serviceweaver_dec_Page_string_dfe97b55(dec *codegen.Decoder, x *Page[string]) {
+	x.Number = dec.Int()
+	x.Values = serviceweaver_dec_slice_string_4af10117(dec)
+}
+
+func serviceweaver_enc_slice_Page_string_1d5accc4(enc *codegen.Encoder, arg []Page[string]) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for i := 0; i < len(arg); i++ {
+		serviceweaver_enc_Page_string_dfe97b55(enc, &arg[i])
+	}
+}
+
+func serviceweaver_dec_slice_Page_string_1d5accc4(dec *codegen.Decoder) []Page[string] {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make([]Page[string], n)
+	for i := 0; i < n; i++ {
+		serviceweaver_dec_Page_string_dfe97b55(dec, &res[i])
+	}
+	return res
+}
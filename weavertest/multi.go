@@ -18,9 +18,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"regexp"
 	"strings"
 
+	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/runtime"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
@@ -37,8 +39,13 @@ import (
 // when deploying an application. It can contain application level as well as
 // component level configs. config is allowed to be empty.
 //
+// fakes contains fake component implementations, keyed by component interface
+// type. Faked components are run in the main process, alongside the test.
+//
+// interceptors are passed to weaver.Run in every child process.
+//
 // Future extension: allow options so the user can control collocation/replication/etc.
-func initMultiProcess(ctx context.Context, name string, config string, logWriter func(string)) (context.Context, func() error, error) {
+func initMultiProcess(ctx context.Context, name string, config string, fakes map[reflect.Type]any, interceptors []weaver.Interceptor, logWriter func(string)) (context.Context, func() error, error) {
 	bootstrap, err := runtime.GetBootstrap(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -55,7 +62,7 @@ func initMultiProcess(ctx context.Context, name string, config string, logWriter
 		}()
 		err := runWeaver(ctx, func(context.Context, any) error {
 			return nil
-		})
+		}, interceptors)
 		if err != nil {
 			panic(err)
 		}
@@ -88,7 +95,7 @@ func initMultiProcess(ctx context.Context, name string, config string, logWriter
 	}
 
 	// Launch the deployer.
-	d := newDeployer(ctx, wlet, appConfig, logWriter)
+	d := newDeployer(ctx, wlet, appConfig, fakes, logWriter)
 	if err := d.start(config); err != nil {
 		return nil, nil, err
 	}
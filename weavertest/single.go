@@ -16,6 +16,7 @@ package weavertest
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/ServiceWeaver/weaver/runtime"
 )
@@ -25,8 +26,12 @@ import (
 // config contains configuration identical to what might be found in a file passed
 // when deploying an application. It can contain application level as well as
 // component level configs. config is allowed to be empty.
-func initSingleProcess(ctx context.Context, config string) context.Context {
+//
+// fakes contains fake component implementations, keyed by component interface
+// type.
+func initSingleProcess(ctx context.Context, config string, fakes map[reflect.Type]any) context.Context {
 	return context.WithValue(ctx, runtime.BootstrapKey{}, runtime.Bootstrap{
 		TestConfig: config,
+		Fakes:      fakes,
 	})
 }
@@ -12,37 +12,47 @@ import (
 
 func init() {
 	codegen.Register(codegen.Registration{
-		Name:  "github.com/ServiceWeaver/weaver/weavertest/testMainInterface",
-		Iface: reflect.TypeOf((*testMainInterface)(nil)).Elem(),
-		Impl:  reflect.TypeOf(testMain{}),
-		LocalStubFn: func(impl any, tracer trace.Tracer) any {
-			return testMainInterface_local_stub{impl: impl.(testMainInterface), tracer: tracer}
+		Name:    "github.com/ServiceWeaver/weaver/weavertest/testMainInterface",
+		Iface:   reflect.TypeOf((*testMainInterface)(nil)).Elem(),
+		Impl:    reflect.TypeOf(testMain{}),
+		RefData: "⟦a0c95ac2:wEaVeRcOmPoNeNt:github.com/ServiceWeaver/weaver/weavertest/testMainInterface:⟧\n",
+		LocalStubFn: func(impl any, tracer trace.Tracer, interceptor codegen.Interceptor) any {
+			return testMainInterface_local_stub{impl: impl.(testMainInterface), tracer: tracer, interceptor: interceptor}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any { return testMainInterface_client_stub{stub: stub} },
-		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
-			return testMainInterface_server_stub{impl: impl.(testMainInterface), addLoad: addLoad}
+		ClientStubFn: func(stub codegen.Stub, caller string, interceptor codegen.Interceptor) any {
+			return testMainInterface_client_stub{stub: stub, interceptor: interceptor}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64), interceptor codegen.Interceptor) codegen.Server {
+			return testMainInterface_server_stub{impl: impl.(testMainInterface), addLoad: addLoad, interceptor: interceptor}
 		},
 	})
 }
 
+func init() {
+	codegen.EmbedSchema("⟦1c493d33:wEaVeRsChEmA:{\"components\":[{\"name\":\"github.com/ServiceWeaver/weaver/weavertest/testMainInterface\",\"methods\":[]}]}⟧\n")
+}
+
 // Local stub implementations.
 
 type testMainInterface_local_stub struct {
-	impl   testMainInterface
-	tracer trace.Tracer
+	impl        testMainInterface
+	tracer      trace.Tracer
+	interceptor codegen.Interceptor
 }
 
 // Client stub implementations.
 
 type testMainInterface_client_stub struct {
-	stub codegen.Stub
+	stub        codegen.Stub
+	interceptor codegen.Interceptor
 }
 
 // Server stub implementations.
 
 type testMainInterface_server_stub struct {
-	impl    testMainInterface
-	addLoad func(key uint64, load float64)
+	impl        testMainInterface
+	addLoad     func(key uint64, load float64)
+	interceptor codegen.Interceptor
 }
 
 // GetStubFn implements the stub.Server interface.